@@ -0,0 +1,1678 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: admin/connection.proto
+
+package admin
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	github_com_onosproject_onos_api_go_onos_topo "github.com/onosproject/onos-api/go/onos/topo"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListConnectionsRequest struct {
+	// target_id limits the response to the given target, if set
+	TargetID github_com_onosproject_onos_api_go_onos_topo.ID `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3,casttype=github.com/onosproject/onos-api/go/onos/topo.ID" json:"target_id,omitempty"`
+}
+
+func (m *ListConnectionsRequest) Reset()         { *m = ListConnectionsRequest{} }
+func (m *ListConnectionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListConnectionsRequest) ProtoMessage()    {}
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7036cc13505265d5, []int{0}
+}
+func (m *ListConnectionsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListConnectionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListConnectionsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListConnectionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConnectionsRequest.Merge(m, src)
+}
+func (m *ListConnectionsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListConnectionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConnectionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConnectionsRequest proto.InternalMessageInfo
+
+func (m *ListConnectionsRequest) GetTargetID() github_com_onosproject_onos_api_go_onos_topo.ID {
+	if m != nil {
+		return m.TargetID
+	}
+	return ""
+}
+
+type ListConnectionsResponse struct {
+	Connection *Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+}
+
+func (m *ListConnectionsResponse) Reset()         { *m = ListConnectionsResponse{} }
+func (m *ListConnectionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListConnectionsResponse) ProtoMessage()    {}
+func (*ListConnectionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7036cc13505265d5, []int{1}
+}
+func (m *ListConnectionsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListConnectionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListConnectionsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListConnectionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConnectionsResponse.Merge(m, src)
+}
+func (m *ListConnectionsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListConnectionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConnectionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConnectionsResponse proto.InternalMessageInfo
+
+func (m *ListConnectionsResponse) GetConnection() *Connection {
+	if m != nil {
+		return m.Connection
+	}
+	return nil
+}
+
+type WatchConnectionsRequest struct {
+	// target_id limits the changes to the given target, if set
+	TargetID github_com_onosproject_onos_api_go_onos_topo.ID `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3,casttype=github.com/onosproject/onos-api/go/onos/topo.ID" json:"target_id,omitempty"`
+}
+
+func (m *WatchConnectionsRequest) Reset()         { *m = WatchConnectionsRequest{} }
+func (m *WatchConnectionsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchConnectionsRequest) ProtoMessage()    {}
+func (*WatchConnectionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7036cc13505265d5, []int{2}
+}
+func (m *WatchConnectionsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchConnectionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchConnectionsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchConnectionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchConnectionsRequest.Merge(m, src)
+}
+func (m *WatchConnectionsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchConnectionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchConnectionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchConnectionsRequest proto.InternalMessageInfo
+
+func (m *WatchConnectionsRequest) GetTargetID() github_com_onosproject_onos_api_go_onos_topo.ID {
+	if m != nil {
+		return m.TargetID
+	}
+	return ""
+}
+
+type WatchConnectionsResponse struct {
+	Connection *Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+}
+
+func (m *WatchConnectionsResponse) Reset()         { *m = WatchConnectionsResponse{} }
+func (m *WatchConnectionsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchConnectionsResponse) ProtoMessage()    {}
+func (*WatchConnectionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7036cc13505265d5, []int{3}
+}
+func (m *WatchConnectionsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchConnectionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchConnectionsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchConnectionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchConnectionsResponse.Merge(m, src)
+}
+func (m *WatchConnectionsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchConnectionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchConnectionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchConnectionsResponse proto.InternalMessageInfo
+
+func (m *WatchConnectionsResponse) GetConnection() *Connection {
+	if m != nil {
+		return m.Connection
+	}
+	return nil
+}
+
+// Connection is the southbound connection status of a target as seen by an onos-config node
+type Connection struct {
+	TargetID github_com_onosproject_onos_api_go_onos_topo.ID `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3,casttype=github.com/onosproject/onos-api/go/onos/topo.ID" json:"target_id,omitempty"`
+	// conn_id is the ID of the connection; empty if the target is not connected
+	ConnID string `protobuf:"bytes,2,opt,name=conn_id,json=connId,proto3" json:"conn_id,omitempty"`
+	// state is the state of the gRPC channel to the target
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// dial_out indicates the target initiates the connection
+	DialOut bool `protobuf:"varint,4,opt,name=dial_out,json=dialOut,proto3" json:"dial_out,omitempty"`
+	// connected is the time at which the current connection was established
+	Connected *time.Time `protobuf:"bytes,5,opt,name=connected,proto3,stdtime" json:"connected,omitempty"`
+	// last_error is the last error caused by the connection to the target, if any
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// last_error_time is the time at which the last error occurred
+	LastErrorTime *time.Time `protobuf:"bytes,7,opt,name=last_error_time,json=lastErrorTime,proto3,stdtime" json:"last_error_time,omitempty"`
+	// reconnects is the number of times the target has been reconnected
+	Reconnects uint64 `protobuf:"varint,8,opt,name=reconnects,proto3" json:"reconnects,omitempty"`
+	// node is the ID of the onos-config node reporting the status
+	Node string `protobuf:"bytes,9,opt,name=node,proto3" json:"node,omitempty"`
+	// master is the ID of the onos-config node holding the master relation for the target, if any
+	Master string `protobuf:"bytes,10,opt,name=master,proto3" json:"master,omitempty"`
+}
+
+func (m *Connection) Reset()         { *m = Connection{} }
+func (m *Connection) String() string { return proto.CompactTextString(m) }
+func (*Connection) ProtoMessage()    {}
+func (*Connection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7036cc13505265d5, []int{4}
+}
+func (m *Connection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Connection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Connection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Connection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Connection.Merge(m, src)
+}
+func (m *Connection) XXX_Size() int {
+	return m.Size()
+}
+func (m *Connection) XXX_DiscardUnknown() {
+	xxx_messageInfo_Connection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Connection proto.InternalMessageInfo
+
+func (m *Connection) GetTargetID() github_com_onosproject_onos_api_go_onos_topo.ID {
+	if m != nil {
+		return m.TargetID
+	}
+	return ""
+}
+
+func (m *Connection) GetConnID() string {
+	if m != nil {
+		return m.ConnID
+	}
+	return ""
+}
+
+func (m *Connection) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Connection) GetDialOut() bool {
+	if m != nil {
+		return m.DialOut
+	}
+	return false
+}
+
+func (m *Connection) GetConnected() *time.Time {
+	if m != nil {
+		return m.Connected
+	}
+	return nil
+}
+
+func (m *Connection) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *Connection) GetLastErrorTime() *time.Time {
+	if m != nil {
+		return m.LastErrorTime
+	}
+	return nil
+}
+
+func (m *Connection) GetReconnects() uint64 {
+	if m != nil {
+		return m.Reconnects
+	}
+	return 0
+}
+
+func (m *Connection) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Connection) GetMaster() string {
+	if m != nil {
+		return m.Master
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListConnectionsRequest)(nil), "onos.config.admin.ListConnectionsRequest")
+	proto.RegisterType((*ListConnectionsResponse)(nil), "onos.config.admin.ListConnectionsResponse")
+	proto.RegisterType((*WatchConnectionsRequest)(nil), "onos.config.admin.WatchConnectionsRequest")
+	proto.RegisterType((*WatchConnectionsResponse)(nil), "onos.config.admin.WatchConnectionsResponse")
+	proto.RegisterType((*Connection)(nil), "onos.config.admin.Connection")
+}
+
+func init() { proto.RegisterFile("admin/connection.proto", fileDescriptor_7036cc13505265d5) }
+
+var fileDescriptor_7036cc13505265d5 = []byte{
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x8d, 0x69, 0x9a, 0xc7, 0x45, 0xa8, 0x74, 0x54, 0xa5, 0x43, 0xa4, 0x3a, 0x51, 0xd8, 0x84,
+	0xd7, 0x18, 0x95, 0x35, 0x20, 0x85, 0x80, 0x88, 0x84, 0x84, 0x64, 0x2a, 0xf1, 0x10, 0x52, 0xe4,
+	0xd8, 0x53, 0x77, 0xaa, 0xd8, 0xd7, 0x78, 0x26, 0xe5, 0x37, 0xfa, 0x33, 0xfc, 0x03, 0xcb, 0x2e,
+	0x59, 0x05, 0xe4, 0xec, 0xf9, 0x00, 0x56, 0x68, 0xc6, 0x49, 0x5d, 0x35, 0x01, 0x65, 0x01, 0xdd,
+	0xdd, 0x39, 0xf7, 0xde, 0x73, 0x7c, 0x3c, 0x67, 0xa0, 0xe1, 0x05, 0x91, 0x88, 0x1d, 0x1f, 0xe3,
+	0x98, 0xfb, 0x4a, 0x60, 0xcc, 0x92, 0x14, 0x15, 0x92, 0x6d, 0x8c, 0x51, 0x32, 0x1f, 0xe3, 0x43,
+	0x11, 0x32, 0x33, 0xd3, 0x6c, 0x85, 0x88, 0xe1, 0x98, 0x3b, 0x66, 0x60, 0x34, 0x39, 0x74, 0x94,
+	0x88, 0xb8, 0x54, 0x5e, 0x94, 0xe4, 0x3b, 0xcd, 0x9d, 0x10, 0x43, 0x34, 0xa5, 0xa3, 0xab, 0x1c,
+	0xed, 0x9c, 0x40, 0xe3, 0x95, 0x90, 0xea, 0xd9, 0xb9, 0x82, 0x74, 0xf9, 0xa7, 0x09, 0x97, 0x8a,
+	0x7c, 0x84, 0xba, 0xf2, 0xd2, 0x90, 0xab, 0xa1, 0x08, 0xa8, 0xd5, 0xb6, 0xba, 0xf5, 0xde, 0xd3,
+	0x6c, 0xda, 0xaa, 0x1d, 0x18, 0x70, 0xd0, 0xff, 0x35, 0x6d, 0x39, 0xa1, 0x50, 0x47, 0x93, 0x11,
+	0xf3, 0x31, 0x72, 0xf4, 0x17, 0x25, 0x29, 0x1e, 0x73, 0x5f, 0x99, 0xfa, 0x81, 0x97, 0x08, 0x27,
+	0x44, 0x53, 0x3b, 0x0a, 0x13, 0x64, 0x83, 0xbe, 0x5b, 0xcb, 0x19, 0x07, 0x41, 0xe7, 0x1d, 0xec,
+	0x2e, 0xe9, 0xca, 0x04, 0x63, 0xc9, 0xc9, 0x63, 0x80, 0xc2, 0xb0, 0x51, 0xbe, 0xbe, 0xbf, 0xc7,
+	0x96, 0x1c, 0xb3, 0x62, 0xd7, 0xbd, 0xb0, 0xd0, 0xf9, 0x0c, 0xbb, 0x6f, 0x3d, 0xe5, 0x1f, 0x5d,
+	0xb9, 0xa5, 0xf7, 0x40, 0x97, 0x85, 0xff, 0x8d, 0xa7, 0x2f, 0x1b, 0x00, 0x45, 0xeb, 0xff, 0xfa,
+	0x20, 0xb7, 0xa1, 0xaa, 0xa5, 0x35, 0xf7, 0x35, 0xc3, 0x0d, 0xd9, 0xb4, 0x55, 0xd1, 0xf2, 0x83,
+	0xbe, 0x5b, 0xd1, 0xad, 0x41, 0x40, 0x76, 0x60, 0x53, 0x2a, 0x4f, 0x71, 0xba, 0xa1, 0x47, 0xdc,
+	0xfc, 0x40, 0x6e, 0x41, 0x2d, 0x10, 0xde, 0x78, 0x88, 0x13, 0x45, 0xcb, 0x6d, 0xab, 0x5b, 0x73,
+	0xab, 0xfa, 0xfc, 0x7a, 0xa2, 0xc8, 0x13, 0xa8, 0xcf, 0x0d, 0xf1, 0x80, 0x6e, 0x9a, 0x1f, 0xd0,
+	0x64, 0x79, 0x66, 0xd9, 0x22, 0xb3, 0xec, 0x60, 0x91, 0xd9, 0x5e, 0xf9, 0xf4, 0x7b, 0xcb, 0x72,
+	0x8b, 0x15, 0xb2, 0x07, 0x30, 0xf6, 0xa4, 0x1a, 0xf2, 0x34, 0xc5, 0x94, 0x56, 0x8c, 0x6a, 0x5d,
+	0x23, 0xcf, 0x35, 0x40, 0x5e, 0xc2, 0x56, 0xd1, 0x1e, 0xea, 0xec, 0xd3, 0xea, 0x9a, 0x22, 0x37,
+	0xce, 0x59, 0x74, 0x87, 0xd8, 0x00, 0x29, 0x9f, 0xeb, 0x4a, 0x5a, 0x6b, 0x5b, 0xdd, 0xb2, 0x7b,
+	0x01, 0x21, 0x04, 0xca, 0x31, 0x06, 0x9c, 0xd6, 0xcd, 0x27, 0x98, 0x9a, 0x34, 0xa0, 0x12, 0x79,
+	0x52, 0xf1, 0x94, 0x82, 0x41, 0xe7, 0xa7, 0xfd, 0x9f, 0x16, 0x6c, 0x17, 0xf7, 0xf6, 0x86, 0xa7,
+	0x27, 0xc2, 0xe7, 0xe4, 0x18, 0xb6, 0x2e, 0x65, 0x9f, 0xdc, 0x59, 0x91, 0x85, 0xd5, 0xef, 0xb2,
+	0x79, 0x77, 0x9d, 0xd1, 0x3c, 0x76, 0x0f, 0x2d, 0x12, 0xc1, 0xcd, 0xcb, 0xa1, 0x24, 0xab, 0x18,
+	0xfe, 0xf0, 0x64, 0x9a, 0xf7, 0xd6, 0x9a, 0x5d, 0xc8, 0xf5, 0x5e, 0x7c, 0xcd, 0x6c, 0xeb, 0x2c,
+	0xb3, 0xad, 0x1f, 0x99, 0x6d, 0x9d, 0xce, 0xec, 0xd2, 0xd9, 0xcc, 0x2e, 0x7d, 0x9b, 0xd9, 0xa5,
+	0x0f, 0xf7, 0xff, 0x16, 0xc8, 0x9c, 0xde, 0xd1, 0xb9, 0x34, 0x12, 0xa3, 0x8a, 0xb9, 0xad, 0x47,
+	0xbf, 0x07, 0x00, 0xaa, 0xfa, 0x06, 0x54, 0x01, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ConnectionServiceClient is the client API for ConnectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ConnectionServiceClient interface {
+	// ListConnections returns a stream of the southbound connection status of each target
+	ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (ConnectionService_ListConnectionsClient, error)
+	// WatchConnections returns a stream of changes to the southbound connection status of targets
+	WatchConnections(ctx context.Context, in *WatchConnectionsRequest, opts ...grpc.CallOption) (ConnectionService_WatchConnectionsClient, error)
+}
+
+type connectionServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewConnectionServiceClient(cc *grpc.ClientConn) ConnectionServiceClient {
+	return &connectionServiceClient{cc}
+}
+
+func (c *connectionServiceClient) ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (ConnectionService_ListConnectionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ConnectionService_serviceDesc.Streams[0], "/onos.config.admin.ConnectionService/ListConnections", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &connectionServiceListConnectionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConnectionService_ListConnectionsClient interface {
+	Recv() (*ListConnectionsResponse, error)
+	grpc.ClientStream
+}
+
+type connectionServiceListConnectionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *connectionServiceListConnectionsClient) Recv() (*ListConnectionsResponse, error) {
+	m := new(ListConnectionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *connectionServiceClient) WatchConnections(ctx context.Context, in *WatchConnectionsRequest, opts ...grpc.CallOption) (ConnectionService_WatchConnectionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ConnectionService_serviceDesc.Streams[1], "/onos.config.admin.ConnectionService/WatchConnections", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &connectionServiceWatchConnectionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConnectionService_WatchConnectionsClient interface {
+	Recv() (*WatchConnectionsResponse, error)
+	grpc.ClientStream
+}
+
+type connectionServiceWatchConnectionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *connectionServiceWatchConnectionsClient) Recv() (*WatchConnectionsResponse, error) {
+	m := new(WatchConnectionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConnectionServiceServer is the server API for ConnectionService service.
+type ConnectionServiceServer interface {
+	// ListConnections returns a stream of the southbound connection status of each target
+	ListConnections(*ListConnectionsRequest, ConnectionService_ListConnectionsServer) error
+	// WatchConnections returns a stream of changes to the southbound connection status of targets
+	WatchConnections(*WatchConnectionsRequest, ConnectionService_WatchConnectionsServer) error
+}
+
+// UnimplementedConnectionServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedConnectionServiceServer struct {
+}
+
+func (*UnimplementedConnectionServiceServer) ListConnections(req *ListConnectionsRequest, srv ConnectionService_ListConnectionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListConnections not implemented")
+}
+func (*UnimplementedConnectionServiceServer) WatchConnections(req *WatchConnectionsRequest, srv ConnectionService_WatchConnectionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchConnections not implemented")
+}
+
+func RegisterConnectionServiceServer(s *grpc.Server, srv ConnectionServiceServer) {
+	s.RegisterService(&_ConnectionService_serviceDesc, srv)
+}
+
+func _ConnectionService_ListConnections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListConnectionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConnectionServiceServer).ListConnections(m, &connectionServiceListConnectionsServer{stream})
+}
+
+type ConnectionService_ListConnectionsServer interface {
+	Send(*ListConnectionsResponse) error
+	grpc.ServerStream
+}
+
+type connectionServiceListConnectionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *connectionServiceListConnectionsServer) Send(m *ListConnectionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ConnectionService_WatchConnections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchConnectionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConnectionServiceServer).WatchConnections(m, &connectionServiceWatchConnectionsServer{stream})
+}
+
+type ConnectionService_WatchConnectionsServer interface {
+	Send(*WatchConnectionsResponse) error
+	grpc.ServerStream
+}
+
+type connectionServiceWatchConnectionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *connectionServiceWatchConnectionsServer) Send(m *WatchConnectionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ConnectionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.config.admin.ConnectionService",
+	HandlerType: (*ConnectionServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListConnections",
+			Handler:       _ConnectionService_ListConnections_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchConnections",
+			Handler:       _ConnectionService_WatchConnections_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "admin/connection.proto",
+}
+
+func (m *ListConnectionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListConnectionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListConnectionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TargetID) > 0 {
+		i -= len(m.TargetID)
+		copy(dAtA[i:], m.TargetID)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.TargetID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListConnectionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListConnectionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListConnectionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Connection != nil {
+		{
+			size, err := m.Connection.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConnection(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchConnectionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchConnectionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchConnectionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TargetID) > 0 {
+		i -= len(m.TargetID)
+		copy(dAtA[i:], m.TargetID)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.TargetID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchConnectionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchConnectionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchConnectionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Connection != nil {
+		{
+			size, err := m.Connection.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConnection(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Connection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Connection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Connection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Master) > 0 {
+		i -= len(m.Master)
+		copy(dAtA[i:], m.Master)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.Master)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Node) > 0 {
+		i -= len(m.Node)
+		copy(dAtA[i:], m.Node)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.Node)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Reconnects != 0 {
+		i = encodeVarintConnection(dAtA, i, uint64(m.Reconnects))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.LastErrorTime != nil {
+		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastErrorTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastErrorTime):])
+		if err3 != nil {
+			return 0, err3
+		}
+		i -= n3
+		i = encodeVarintConnection(dAtA, i, uint64(n3))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.LastError) > 0 {
+		i -= len(m.LastError)
+		copy(dAtA[i:], m.LastError)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.LastError)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Connected != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Connected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Connected):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintConnection(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.DialOut {
+		i--
+		if m.DialOut {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ConnID) > 0 {
+		i -= len(m.ConnID)
+		copy(dAtA[i:], m.ConnID)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.ConnID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TargetID) > 0 {
+		i -= len(m.TargetID)
+		copy(dAtA[i:], m.TargetID)
+		i = encodeVarintConnection(dAtA, i, uint64(len(m.TargetID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintConnection(dAtA []byte, offset int, v uint64) int {
+	offset -= sovConnection(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ListConnectionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TargetID)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	return n
+}
+
+func (m *ListConnectionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Connection != nil {
+		l = m.Connection.Size()
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchConnectionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TargetID)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchConnectionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Connection != nil {
+		l = m.Connection.Size()
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	return n
+}
+
+func (m *Connection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TargetID)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	l = len(m.ConnID)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	if m.DialOut {
+		n += 2
+	}
+	if m.Connected != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Connected)
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	if m.LastErrorTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastErrorTime)
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	if m.Reconnects != 0 {
+		n += 1 + sovConnection(uint64(m.Reconnects))
+	}
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	l = len(m.Master)
+	if l > 0 {
+		n += 1 + l + sovConnection(uint64(l))
+	}
+	return n
+}
+
+func sovConnection(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozConnection(x uint64) (n int) {
+	return sovConnection(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ListConnectionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListConnectionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListConnectionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetID = github_com_onosproject_onos_api_go_onos_topo.ID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConnection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListConnectionsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListConnectionsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListConnectionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connection == nil {
+				m.Connection = &Connection{}
+			}
+			if err := m.Connection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConnection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchConnectionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchConnectionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchConnectionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetID = github_com_onosproject_onos_api_go_onos_topo.ID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConnection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchConnectionsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchConnectionsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchConnectionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connection == nil {
+				m.Connection = &Connection{}
+			}
+			if err := m.Connection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConnection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Connection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Connection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Connection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetID = github_com_onosproject_onos_api_go_onos_topo.ID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DialOut", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DialOut = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connected", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connected == nil {
+				m.Connected = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Connected, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastErrorTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastErrorTime == nil {
+				m.LastErrorTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.LastErrorTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reconnects", wireType)
+			}
+			m.Reconnects = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reconnects |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConnection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Master = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConnection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConnection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipConnection(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowConnection
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConnection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthConnection
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupConnection
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthConnection
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthConnection        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowConnection          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupConnection = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,2306 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: admin/status.proto
+
+// Package onos.config.admin defines the administrative gRPC interfaces specific to onos-config.
+
+package admin
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	github_com_onosproject_onos_api_go_onos_config_v2 "github.com/onosproject/onos-api/go/onos/config/v2"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetConfigurationStatusRequest struct {
+	ConfigurationID github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID `protobuf:"bytes,1,opt,name=configuration_id,json=configurationId,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.ConfigurationID" json:"configuration_id,omitempty"`
+}
+
+func (m *GetConfigurationStatusRequest) Reset()         { *m = GetConfigurationStatusRequest{} }
+func (m *GetConfigurationStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationStatusRequest) ProtoMessage()    {}
+func (*GetConfigurationStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{0}
+}
+func (m *GetConfigurationStatusRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConfigurationStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConfigurationStatusRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetConfigurationStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationStatusRequest.Merge(m, src)
+}
+func (m *GetConfigurationStatusRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConfigurationStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationStatusRequest proto.InternalMessageInfo
+
+func (m *GetConfigurationStatusRequest) GetConfigurationID() github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID {
+	if m != nil {
+		return m.ConfigurationID
+	}
+	return ""
+}
+
+type GetConfigurationStatusResponse struct {
+	Status *ConfigurationStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *GetConfigurationStatusResponse) Reset()         { *m = GetConfigurationStatusResponse{} }
+func (m *GetConfigurationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigurationStatusResponse) ProtoMessage()    {}
+func (*GetConfigurationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{1}
+}
+func (m *GetConfigurationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConfigurationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConfigurationStatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetConfigurationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigurationStatusResponse.Merge(m, src)
+}
+func (m *GetConfigurationStatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConfigurationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigurationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigurationStatusResponse proto.InternalMessageInfo
+
+func (m *GetConfigurationStatusResponse) GetStatus() *ConfigurationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ListConfigurationStatusesRequest struct {
+}
+
+func (m *ListConfigurationStatusesRequest) Reset()         { *m = ListConfigurationStatusesRequest{} }
+func (m *ListConfigurationStatusesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListConfigurationStatusesRequest) ProtoMessage()    {}
+func (*ListConfigurationStatusesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{2}
+}
+func (m *ListConfigurationStatusesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListConfigurationStatusesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListConfigurationStatusesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListConfigurationStatusesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigurationStatusesRequest.Merge(m, src)
+}
+func (m *ListConfigurationStatusesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListConfigurationStatusesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigurationStatusesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigurationStatusesRequest proto.InternalMessageInfo
+
+type ListConfigurationStatusesResponse struct {
+	Status *ConfigurationStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *ListConfigurationStatusesResponse) Reset()         { *m = ListConfigurationStatusesResponse{} }
+func (m *ListConfigurationStatusesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListConfigurationStatusesResponse) ProtoMessage()    {}
+func (*ListConfigurationStatusesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{3}
+}
+func (m *ListConfigurationStatusesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListConfigurationStatusesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListConfigurationStatusesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListConfigurationStatusesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigurationStatusesResponse.Merge(m, src)
+}
+func (m *ListConfigurationStatusesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListConfigurationStatusesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigurationStatusesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigurationStatusesResponse proto.InternalMessageInfo
+
+func (m *ListConfigurationStatusesResponse) GetStatus() *ConfigurationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// ConfigurationStatus is the status onos-config tracks for a target configuration
+type ConfigurationStatus struct {
+	ID         github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID `protobuf:"bytes,1,opt,name=id,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.ConfigurationID" json:"id,omitempty"`
+	TargetID   github_com_onosproject_onos_api_go_onos_config_v2.TargetID        `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.TargetID" json:"target_id,omitempty"`
+	Drift      *DriftStatus                                                      `protobuf:"bytes,3,opt,name=drift,proto3" json:"drift,omitempty"`
+	Validation *ValidationStatus                                                 `protobuf:"bytes,4,opt,name=validation,proto3" json:"validation,omitempty"`
+}
+
+func (m *ConfigurationStatus) Reset()         { *m = ConfigurationStatus{} }
+func (m *ConfigurationStatus) String() string { return proto.CompactTextString(m) }
+func (*ConfigurationStatus) ProtoMessage()    {}
+func (*ConfigurationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{4}
+}
+func (m *ConfigurationStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigurationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigurationStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConfigurationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigurationStatus.Merge(m, src)
+}
+func (m *ConfigurationStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfigurationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigurationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigurationStatus proto.InternalMessageInfo
+
+func (m *ConfigurationStatus) GetID() github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ConfigurationStatus) GetTargetID() github_com_onosproject_onos_api_go_onos_config_v2.TargetID {
+	if m != nil {
+		return m.TargetID
+	}
+	return ""
+}
+
+func (m *ConfigurationStatus) GetDrift() *DriftStatus {
+	if m != nil {
+		return m.Drift
+	}
+	return nil
+}
+
+func (m *ConfigurationStatus) GetValidation() *ValidationStatus {
+	if m != nil {
+		return m.Validation
+	}
+	return nil
+}
+
+// ValidationStatus indicates whether the configuration of a target is validated by a model plugin.
+// Targets without a model plugin for their type and version are only configurable when unvalidated
+// configuration is allowed, in which case their configuration is stored as raw path/values.
+type ValidationStatus struct {
+	// type is the target type for which validation was last evaluated
+	Type github_com_onosproject_onos_api_go_onos_config_v2.TargetType `protobuf:"bytes,1,opt,name=type,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.TargetType" json:"type,omitempty"`
+	// version is the target version for which validation was last evaluated
+	Version github_com_onosproject_onos_api_go_onos_config_v2.TargetVersion `protobuf:"bytes,2,opt,name=version,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.TargetVersion" json:"version,omitempty"`
+	// validated indicates whether a model plugin validates the target's configuration
+	Validated bool `protobuf:"varint,3,opt,name=validated,proto3" json:"validated,omitempty"`
+}
+
+func (m *ValidationStatus) Reset()         { *m = ValidationStatus{} }
+func (m *ValidationStatus) String() string { return proto.CompactTextString(m) }
+func (*ValidationStatus) ProtoMessage()    {}
+func (*ValidationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{5}
+}
+func (m *ValidationStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationStatus.Merge(m, src)
+}
+func (m *ValidationStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationStatus proto.InternalMessageInfo
+
+func (m *ValidationStatus) GetType() github_com_onosproject_onos_api_go_onos_config_v2.TargetType {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ValidationStatus) GetVersion() github_com_onosproject_onos_api_go_onos_config_v2.TargetVersion {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ValidationStatus) GetValidated() bool {
+	if m != nil {
+		return m.Validated
+	}
+	return false
+}
+
+// DriftStatus is the result of the last comparison of the configuration on a target with its intended configuration
+type DriftStatus struct {
+	// checked is the time at which the target was last checked
+	Checked *time.Time `protobuf:"bytes,1,opt,name=checked,proto3,stdtime" json:"checked,omitempty"`
+	// index is the configuration index against which the target was checked
+	Index github_com_onosproject_onos_api_go_onos_config_v2.Index `protobuf:"varint,2,opt,name=index,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.Index" json:"index,omitempty"`
+	// drifted_paths is the list of paths for which the target configuration differs from the intended configuration
+	DriftedPaths []DriftedPath `protobuf:"bytes,3,rep,name=drifted_paths,json=driftedPaths,proto3" json:"drifted_paths"`
+	// remediation is the remediation action taken for the drifted paths, if any
+	Remediation string `protobuf:"bytes,4,opt,name=remediation,proto3" json:"remediation,omitempty"`
+	// transaction_id is the ID of the transaction created to adopt the drifted paths, if any
+	TransactionID github_com_onosproject_onos_api_go_onos_config_v2.TransactionID `protobuf:"bytes,5,opt,name=transaction_id,json=transactionId,proto3,casttype=github.com/onosproject/onos-api/go/onos/config/v2.TransactionID" json:"transaction_id,omitempty"`
+	// error is the error with which the last check failed, if any
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *DriftStatus) Reset()         { *m = DriftStatus{} }
+func (m *DriftStatus) String() string { return proto.CompactTextString(m) }
+func (*DriftStatus) ProtoMessage()    {}
+func (*DriftStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{6}
+}
+func (m *DriftStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DriftStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DriftStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DriftStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DriftStatus.Merge(m, src)
+}
+func (m *DriftStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *DriftStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DriftStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DriftStatus proto.InternalMessageInfo
+
+func (m *DriftStatus) GetChecked() *time.Time {
+	if m != nil {
+		return m.Checked
+	}
+	return nil
+}
+
+func (m *DriftStatus) GetIndex() github_com_onosproject_onos_api_go_onos_config_v2.Index {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DriftStatus) GetDriftedPaths() []DriftedPath {
+	if m != nil {
+		return m.DriftedPaths
+	}
+	return nil
+}
+
+func (m *DriftStatus) GetRemediation() string {
+	if m != nil {
+		return m.Remediation
+	}
+	return ""
+}
+
+func (m *DriftStatus) GetTransactionID() github_com_onosproject_onos_api_go_onos_config_v2.TransactionID {
+	if m != nil {
+		return m.TransactionID
+	}
+	return ""
+}
+
+func (m *DriftStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// DriftedPath is a path for which the configuration on a target differs from its intended configuration
+type DriftedPath struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// intended is the intended value of the path; empty if the path is not intended to exist
+	Intended string `protobuf:"bytes,2,opt,name=intended,proto3" json:"intended,omitempty"`
+	// actual is the value of the path on the target; empty if the path does not exist on the target
+	Actual string `protobuf:"bytes,3,opt,name=actual,proto3" json:"actual,omitempty"`
+}
+
+func (m *DriftedPath) Reset()         { *m = DriftedPath{} }
+func (m *DriftedPath) String() string { return proto.CompactTextString(m) }
+func (*DriftedPath) ProtoMessage()    {}
+func (*DriftedPath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_17a21db340f8537e, []int{7}
+}
+func (m *DriftedPath) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DriftedPath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DriftedPath.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DriftedPath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DriftedPath.Merge(m, src)
+}
+func (m *DriftedPath) XXX_Size() int {
+	return m.Size()
+}
+func (m *DriftedPath) XXX_DiscardUnknown() {
+	xxx_messageInfo_DriftedPath.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DriftedPath proto.InternalMessageInfo
+
+func (m *DriftedPath) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DriftedPath) GetIntended() string {
+	if m != nil {
+		return m.Intended
+	}
+	return ""
+}
+
+func (m *DriftedPath) GetActual() string {
+	if m != nil {
+		return m.Actual
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetConfigurationStatusRequest)(nil), "onos.config.admin.GetConfigurationStatusRequest")
+	proto.RegisterType((*GetConfigurationStatusResponse)(nil), "onos.config.admin.GetConfigurationStatusResponse")
+	proto.RegisterType((*ListConfigurationStatusesRequest)(nil), "onos.config.admin.ListConfigurationStatusesRequest")
+	proto.RegisterType((*ListConfigurationStatusesResponse)(nil), "onos.config.admin.ListConfigurationStatusesResponse")
+	proto.RegisterType((*ConfigurationStatus)(nil), "onos.config.admin.ConfigurationStatus")
+	proto.RegisterType((*ValidationStatus)(nil), "onos.config.admin.ValidationStatus")
+	proto.RegisterType((*DriftStatus)(nil), "onos.config.admin.DriftStatus")
+	proto.RegisterType((*DriftedPath)(nil), "onos.config.admin.DriftedPath")
+}
+
+func init() { proto.RegisterFile("admin/status.proto", fileDescriptor_17a21db340f8537e) }
+
+var fileDescriptor_17a21db340f8537e = []byte{
+	// 731 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4f, 0x6f, 0xd3, 0x4a,
+	0x10, 0x8f, 0xf3, 0xaf, 0xc9, 0xf4, 0xf5, 0xb5, 0x6f, 0x5f, 0x55, 0xe5, 0x45, 0x0f, 0x3b, 0x18,
+	0x09, 0x71, 0x00, 0xbb, 0xb4, 0x95, 0x90, 0x0a, 0xe2, 0x4f, 0x5a, 0x81, 0x02, 0x1c, 0xc0, 0x0d,
+	0x15, 0x02, 0xa1, 0xe2, 0x7a, 0xb7, 0xce, 0x42, 0xe3, 0x35, 0xf6, 0x26, 0xa2, 0x42, 0x9c, 0xb8,
+	0x70, 0xec, 0x07, 0xe0, 0x0a, 0x9f, 0xa5, 0xc7, 0x9e, 0x10, 0x27, 0x83, 0x52, 0xf1, 0x25, 0x72,
+	0x42, 0x5e, 0xdb, 0x4d, 0x5a, 0xdc, 0xa0, 0x54, 0xbd, 0xed, 0xee, 0xcc, 0xef, 0x37, 0x33, 0xbf,
+	0x19, 0x8f, 0x01, 0x99, 0xb8, 0x4d, 0x1d, 0xdd, 0xe7, 0x26, 0xef, 0xf8, 0x9a, 0xeb, 0x31, 0xce,
+	0xd0, 0x3f, 0xcc, 0x61, 0xbe, 0x66, 0x31, 0x67, 0x8b, 0xda, 0x9a, 0xb0, 0x57, 0x15, 0x9b, 0x31,
+	0x7b, 0x9b, 0xe8, 0xc2, 0x61, 0xb3, 0xb3, 0xa5, 0x73, 0xda, 0x26, 0x3e, 0x37, 0xdb, 0x6e, 0x84,
+	0xa9, 0xce, 0xda, 0xcc, 0x66, 0xe2, 0xa8, 0x87, 0xa7, 0xe8, 0x55, 0xfd, 0x2c, 0xc1, 0xb9, 0x7b,
+	0x84, 0xaf, 0x08, 0xaa, 0x8e, 0x67, 0x72, 0xca, 0x9c, 0x35, 0x11, 0xca, 0x20, 0x6f, 0x3a, 0xc4,
+	0xe7, 0xe8, 0x83, 0x04, 0x33, 0xd6, 0xb0, 0x79, 0x83, 0xe2, 0x8a, 0x54, 0x93, 0x2e, 0x95, 0xeb,
+	0x4f, 0x7b, 0x81, 0x32, 0x7d, 0x04, 0xda, 0x58, 0xed, 0x07, 0xca, 0x1d, 0x9b, 0xf2, 0x56, 0x67,
+	0x53, 0xb3, 0x58, 0x5b, 0x0f, 0x13, 0x75, 0x3d, 0xf6, 0x8a, 0x58, 0x5c, 0x9c, 0xaf, 0x98, 0x2e,
+	0xd5, 0x6d, 0x26, 0xce, 0x7a, 0x44, 0xab, 0x77, 0x17, 0xb4, 0x63, 0x24, 0xc6, 0xf4, 0x91, 0x88,
+	0x0d, 0xac, 0xbe, 0x04, 0xf9, 0xa4, 0x34, 0x7d, 0x97, 0x39, 0x3e, 0x41, 0x37, 0xa1, 0x18, 0x69,
+	0x24, 0x92, 0x9b, 0x5c, 0xb8, 0xa8, 0xfd, 0x26, 0x92, 0x96, 0x86, 0x8f, 0x51, 0xaa, 0x0a, 0xb5,
+	0x87, 0xd4, 0x4f, 0x0b, 0x41, 0x12, 0x2d, 0x54, 0x0b, 0xce, 0x8f, 0xf0, 0x39, 0xa3, 0x44, 0xbe,
+	0x66, 0xe1, 0xdf, 0x14, 0x3b, 0x7a, 0x0e, 0xd9, 0x43, 0xe5, 0x1f, 0xf4, 0x02, 0x25, 0x7b, 0x56,
+	0x62, 0x67, 0x29, 0x46, 0x36, 0x94, 0xb9, 0xe9, 0xd9, 0x84, 0x87, 0xdd, 0xcd, 0x8a, 0x18, 0xf7,
+	0x7b, 0x81, 0x52, 0x6a, 0x8a, 0x47, 0x11, 0x69, 0x79, 0xfc, 0x48, 0x09, 0xda, 0x28, 0x45, 0xe4,
+	0x0d, 0x8c, 0x96, 0xa0, 0x80, 0x3d, 0xba, 0xc5, 0x2b, 0x39, 0x21, 0x8e, 0x9c, 0x22, 0xce, 0x6a,
+	0x68, 0x8f, 0x45, 0x89, 0x9c, 0xd1, 0x0a, 0x40, 0xd7, 0xdc, 0xa6, 0x58, 0xa4, 0x5c, 0xc9, 0x0b,
+	0xe8, 0x85, 0x14, 0xe8, 0xfa, 0xa1, 0x53, 0x8c, 0x1f, 0x82, 0xa9, 0x3f, 0x25, 0x98, 0x39, 0xee,
+	0x80, 0x9a, 0x90, 0xe7, 0x3b, 0x2e, 0x89, 0x75, 0xbd, 0xdd, 0x0f, 0x94, 0x1b, 0xa7, 0xad, 0xb3,
+	0xb9, 0xe3, 0x12, 0x43, 0xb0, 0xa1, 0x17, 0x30, 0xd1, 0x25, 0x9e, 0x1f, 0x26, 0x1b, 0x89, 0xb9,
+	0xd2, 0x0f, 0x94, 0x5b, 0xa7, 0x25, 0x5e, 0x8f, 0xa8, 0x8c, 0x84, 0x13, 0xfd, 0x0f, 0xe5, 0xb8,
+	0x2e, 0x82, 0x85, 0x90, 0x25, 0x63, 0xf0, 0xa0, 0x7e, 0xca, 0xc1, 0xe4, 0x90, 0x86, 0x68, 0x19,
+	0x26, 0xac, 0x16, 0xb1, 0x5e, 0x13, 0x1c, 0x4f, 0x64, 0x55, 0x8b, 0x96, 0x85, 0x96, 0x2c, 0x0b,
+	0xad, 0x99, 0x2c, 0x8b, 0x7a, 0x7e, 0xf7, 0xbb, 0x22, 0x19, 0x09, 0x00, 0x3d, 0x86, 0x02, 0x75,
+	0x30, 0x79, 0x2b, 0xca, 0xc8, 0xd7, 0xaf, 0xf7, 0x03, 0xe5, 0xda, 0xf8, 0x65, 0x34, 0x42, 0x0a,
+	0x23, 0x62, 0x42, 0x0d, 0x98, 0x12, 0x4d, 0x25, 0x78, 0xc3, 0x35, 0x79, 0xcb, 0xaf, 0xe4, 0x6a,
+	0xb9, 0x51, 0x93, 0x40, 0xf0, 0x23, 0x93, 0xb7, 0xea, 0xf9, 0xbd, 0x40, 0xc9, 0x18, 0x7f, 0xe1,
+	0xc1, 0x93, 0x8f, 0x6a, 0x30, 0xe9, 0x91, 0x36, 0xc1, 0x74, 0x30, 0x17, 0x65, 0x63, 0xf8, 0x09,
+	0xbd, 0x83, 0xbf, 0xb9, 0x67, 0x3a, 0xbe, 0x69, 0x25, 0xab, 0xab, 0x20, 0xfa, 0xd1, 0xec, 0x05,
+	0xca, 0x54, 0x73, 0x60, 0x69, 0xac, 0x9e, 0xb2, 0x41, 0xc3, 0x14, 0xc6, 0xd4, 0x50, 0xac, 0x06,
+	0x46, 0xb3, 0x50, 0x20, 0x9e, 0xc7, 0xbc, 0x4a, 0x51, 0x24, 0x16, 0x5d, 0xd4, 0x27, 0x71, 0x77,
+	0xa2, 0x22, 0x10, 0x82, 0x7c, 0x28, 0x43, 0x34, 0x80, 0x86, 0x38, 0xa3, 0x2a, 0x94, 0xa8, 0xc3,
+	0x89, 0x83, 0x49, 0xfc, 0x31, 0x1a, 0x87, 0x77, 0x34, 0x07, 0x45, 0xd3, 0xe2, 0x1d, 0x73, 0x5b,
+	0x34, 0xbe, 0x6c, 0xc4, 0xb7, 0x85, 0x2f, 0x59, 0xa8, 0xa6, 0xac, 0x8d, 0x35, 0xe2, 0x75, 0xa9,
+	0x45, 0xd0, 0x7b, 0x98, 0x4b, 0x5f, 0xa0, 0x68, 0x3e, 0x45, 0xf8, 0x91, 0xbf, 0x84, 0xea, 0xd5,
+	0x31, 0x10, 0xf1, 0x52, 0xfc, 0x28, 0xc1, 0x7f, 0x27, 0xae, 0x4e, 0xb4, 0x98, 0x42, 0xf8, 0xa7,
+	0x65, 0x5c, 0x5d, 0x1a, 0x0f, 0x14, 0x25, 0x32, 0x2f, 0xd5, 0xef, 0xee, 0xf5, 0x64, 0x69, 0xbf,
+	0x27, 0x4b, 0x3f, 0x7a, 0xb2, 0xb4, 0x7b, 0x20, 0x67, 0xf6, 0x0f, 0xe4, 0xcc, 0xb7, 0x03, 0x39,
+	0xf3, 0xec, 0xf2, 0xa8, 0xfe, 0xc7, 0x3d, 0x0f, 0xc7, 0x40, 0xc4, 0xda, 0x2c, 0x8a, 0xaf, 0x67,
+	0xf1, 0xd7, 0x00, 0xdb, 0xff, 0x29, 0xa8, 0xa1, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ConfigurationStatusServiceClient is the client API for ConfigurationStatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ConfigurationStatusServiceClient interface {
+	// GetConfigurationStatus returns the status of a configuration
+	GetConfigurationStatus(ctx context.Context, in *GetConfigurationStatusRequest, opts ...grpc.CallOption) (*GetConfigurationStatusResponse, error)
+	// ListConfigurationStatuses returns a stream of the status of each configuration
+	ListConfigurationStatuses(ctx context.Context, in *ListConfigurationStatusesRequest, opts ...grpc.CallOption) (ConfigurationStatusService_ListConfigurationStatusesClient, error)
+}
+
+type configurationStatusServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewConfigurationStatusServiceClient(cc *grpc.ClientConn) ConfigurationStatusServiceClient {
+	return &configurationStatusServiceClient{cc}
+}
+
+func (c *configurationStatusServiceClient) GetConfigurationStatus(ctx context.Context, in *GetConfigurationStatusRequest, opts ...grpc.CallOption) (*GetConfigurationStatusResponse, error) {
+	out := new(GetConfigurationStatusResponse)
+	err := c.cc.Invoke(ctx, "/onos.config.admin.ConfigurationStatusService/GetConfigurationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationStatusServiceClient) ListConfigurationStatuses(ctx context.Context, in *ListConfigurationStatusesRequest, opts ...grpc.CallOption) (ConfigurationStatusService_ListConfigurationStatusesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ConfigurationStatusService_serviceDesc.Streams[0], "/onos.config.admin.ConfigurationStatusService/ListConfigurationStatuses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configurationStatusServiceListConfigurationStatusesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConfigurationStatusService_ListConfigurationStatusesClient interface {
+	Recv() (*ListConfigurationStatusesResponse, error)
+	grpc.ClientStream
+}
+
+type configurationStatusServiceListConfigurationStatusesClient struct {
+	grpc.ClientStream
+}
+
+func (x *configurationStatusServiceListConfigurationStatusesClient) Recv() (*ListConfigurationStatusesResponse, error) {
+	m := new(ListConfigurationStatusesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConfigurationStatusServiceServer is the server API for ConfigurationStatusService service.
+type ConfigurationStatusServiceServer interface {
+	// GetConfigurationStatus returns the status of a configuration
+	GetConfigurationStatus(context.Context, *GetConfigurationStatusRequest) (*GetConfigurationStatusResponse, error)
+	// ListConfigurationStatuses returns a stream of the status of each configuration
+	ListConfigurationStatuses(*ListConfigurationStatusesRequest, ConfigurationStatusService_ListConfigurationStatusesServer) error
+}
+
+// UnimplementedConfigurationStatusServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedConfigurationStatusServiceServer struct {
+}
+
+func (*UnimplementedConfigurationStatusServiceServer) GetConfigurationStatus(ctx context.Context, req *GetConfigurationStatusRequest) (*GetConfigurationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigurationStatus not implemented")
+}
+func (*UnimplementedConfigurationStatusServiceServer) ListConfigurationStatuses(req *ListConfigurationStatusesRequest, srv ConfigurationStatusService_ListConfigurationStatusesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListConfigurationStatuses not implemented")
+}
+
+func RegisterConfigurationStatusServiceServer(s *grpc.Server, srv ConfigurationStatusServiceServer) {
+	s.RegisterService(&_ConfigurationStatusService_serviceDesc, srv)
+}
+
+func _ConfigurationStatusService_GetConfigurationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationStatusServiceServer).GetConfigurationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.config.admin.ConfigurationStatusService/GetConfigurationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationStatusServiceServer).GetConfigurationStatus(ctx, req.(*GetConfigurationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationStatusService_ListConfigurationStatuses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListConfigurationStatusesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigurationStatusServiceServer).ListConfigurationStatuses(m, &configurationStatusServiceListConfigurationStatusesServer{stream})
+}
+
+type ConfigurationStatusService_ListConfigurationStatusesServer interface {
+	Send(*ListConfigurationStatusesResponse) error
+	grpc.ServerStream
+}
+
+type configurationStatusServiceListConfigurationStatusesServer struct {
+	grpc.ServerStream
+}
+
+func (x *configurationStatusServiceListConfigurationStatusesServer) Send(m *ListConfigurationStatusesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ConfigurationStatusService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.config.admin.ConfigurationStatusService",
+	HandlerType: (*ConfigurationStatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConfigurationStatus",
+			Handler:    _ConfigurationStatusService_GetConfigurationStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListConfigurationStatuses",
+			Handler:       _ConfigurationStatusService_ListConfigurationStatuses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "admin/status.proto",
+}
+
+func (m *GetConfigurationStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigurationStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConfigurationStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ConfigurationID) > 0 {
+		i -= len(m.ConfigurationID)
+		copy(dAtA[i:], m.ConfigurationID)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.ConfigurationID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConfigurationStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConfigurationStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConfigurationStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Status != nil {
+		{
+			size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStatus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListConfigurationStatusesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListConfigurationStatusesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListConfigurationStatusesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ListConfigurationStatusesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListConfigurationStatusesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListConfigurationStatusesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Status != nil {
+		{
+			size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStatus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigurationStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigurationStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigurationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Validation != nil {
+		{
+			size, err := m.Validation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStatus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Drift != nil {
+		{
+			size, err := m.Drift.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStatus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TargetID) > 0 {
+		i -= len(m.TargetID)
+		copy(dAtA[i:], m.TargetID)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.TargetID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidationStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidationStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Validated {
+		i--
+		if m.Validated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DriftStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriftStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DriftStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.TransactionID) > 0 {
+		i -= len(m.TransactionID)
+		copy(dAtA[i:], m.TransactionID)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.TransactionID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Remediation) > 0 {
+		i -= len(m.Remediation)
+		copy(dAtA[i:], m.Remediation)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Remediation)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.DriftedPaths) > 0 {
+		for iNdEx := len(m.DriftedPaths) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DriftedPaths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintStatus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Index != 0 {
+		i = encodeVarintStatus(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Checked != nil {
+		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Checked, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Checked):])
+		if err5 != nil {
+			return 0, err5
+		}
+		i -= n5
+		i = encodeVarintStatus(dAtA, i, uint64(n5))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DriftedPath) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DriftedPath) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DriftedPath) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Actual) > 0 {
+		i -= len(m.Actual)
+		copy(dAtA[i:], m.Actual)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Actual)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Intended) > 0 {
+		i -= len(m.Intended)
+		copy(dAtA[i:], m.Intended)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Intended)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintStatus(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintStatus(dAtA []byte, offset int, v uint64) int {
+	offset -= sovStatus(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetConfigurationStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConfigurationID)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func (m *GetConfigurationStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func (m *ListConfigurationStatusesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListConfigurationStatusesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfigurationStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.TargetID)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	if m.Drift != nil {
+		l = m.Drift.Size()
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	if m.Validation != nil {
+		l = m.Validation.Size()
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func (m *ValidationStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	if m.Validated {
+		n += 2
+	}
+	return n
+}
+
+func (m *DriftStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Checked != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Checked)
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovStatus(uint64(m.Index))
+	}
+	if len(m.DriftedPaths) > 0 {
+		for _, e := range m.DriftedPaths {
+			l = e.Size()
+			n += 1 + l + sovStatus(uint64(l))
+		}
+	}
+	l = len(m.Remediation)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.TransactionID)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func (m *DriftedPath) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.Intended)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	l = len(m.Actual)
+	if l > 0 {
+		n += 1 + l + sovStatus(uint64(l))
+	}
+	return n
+}
+
+func sovStatus(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozStatus(x uint64) (n int) {
+	return sovStatus(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetConfigurationStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigurationStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigurationStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigurationID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConfigurationID = github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConfigurationStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConfigurationStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConfigurationStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &ConfigurationStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListConfigurationStatusesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListConfigurationStatusesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListConfigurationStatusesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListConfigurationStatusesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListConfigurationStatusesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListConfigurationStatusesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &ConfigurationStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigurationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigurationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigurationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = github_com_onosproject_onos_api_go_onos_config_v2.ConfigurationID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetID = github_com_onosproject_onos_api_go_onos_config_v2.TargetID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Drift", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Drift == nil {
+				m.Drift = &DriftStatus{}
+			}
+			if err := m.Drift.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validation == nil {
+				m.Validation = &ValidationStatus{}
+			}
+			if err := m.Validation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = github_com_onosproject_onos_api_go_onos_config_v2.TargetType(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = github_com_onosproject_onos_api_go_onos_config_v2.TargetVersion(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Validated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriftStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriftStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriftStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checked", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Checked == nil {
+				m.Checked = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Checked, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= github_com_onosproject_onos_api_go_onos_config_v2.Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DriftedPaths", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DriftedPaths = append(m.DriftedPaths, DriftedPath{})
+			if err := m.DriftedPaths[len(m.DriftedPaths)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remediation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Remediation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransactionID = github_com_onosproject_onos_api_go_onos_config_v2.TransactionID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DriftedPath) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DriftedPath: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DriftedPath: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Intended", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Intended = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actual", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actual = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStatus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipStatus(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowStatus
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthStatus
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupStatus
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthStatus
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthStatus        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowStatus          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupStatus = fmt.Errorf("proto: unexpected end of group")
+)
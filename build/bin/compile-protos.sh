@@ -0,0 +1,7 @@
+#!/bin/sh
+
+proto_imports=".:${GOPATH}/src/github.com/gogo/protobuf/protobuf:${GOPATH}/src/github.com/gogo/protobuf:${GOPATH}/src"
+
+cd api && protoc -I=$proto_imports \
+    --gogofaster_out=Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types,Mgogoproto/gogo.proto=github.com/gogo/protobuf/gogoproto,plugins=grpc,paths=source_relative:. \
+    admin/*.proto
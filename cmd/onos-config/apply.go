@@ -0,0 +1,149 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/batch"
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func getApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply FILE...",
+		Short: "Apply gnmi_cli (.gnmi) or YAML SetRequest files in order through the northbound gNMI service",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runApplyCommand,
+	}
+	cmd.Flags().String("address", "onos-config:5150", "address of the onos-config gNMI service")
+	cmd.Flags().String("caPath", "", "path to CA certificate; the server certificate is not verified if not set")
+	cmd.Flags().String("keyPath", "", "path to client private key; the default client key is used if not set")
+	cmd.Flags().String("certPath", "", "path to client certificate; the default client certificate is used if not set")
+	cmd.Flags().Bool("noTLS", false, "connect without TLS")
+	cmd.Flags().Bool("parseOnly", false, "parse the files without sending them to onos-config; the files are not validated")
+	cmd.Flags().Bool("stopOnError", false, "skip the remaining files once a file fails")
+	cmd.Flags().String("strategy", "", "transaction strategy of all files, e.g. synchronous,serializable")
+	cmd.Flags().StringArray("fileStrategy", []string{}, "transaction strategy of a single file as FILE=STRATEGY (repeated)")
+	cmd.Flags().Duration("timeout", 0, "timeout for applying a single file (default 30s)")
+	return cmd
+}
+
+func runApplyCommand(cmd *cobra.Command, args []string) error {
+	address, _ := cmd.Flags().GetString("address")
+	parseOnly, _ := cmd.Flags().GetBool("parseOnly")
+	stopOnError, _ := cmd.Flags().GetBool("stopOnError")
+	strategy, _ := cmd.Flags().GetString("strategy")
+	fileStrategies, _ := cmd.Flags().GetStringArray("fileStrategy")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	options := batch.Options{
+		ParseOnly:      parseOnly,
+		StopOnError:    stopOnError,
+		FileStrategies: make(map[string]configapi.TransactionStrategy),
+		Timeout:        timeout,
+	}
+	if strategy != "" {
+		s, err := batch.ParseStrategy(strategy)
+		if err != nil {
+			return err
+		}
+		options.Strategy = &s
+	}
+	for _, fileStrategy := range fileStrategies {
+		i := strings.LastIndex(fileStrategy, "=")
+		if i < 0 {
+			return fmt.Errorf("invalid file strategy %s: expected FILE=STRATEGY", fileStrategy)
+		}
+		s, err := batch.ParseStrategy(fileStrategy[i+1:])
+		if err != nil {
+			return err
+		}
+		options.FileStrategies[fileStrategy[:i]] = s
+	}
+
+	var client gnmi.GNMIClient
+	if !parseOnly {
+		opts, err := getApplyDialOptions(cmd)
+		if err != nil {
+			return err
+		}
+		conn, err := grpc.Dial(address, opts...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		client = gnmi.NewGNMIClient(conn)
+	}
+
+	results := batch.Apply(context.Background(), client, args, options)
+	if err := batch.WriteSummary(cmd.OutOrStdout(), results); err != nil {
+		return err
+	}
+	if batch.Failed(results) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed applying files")
+	}
+	return nil
+}
+
+// getApplyDialOptions returns the options for connecting to the northbound gNMI service
+func getApplyDialOptions(cmd *cobra.Command) ([]grpc.DialOption, error) {
+	noTLS, _ := cmd.Flags().GetBool("noTLS")
+	if noTLS {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	caPath, _ := cmd.Flags().GetString("caPath")
+	keyPath, _ := cmd.Flags().GetString("keyPath")
+	certPath, _ := cmd.Flags().GetString("certPath")
+
+	var cert tls.Certificate
+	var err error
+	if keyPath != "" || certPath != "" {
+		cert, err = tls.LoadX509KeyPair(certPath, keyPath)
+	} else {
+		cert, err = tls.X509KeyPair([]byte(certs.DefaultClientCrt), []byte(certs.DefaultClientKey))
+	}
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if caPath != "" {
+		ca, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", caPath)
+		}
+	} else {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, nil
+}
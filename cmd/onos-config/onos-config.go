@@ -20,6 +20,10 @@ gives a gNMI interface northbound for other systems to connect to, and an
 Admin service through gRPC
 
 Arguments
+-config <the location of a YAML configuration file; arguments given on the command line override its settings>
+
+-port <the port on which the northbound gRPC services are served>
+
 -allowUnvalidatedConfig <allow configuration for devices without a corresponding model plugin>
 
 -modelPlugin (repeated) <the location of a shared object library that implements the Model Plugin interface>
@@ -30,6 +34,18 @@ Arguments
 
 -certPath <the location of a client certificate>
 
+-topoFile <the location of a YAML or JSON topology file to use instead of onos-topo>
+
+-dialOutPort <the port on which targets initiating the southbound gNMI connection are accepted>
+
+-storeBackend <the backend of the transaction, proposal and configuration stores: atomix, memory or embedded>
+
+-storePath <the location of the embedded store database>
+
+-bootstrapPath <the location of a directory of YAML SetRequest files applied once at startup>
+
+Commands
+apply FILE... <apply gnmi_cli (.gnmi) or YAML SetRequest files in order through the northbound gNMI service>
 
 See ../../docs/run.md for how to run the application.
 */
@@ -40,6 +56,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/onosproject/onos-config/pkg/config"
 	"github.com/onosproject/onos-config/pkg/manager"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/spf13/cobra"
@@ -61,39 +78,79 @@ func getRootCommand() *cobra.Command {
 		Short: "ONOS configuration subsystem",
 		RunE:  runRootCommand,
 	}
+	cmd.AddCommand(getApplyCommand())
+	cmd.Flags().String("config", "", "path to a configuration file")
 	cmd.Flags().Int("port", 5150, "gRPC port")
 	cmd.Flags().Bool("allowUnvalidatedConfig", false, "allow configuration for devices without a corresponding model plugin")
 	cmd.Flags().String("caPath", "", "path to CA certificate")
 	cmd.Flags().String("keyPath", "", "path to client private key")
 	cmd.Flags().String("certPath", "", "ppath to client certificate")
 	cmd.Flags().String("topoEndpoint", "onos-topo:5150", "topology service endpoint")
+	cmd.Flags().String("topoFile", "", "path to a topology file to use instead of the topology service")
 	cmd.Flags().StringSlice("plugin", []string{}, "configuration model plugin (name:port)")
+	cmd.Flags().Int("dialOutPort", 0, "port on which dial-out gNMI targets are accepted (0 to disable)")
+	cmd.Flags().String("storeBackend", string(manager.StoreBackendAtomix), "store backend (atomix, memory or embedded)")
+	cmd.Flags().String("storePath", "onos-config.db", "path to the embedded store database")
+	cmd.Flags().String("bootstrapPath", "", "path to a directory of SetRequest files applied at startup")
 	return cmd
 }
 
 func runRootCommand(cmd *cobra.Command, args []string) error {
-	caPath, _ := cmd.Flags().GetString("caPath")
-	keyPath, _ := cmd.Flags().GetString("keyPath")
-	certPath, _ := cmd.Flags().GetString("certPath")
-	topoEndpoint, _ := cmd.Flags().GetString("topoEndpoint")
-	plugins, _ := cmd.Flags().GetStringSlice("plugin")
+	configFile, _ := cmd.Flags().GetString("config")
+	fileConfig := config.Default()
+	if configFile != "" {
+		var err error
+		fileConfig, err = config.Load(configFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	port := getIntFlag(cmd, "port", fileConfig.GRPCPort)
+	allowUnvalidatedConfig := getBoolFlag(cmd, "allowUnvalidatedConfig", fileConfig.AllowUnvalidatedConfig)
+	caPath := getStringFlag(cmd, "caPath", fileConfig.CAPath)
+	keyPath := getStringFlag(cmd, "keyPath", fileConfig.KeyPath)
+	certPath := getStringFlag(cmd, "certPath", fileConfig.CertPath)
+	topoEndpoint := getStringFlag(cmd, "topoEndpoint", fileConfig.TopoEndpoint)
+	topoFile := getStringFlag(cmd, "topoFile", fileConfig.TopoFile)
+	plugins := getStringSliceFlag(cmd, "plugin", fileConfig.Plugins)
+	dialOutPort := getIntFlag(cmd, "dialOutPort", fileConfig.DialOutPort)
+	storeBackend := getStringFlag(cmd, "storeBackend", fileConfig.StoreBackend)
+	storePath := getStringFlag(cmd, "storePath", fileConfig.StorePath)
+	bootstrapPath := getStringFlag(cmd, "bootstrapPath", fileConfig.BootstrapPath)
 
 	log.Infow("Starting onos-config",
+		"ConfigFile", configFile,
 		"CAPath", caPath,
 		"KeyPath", keyPath,
 		"CertPath", certPath,
-		"GRPCPort", 5150,
+		"GRPCPort", port,
+		"AllowUnvalidatedConfig", allowUnvalidatedConfig,
 		"TopoAddress", topoEndpoint,
+		"TopoFile", topoFile,
 		"Plugins", plugins,
+		"DialOutPort", dialOutPort,
+		"StoreBackend", storeBackend,
+		"StorePath", storePath,
+		"BootstrapPath", bootstrapPath,
+		"NodeLeaseDuration", fileConfig.Controllers.NodeLeaseDuration,
 	)
 
 	cfg := manager.Config{
-		CAPath:      caPath,
-		KeyPath:     keyPath,
-		CertPath:    certPath,
-		GRPCPort:    5150,
-		TopoAddress: topoEndpoint,
-		Plugins:     plugins,
+		CAPath:                 caPath,
+		KeyPath:                keyPath,
+		CertPath:               certPath,
+		GRPCPort:               port,
+		TopoAddress:            topoEndpoint,
+		TopoFile:               topoFile,
+		Plugins:                plugins,
+		DialOutPort:            dialOutPort,
+		StoreBackend:           manager.StoreBackend(storeBackend),
+		StorePath:              storePath,
+		AllowUnvalidatedConfig: allowUnvalidatedConfig,
+		NodeLeaseDuration:      fileConfig.Controllers.NodeLeaseDuration,
+		BootstrapPath:          bootstrapPath,
+		ConfigFile:             configFile,
 	}
 
 	mgr := manager.NewManager(cfg)
@@ -107,3 +164,39 @@ func runRootCommand(cmd *cobra.Command, args []string) error {
 	mgr.Close()
 	return nil
 }
+
+// getStringFlag returns the value of the given flag if it was set, otherwise the value from the configuration file if any
+func getStringFlag(cmd *cobra.Command, name string, fileValue string) string {
+	value, _ := cmd.Flags().GetString(name)
+	if !cmd.Flags().Changed(name) && fileValue != "" {
+		return fileValue
+	}
+	return value
+}
+
+// getStringSliceFlag returns the value of the given flag if it was set, otherwise the value from the configuration file if any
+func getStringSliceFlag(cmd *cobra.Command, name string, fileValue []string) []string {
+	value, _ := cmd.Flags().GetStringSlice(name)
+	if !cmd.Flags().Changed(name) && len(fileValue) > 0 {
+		return fileValue
+	}
+	return value
+}
+
+// getIntFlag returns the value of the given flag if it was set, otherwise the value from the configuration file if any
+func getIntFlag(cmd *cobra.Command, name string, fileValue int) int {
+	value, _ := cmd.Flags().GetInt(name)
+	if !cmd.Flags().Changed(name) && fileValue != 0 {
+		return fileValue
+	}
+	return value
+}
+
+// getBoolFlag returns the value of the given flag if it was set, otherwise the value from the configuration file
+func getBoolFlag(cmd *cobra.Command, name string, fileValue bool) bool {
+	value, _ := cmd.Flags().GetBool(name)
+	if !cmd.Flags().Changed(name) {
+		return value || fileValue
+	}
+	return value
+}
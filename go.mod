@@ -3,6 +3,7 @@ module github.com/onosproject/onos-config
 go 1.16
 
 require (
+	github.com/Masterminds/semver/v3 v3.1.1
 	github.com/Pallinder/go-randomdata v1.2.0
 	github.com/atomix/atomix-go-client v0.6.2
 	github.com/atomix/atomix-go-framework v0.10.1
@@ -24,6 +25,8 @@ require (
 	github.com/prometheus/common v0.26.0
 	github.com/spf13/cobra v1.2.1
 	github.com/stretchr/testify v1.7.0
+	go.etcd.io/bbolt v1.3.6
+	golang.org/x/crypto v0.0.0-20220128200615-198e4374d7ed
 	golang.org/x/net v0.0.0-20220127200216-cd36cc0744dd
 	golang.org/x/oauth2 v0.0.0-20210819190943-2bc19b11175f
 	google.golang.org/grpc v1.41.0
@@ -0,0 +1,276 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batch applies sets of gNMI SetRequest files through the northbound gNMI service.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/config/load"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi_ext"
+)
+
+// defaultTimeout is the default timeout for applying a single file
+const defaultTimeout = 30 * time.Second
+
+// Status is the outcome of applying a single file
+type Status string
+
+const (
+	// StatusApplied indicates the file was applied as a transaction
+	StatusApplied Status = "Applied"
+	// StatusParsed indicates the file was parsed but neither validated nor applied by onos-config
+	// because only parsing was requested
+	StatusParsed Status = "Parsed"
+	// StatusFailed indicates the file could not be loaded or applied
+	StatusFailed Status = "Failed"
+	// StatusSkipped indicates the file was not applied because an earlier file failed
+	StatusSkipped Status = "Skipped"
+)
+
+// Options are the options for applying a batch of files
+type Options struct {
+	// ParseOnly parses the files and checks their transaction strategies without sending them to onos-config;
+	// the files are not validated against the target models
+	ParseOnly bool
+	// StopOnError skips the remaining files once a file fails
+	StopOnError bool
+	// Strategy is the transaction strategy of files without a strategy of their own;
+	// when nil, the strategy of the request or of onos-config is used
+	Strategy *configapi.TransactionStrategy
+	// FileStrategies are the transaction strategies of individual files, keyed by path or file name
+	FileStrategies map[string]configapi.TransactionStrategy
+	// Timeout is the timeout for applying a single file
+	Timeout time.Duration
+}
+
+// Result is the result of applying a single file
+type Result struct {
+	Path             string
+	Status           Status
+	Strategy         *configapi.TransactionStrategy
+	TransactionID    configapi.TransactionID
+	TransactionIndex configapi.Index
+	Updates          int
+	Replaces         int
+	Deletes          int
+	Err              error
+}
+
+// Apply applies the given files in order through the given gNMI client, each as one SetRequest
+func Apply(ctx context.Context, client gnmi.GNMIClient, paths []string, options Options) []Result {
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	results := make([]Result, 0, len(paths))
+	failed := false
+	for _, path := range paths {
+		result := Result{
+			Path: path,
+		}
+		if failed && options.StopOnError {
+			result.Status = StatusSkipped
+			results = append(results, result)
+			continue
+		}
+
+		if err := applyFile(ctx, client, path, options, timeout, &result); err != nil {
+			result.Status = StatusFailed
+			result.Err = err
+			failed = true
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func applyFile(ctx context.Context, client gnmi.GNMIClient, path string, options Options, timeout time.Duration, result *Result) error {
+	request, err := LoadFile(path)
+	if err != nil {
+		return err
+	}
+	result.Updates = len(request.Update)
+	result.Replaces = len(request.Replace)
+	result.Deletes = len(request.Delete)
+
+	strategy := options.Strategy
+	if fileStrategy, ok := options.FileStrategies[path]; ok {
+		strategy = &fileStrategy
+	} else if fileStrategy, ok := options.FileStrategies[filepath.Base(path)]; ok {
+		strategy = &fileStrategy
+	}
+	if strategy != nil {
+		if err := setTransactionStrategy(request, *strategy); err != nil {
+			return err
+		}
+		result.Strategy = strategy
+	}
+
+	if options.ParseOnly {
+		result.Status = StatusParsed
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	response, err := client.Set(ctx, request)
+	if err != nil {
+		return errors.FromGRPC(err)
+	}
+	transactionInfo, err := getTransactionInfo(response)
+	if err != nil {
+		return err
+	}
+	result.Status = StatusApplied
+	result.TransactionID = transactionInfo.ID
+	result.TransactionIndex = transactionInfo.Index
+	return nil
+}
+
+// LoadFile loads a SetRequest from a gnmi_cli textproto file (.gnmi) or a YAML file in the pkg/config/load format
+func LoadFile(path string) (*gnmi.SetRequest, error) {
+	var request *gnmi.SetRequest
+	switch filepath.Ext(path) {
+	case ".gnmi":
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.NewNotFound("failed reading %s: %v", path, err)
+		}
+		request = &gnmi.SetRequest{}
+		if err := proto.UnmarshalText(string(contents), request); err != nil {
+			return nil, errors.NewInvalid("%s is not a SetRequest: %v", path, err)
+		}
+	case ".yaml", ".yml":
+		config, err := load.LoadConfigGnmi(path)
+		if err != nil {
+			return nil, errors.NewInvalid("%s is not a SetRequest: %v", path, err)
+		}
+		request = load.ToGnmiSetRequest(&config)
+	default:
+		return nil, errors.NewInvalid("unsupported file type %s", path)
+	}
+	if len(request.Update)+len(request.Replace)+len(request.Delete) == 0 {
+		return nil, errors.NewInvalid("no updates, replace or deletes in %s", path)
+	}
+	return request, nil
+}
+
+// ParseStrategy parses a transaction strategy given as a comma separated synchronicity and isolation,
+// e.g. "synchronous,serializable"
+func ParseStrategy(s string) (configapi.TransactionStrategy, error) {
+	strategy := configapi.TransactionStrategy{}
+	for _, value := range strings.Split(s, ",") {
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if synchronicity, ok := configapi.TransactionStrategy_Synchronicity_value[value]; ok {
+			strategy.Synchronicity = configapi.TransactionStrategy_Synchronicity(synchronicity)
+		} else if isolation, ok := configapi.TransactionStrategy_Isolation_value[value]; ok {
+			strategy.Isolation = configapi.TransactionStrategy_Isolation(isolation)
+		} else {
+			return configapi.TransactionStrategy{}, errors.NewInvalid("unknown transaction strategy %s", s)
+		}
+	}
+	return strategy, nil
+}
+
+// setTransactionStrategy replaces the transaction strategy extension of the given request
+func setTransactionStrategy(request *gnmi.SetRequest, strategy configapi.TransactionStrategy) error {
+	bytes, err := gogoproto.Marshal(&strategy)
+	if err != nil {
+		return errors.NewInvalid(err.Error())
+	}
+	extensions := make([]*gnmi_ext.Extension, 0, len(request.Extension)+1)
+	for _, ext := range request.Extension {
+		if ext.GetRegisteredExt().GetId() != configapi.TransactionStrategyExtensionID {
+			extensions = append(extensions, ext)
+		}
+	}
+	request.Extension = append(extensions, &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_RegisteredExt{
+			RegisteredExt: &gnmi_ext.RegisteredExtension{
+				Id:  configapi.TransactionStrategyExtensionID,
+				Msg: bytes,
+			},
+		},
+	})
+	return nil
+}
+
+// getTransactionInfo returns the transaction info extension of the given response
+func getTransactionInfo(response *gnmi.SetResponse) (*configapi.TransactionInfo, error) {
+	for _, ext := range response.Extension {
+		if ext.GetRegisteredExt().GetId() == configapi.TransactionInfoExtensionID {
+			transactionInfo := &configapi.TransactionInfo{}
+			if err := gogoproto.Unmarshal(ext.GetRegisteredExt().GetMsg(), transactionInfo); err != nil {
+				return nil, errors.NewInvalid("invalid transaction info: %v", err)
+			}
+			return transactionInfo, nil
+		}
+	}
+	return nil, errors.NewNotFound("transaction info extension not found")
+}
+
+// WriteSummary writes a summary report of the given results
+func WriteSummary(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tSTATUS\tUPDATES\tREPLACES\tDELETES\tSTRATEGY\tTRANSACTION\tINDEX\tERROR")
+	counts := make(map[Status]int)
+	for _, result := range results {
+		counts[result.Status]++
+		strategy := "-"
+		if result.Strategy != nil {
+			strategy = strings.ToLower(fmt.Sprintf("%s,%s", result.Strategy.Synchronicity, result.Strategy.Isolation))
+		}
+		transactionID, index, errMsg := "-", "-", "-"
+		if result.TransactionID != "" {
+			transactionID = string(result.TransactionID)
+			index = fmt.Sprintf("%d", result.TransactionIndex)
+		}
+		if result.Err != nil {
+			errMsg = result.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n", result.Path, result.Status,
+			result.Updates, result.Replaces, result.Deletes, strategy, transactionID, index, errMsg)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d files: %d applied, %d parsed, %d failed, %d skipped\n", len(results),
+		counts[StatusApplied], counts[StatusParsed], counts[StatusFailed], counts[StatusSkipped])
+	return err
+}
+
+// Failed returns whether any of the given results failed
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
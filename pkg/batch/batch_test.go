@@ -0,0 +1,195 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/gnmi/proto/gnmi_ext"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+const (
+	setFile    = "../../gnmi_cli/set.timezone.gnmi"
+	deleteFile = "../../gnmi_cli/delete.timezone.gnmi"
+	getFile    = "../../gnmi_cli/get.timezone.gnmi"
+	yamlFile   = "../config/load/onos-config-load-sample-gnmi.yaml"
+)
+
+// testClient is a gNMI client recording SetRequests
+type testClient struct {
+	gnmi.GNMIClient
+	requests []*gnmi.SetRequest
+	fail     map[int]error
+}
+
+func (c *testClient) Set(ctx context.Context, request *gnmi.SetRequest, opts ...grpc.CallOption) (*gnmi.SetResponse, error) {
+	c.requests = append(c.requests, request)
+	index := len(c.requests)
+	if err := c.fail[index]; err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	bytes, err := gogoproto.Marshal(&configapi.TransactionInfo{
+		ID:    configapi.TransactionID(fmt.Sprintf("transaction-%d", index)),
+		Index: configapi.Index(index),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gnmi.SetResponse{
+		Extension: []*gnmi_ext.Extension{
+			{
+				Ext: &gnmi_ext.Extension_RegisteredExt{
+					RegisteredExt: &gnmi_ext.RegisteredExtension{
+						Id:  configapi.TransactionInfoExtensionID,
+						Msg: bytes,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func getStrategy(t *testing.T, request *gnmi.SetRequest) *configapi.TransactionStrategy {
+	for _, ext := range request.Extension {
+		if ext.GetRegisteredExt().GetId() == configapi.TransactionStrategyExtensionID {
+			strategy := &configapi.TransactionStrategy{}
+			assert.NoError(t, gogoproto.Unmarshal(ext.GetRegisteredExt().GetMsg(), strategy))
+			return strategy
+		}
+	}
+	return nil
+}
+
+func TestLoadFile(t *testing.T) {
+	request, err := LoadFile(setFile)
+	assert.NoError(t, err)
+	assert.Len(t, request.Update, 1)
+	assert.Equal(t, "devicesim-1", request.Update[0].Path.Target)
+	assert.Equal(t, "Europe/Paris", request.Update[0].Val.GetStringVal())
+
+	request, err = LoadFile(deleteFile)
+	assert.NoError(t, err)
+	assert.Len(t, request.Delete, 1)
+
+	request, err = LoadFile(yamlFile)
+	assert.NoError(t, err)
+	assert.Len(t, request.Update, 6)
+
+	_, err = LoadFile(getFile)
+	assert.True(t, errors.IsInvalid(err))
+
+	_, err = LoadFile("batch.go")
+	assert.True(t, errors.IsInvalid(err))
+
+	_, err = LoadFile("missing.gnmi")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestParseStrategy(t *testing.T) {
+	strategy, err := ParseStrategy("synchronous,serializable")
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.TransactionStrategy_SYNCHRONOUS, strategy.Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_SERIALIZABLE, strategy.Isolation)
+
+	strategy, err = ParseStrategy("Asynchronous")
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.TransactionStrategy_ASYNCHRONOUS, strategy.Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_DEFAULT, strategy.Isolation)
+
+	_, err = ParseStrategy("eventually")
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestApply(t *testing.T) {
+	client := &testClient{}
+	results := Apply(context.TODO(), client, []string{setFile, yamlFile, deleteFile}, Options{
+		Strategy: &configapi.TransactionStrategy{
+			Synchronicity: configapi.TransactionStrategy_SYNCHRONOUS,
+		},
+		FileStrategies: map[string]configapi.TransactionStrategy{
+			"delete.timezone.gnmi": {Isolation: configapi.TransactionStrategy_SERIALIZABLE},
+		},
+	})
+	assert.False(t, Failed(results))
+	assert.Len(t, results, 3)
+	assert.Len(t, client.requests, 3)
+	for i, result := range results {
+		assert.Equal(t, StatusApplied, result.Status)
+		assert.Equal(t, configapi.TransactionID(fmt.Sprintf("transaction-%d", i+1)), result.TransactionID)
+		assert.Equal(t, configapi.Index(i+1), result.TransactionIndex)
+	}
+
+	assert.Equal(t, configapi.TransactionStrategy_SYNCHRONOUS, getStrategy(t, client.requests[0]).Synchronicity)
+	assert.Len(t, client.requests[0].Extension, 3)
+	assert.Equal(t, configapi.TransactionStrategy_SYNCHRONOUS, getStrategy(t, client.requests[1]).Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_ASYNCHRONOUS, getStrategy(t, client.requests[2]).Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_SERIALIZABLE, getStrategy(t, client.requests[2]).Isolation)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteSummary(buf, results))
+	assert.Contains(t, buf.String(), "transaction-3")
+	assert.Contains(t, buf.String(), "3 files: 3 applied, 0 parsed, 0 failed, 0 skipped")
+}
+
+func TestApplyParseOnly(t *testing.T) {
+	client := &testClient{}
+	results := Apply(context.TODO(), client, []string{setFile, getFile, deleteFile}, Options{
+		ParseOnly: true,
+	})
+	assert.True(t, Failed(results))
+	assert.Len(t, client.requests, 0)
+	assert.Equal(t, StatusParsed, results[0].Status)
+	assert.Equal(t, 1, results[0].Updates)
+	assert.Nil(t, results[0].Strategy)
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.Equal(t, StatusParsed, results[2].Status)
+	assert.Equal(t, 1, results[2].Deletes)
+}
+
+func TestApplyStopOnError(t *testing.T) {
+	client := &testClient{
+		fail: map[int]error{2: errors.NewInvalid("invalid path")},
+	}
+	results := Apply(context.TODO(), client, []string{setFile, yamlFile, deleteFile}, Options{
+		StopOnError: true,
+	})
+	assert.True(t, Failed(results))
+	assert.Len(t, client.requests, 2)
+	assert.Equal(t, StatusApplied, results[0].Status)
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.True(t, errors.IsInvalid(results[1].Err))
+	assert.Equal(t, StatusSkipped, results[2].Status)
+
+	client = &testClient{
+		fail: map[int]error{2: errors.NewInvalid("invalid path")},
+	}
+	results = Apply(context.TODO(), client, []string{setFile, yamlFile, deleteFile}, Options{})
+	assert.Len(t, client.requests, 3)
+	assert.Equal(t, StatusApplied, results[2].Status)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteSummary(buf, results))
+	assert.Contains(t, buf.String(), "invalid path")
+	assert.Contains(t, buf.String(), "3 files: 2 applied, 0 parsed, 1 failed, 0 skipped")
+}
@@ -15,13 +15,205 @@
 package config
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
 	configlib "github.com/onosproject/onos-lib-go/pkg/config"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"gopkg.in/yaml.v2"
+)
+
+var log = logging.GetLogger("config")
+
+const (
+	// DefaultControllerTimeout is the default timeout of a single controller reconciliation
+	DefaultControllerTimeout = 30 * time.Second
+	// DefaultNodeLeaseDuration is the default duration of the onos-config node lease
+	DefaultNodeLeaseDuration = 30 * time.Second
+	// DefaultOPAURL is the default URL of the OPA sidecar
+	DefaultOPAURL = "http://localhost:8181"
 )
 
+// filePollInterval is the interval at which the configuration file is checked for changes
+const filePollInterval = time.Second
+
 var config *Config
 
 // Config is the onos-config configuration
 type Config struct {
+	// GRPCPort is the port on which the northbound gRPC services are served
+	GRPCPort int `yaml:"port"`
+	// AllowUnvalidatedConfig allows configuring targets without a model plugin
+	AllowUnvalidatedConfig bool     `yaml:"allowUnvalidatedConfig"`
+	CAPath                 string   `yaml:"caPath"`
+	KeyPath                string   `yaml:"keyPath"`
+	CertPath               string   `yaml:"certPath"`
+	TopoEndpoint           string   `yaml:"topoEndpoint"`
+	TopoFile               string   `yaml:"topoFile"`
+	Plugins                []string `yaml:"plugins"`
+	DialOutPort            int      `yaml:"dialOutPort"`
+	StoreBackend           string   `yaml:"storeBackend"`
+	StorePath              string   `yaml:"storePath"`
+	BootstrapPath          string   `yaml:"bootstrapPath"`
+	// Controllers configures the onos-config controllers
+	Controllers ControllersConfig `yaml:"controllers"`
+	// OPA configures the OPA sidecar used to filter Get responses
+	OPA OPAConfig `yaml:"opa"`
+	// Transactions configures the handling of transactions
+	Transactions TransactionsConfig `yaml:"transactions"`
+}
+
+// ControllersConfig is the controllers configuration
+type ControllersConfig struct {
+	// Timeout is the timeout of a single reconciliation; it may be changed at runtime
+	Timeout time.Duration `yaml:"timeout"`
+	// NodeLeaseDuration is the duration of the onos-config node lease in topo
+	NodeLeaseDuration time.Duration `yaml:"nodeLeaseDuration"`
+}
+
+// OPAConfig is the OPA sidecar configuration
+type OPAConfig struct {
+	// URL is the base URL of the OPA sidecar; it may be changed at runtime
+	URL string `yaml:"url"`
+}
+
+// TransactionsConfig is the transactions configuration
+type TransactionsConfig struct {
+	// Synchronicity is the default synchronicity of transactions: synchronous or asynchronous
+	Synchronicity string `yaml:"synchronicity"`
+	// Isolation is the default isolation of transactions: default or serializable
+	Isolation string `yaml:"isolation"`
+}
+
+// Default returns the configuration used when no configuration file is given
+func Default() *Config {
+	return &Config{
+		Controllers: ControllersConfig{
+			Timeout:           DefaultControllerTimeout,
+			NodeLeaseDuration: DefaultNodeLeaseDuration,
+		},
+		OPA: OPAConfig{
+			URL: DefaultOPAURL,
+		},
+	}
+}
+
+// Load loads the onos-config configuration from the YAML file at the given path
+func Load(path string) (*Config, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewNotFound("failed reading configuration file %s: %v", path, err)
+	}
+	return parse(contents)
+}
+
+// parse decodes the given configuration file contents, applying defaults to unset settings
+func parse(contents []byte) (*Config, error) {
+	c := Default()
+	if err := yaml.UnmarshalStrict(contents, c); err != nil {
+		return nil, errors.NewInvalid("invalid configuration file: %v", err)
+	}
+	if c.Controllers.Timeout <= 0 {
+		return nil, errors.NewInvalid("controllers timeout must be positive")
+	}
+	if c.Controllers.NodeLeaseDuration <= 0 {
+		return nil, errors.NewInvalid("controllers node lease duration must be positive")
+	}
+	if _, err := url.ParseRequestURI(c.OPA.URL); err != nil {
+		return nil, errors.NewInvalid("invalid OPA URL %s: %v", c.OPA.URL, err)
+	}
+	if _, err := c.Settings(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Settings returns the settings of the configuration that may be changed at runtime
+func (c *Config) Settings() (Settings, error) {
+	settings := Settings{
+		ControllerTimeout: c.Controllers.Timeout,
+		OPAURL:            strings.TrimSuffix(c.OPA.URL, "/"),
+	}
+	if c.Transactions.Synchronicity != "" {
+		synchronicity, ok := configapi.TransactionStrategy_Synchronicity_value[strings.ToUpper(c.Transactions.Synchronicity)]
+		if !ok {
+			return Settings{}, errors.NewInvalid("unknown transaction synchronicity %s", c.Transactions.Synchronicity)
+		}
+		settings.TransactionStrategy.Synchronicity = configapi.TransactionStrategy_Synchronicity(synchronicity)
+	}
+	if c.Transactions.Isolation != "" {
+		isolation, ok := configapi.TransactionStrategy_Isolation_value[strings.ToUpper(c.Transactions.Isolation)]
+		if !ok {
+			return Settings{}, errors.NewInvalid("unknown transaction isolation %s", c.Transactions.Isolation)
+		}
+		settings.TransactionStrategy.Isolation = configapi.TransactionStrategy_Isolation(isolation)
+	}
+	return settings, nil
+}
+
+// RequiresRestart returns whether changing from the given configuration to this one requires a restart,
+// i.e. whether any of the settings that are not reloaded at runtime differ
+func (c *Config) RequiresRestart(prev *Config) bool {
+	next := *c
+	next.Controllers.Timeout = prev.Controllers.Timeout
+	next.OPA = prev.OPA
+	next.Transactions = prev.Transactions
+	return !reflect.DeepEqual(&next, prev)
+}
+
+// Watch loads the configuration file at the given path and then polls it for changes until the
+// given context is done, calling the given function with the previous and the new configuration
+// each time a valid change is found
+func Watch(ctx context.Context, path string, f func(prev, next *Config)) (*Config, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewNotFound("failed reading configuration file %s: %v", path, err)
+	}
+	current, err := parse(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(filePollInterval)
+		defer ticker.Stop()
+		var lastErr string
+		for {
+			select {
+			case <-ticker.C:
+				next, err := ioutil.ReadFile(path)
+				if err == nil && bytes.Equal(next, contents) {
+					lastErr = ""
+					continue
+				}
+				var c *Config
+				if err == nil {
+					c, err = parse(next)
+				}
+				if err != nil {
+					if err.Error() != lastErr {
+						log.Warnf("Failed reloading configuration file %s: %v", path, err)
+						lastErr = err.Error()
+					}
+					continue
+				}
+				lastErr = ""
+				contents = next
+				f(current, c)
+				current = c
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return current, nil
 }
 
 // GetConfig gets the onos-config configuration
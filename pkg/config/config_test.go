@@ -0,0 +1,156 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfig = `
+port: 5151
+plugins:
+  - testdevice-1:5152
+storeBackend: embedded
+controllers:
+  timeout: 10s
+  nodeLeaseDuration: 1m
+opa:
+  url: http://opa:8181/
+transactions:
+  synchronicity: synchronous
+  isolation: serializable
+`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onos-config.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testConfig), 0644))
+
+	c, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 5151, c.GRPCPort)
+	assert.Equal(t, []string{"testdevice-1:5152"}, c.Plugins)
+	assert.Equal(t, "embedded", c.StoreBackend)
+	assert.Equal(t, 10*time.Second, c.Controllers.Timeout)
+	assert.Equal(t, time.Minute, c.Controllers.NodeLeaseDuration)
+
+	settings, err := c.Settings()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, settings.ControllerTimeout)
+	assert.Equal(t, "http://opa:8181", settings.OPAURL)
+	assert.Equal(t, configapi.TransactionStrategy_SYNCHRONOUS, settings.TransactionStrategy.Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_SERIALIZABLE, settings.TransactionStrategy.Isolation)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestLoadDefaults(t *testing.T) {
+	c, err := parse([]byte("port: 5151\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultControllerTimeout, c.Controllers.Timeout)
+	assert.Equal(t, DefaultNodeLeaseDuration, c.Controllers.NodeLeaseDuration)
+	assert.Equal(t, DefaultOPAURL, c.OPA.URL)
+
+	settings, err := c.Settings()
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.TransactionStrategy_ASYNCHRONOUS, settings.TransactionStrategy.Synchronicity)
+	assert.Equal(t, configapi.TransactionStrategy_DEFAULT, settings.TransactionStrategy.Isolation)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	invalid := []string{
+		"unknown: true\n",
+		"controllers:\n  timeout: 0s\n",
+		"controllers:\n  nodeLeaseDuration: -1s\n",
+		"controllers:\n  timeout: forever\n",
+		"opa:\n  url: opa\n",
+		"transactions:\n  synchronicity: eventually\n",
+		"transactions:\n  isolation: snapshot\n",
+	}
+	for _, contents := range invalid {
+		_, err := parse([]byte(contents))
+		assert.True(t, errors.IsInvalid(err), contents)
+	}
+}
+
+func TestRequiresRestart(t *testing.T) {
+	prev, err := parse([]byte(testConfig))
+	assert.NoError(t, err)
+
+	next, err := parse([]byte(testConfig))
+	assert.NoError(t, err)
+	next.Controllers.Timeout = time.Minute
+	next.OPA.URL = "http://localhost:8181"
+	next.Transactions.Synchronicity = "asynchronous"
+	assert.False(t, next.RequiresRestart(prev))
+
+	next.Controllers.NodeLeaseDuration = time.Hour
+	assert.True(t, next.RequiresRestart(prev))
+
+	next, err = parse([]byte(testConfig))
+	assert.NoError(t, err)
+	next.Plugins = nil
+	assert.True(t, next.RequiresRestart(prev))
+}
+
+// writeFile replaces the file at the given path atomically so the watcher never reads a partial file
+func writeFile(t *testing.T, path string, contents string) {
+	tmp := path + ".tmp"
+	assert.NoError(t, ioutil.WriteFile(tmp, []byte(contents), 0644))
+	assert.NoError(t, os.Rename(tmp, path))
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onos-config.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testConfig), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *Config, 1)
+	initial, err := Watch(ctx, path, func(prev, next *Config) {
+		ch <- next
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, initial.Controllers.Timeout)
+
+	// Invalid changes are ignored
+	writeFile(t, path, "controllers:\n  timeout: 0s\n")
+	select {
+	case <-ch:
+		t.Fatal("invalid configuration reloaded")
+	case <-time.After(2 * filePollInterval):
+	}
+
+	writeFile(t, path, "controllers:\n  timeout: 20s\n")
+	select {
+	case c := <-ch:
+		assert.Equal(t, 20*time.Second, c.Controllers.Timeout)
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration not reloaded")
+	}
+
+	_, err = Watch(ctx, filepath.Join(t.TempDir(), "missing.yaml"), func(prev, next *Config) {})
+	assert.True(t, errors.IsNotFound(err))
+}
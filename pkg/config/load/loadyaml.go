@@ -16,8 +16,11 @@ package load
 
 import (
 	"fmt"
+	"io/ioutil"
+
 	configlib "github.com/onosproject/onos-lib-go/pkg/config"
 	"github.com/openconfig/gnmi/proto/gnmi"
+	"gopkg.in/yaml.v2"
 )
 
 var configGnmi *ConfigGnmiSimple
@@ -67,6 +70,11 @@ func Checker(config *ConfigGnmiSimple) error {
 			return err
 		}
 	}
+	for _, u := range config.SetRequest.Replace {
+		if err := checkOnlyOneVal(u); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -120,3 +128,19 @@ func checkOnlyOneVal(u *Update) error {
 	}
 	return nil
 }
+
+// LoadConfigGnmi loads a simple native SetRequest from the YAML file at the given path
+func LoadConfigGnmi(path string) (ConfigGnmiSimple, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ConfigGnmiSimple{}, err
+	}
+	config := ConfigGnmiSimple{}
+	if err := yaml.Unmarshal(contents, &config); err != nil {
+		return ConfigGnmiSimple{}, fmt.Errorf("invalid SetRequest in %s: %v", path, err)
+	}
+	if err := Checker(&config); err != nil {
+		return ConfigGnmiSimple{}, err
+	}
+	return config, nil
+}
@@ -15,6 +15,7 @@
 package load
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"gopkg.in/yaml.v2"
 	"gotest.tools/assert"
@@ -122,3 +123,37 @@ func Test_LoadLeafList(t *testing.T) {
 	assert.Assert(t, !ok, "Fails here - no way to pass string value in from YAML %v", llVal.LeaflistVal.Element[0].Value)
 	//assert.Equal(t, "abc", strVal1.StringVal)
 }
+
+func Test_LoadConfigFile(t *testing.T) {
+	Clear()
+	named, err := GetConfigGnmi("onos-config-load-sample-gnmi.yaml")
+	assert.NilError(t, err, "Unexpected error loading gnmi setrequest yaml")
+
+	config, err := LoadConfigGnmi("onos-config-load-sample-gnmi.yaml")
+	assert.NilError(t, err, "Unexpected error loading gnmi setrequest yaml file")
+	assert.Assert(t, proto.Equal(ToGnmiSetRequest(&named), ToGnmiSetRequest(&config)))
+
+	_, err = LoadConfigGnmi("missing.yaml")
+	assert.Assert(t, err != nil)
+}
+
+func Test_ConvertReplace(t *testing.T) {
+	config := ConfigGnmiSimple{
+		SetRequest: SetRequest{
+			Replace: []*Update{
+				{
+					Path: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: "intervals"}}, Target: "315010-0001420"},
+					Val:  &TypedValue{UIntValue: &gnmi.TypedValue_UintVal{UintVal: 20}},
+				},
+			},
+		},
+	}
+	assert.NilError(t, Checker(&config))
+
+	gnmiSr := ToGnmiSetRequest(&config)
+	assert.Equal(t, "", gnmiSr.Prefix.Target)
+	assert.Equal(t, 0, len(gnmiSr.Update))
+	assert.Equal(t, 1, len(gnmiSr.Replace))
+	assert.Equal(t, "intervals", gnmiSr.Replace[0].Path.Elem[0].Name)
+	assert.Equal(t, uint64(20), gnmiSr.Replace[0].Val.Value.(*gnmi.TypedValue_UintVal).UintVal)
+}
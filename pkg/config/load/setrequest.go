@@ -66,8 +66,8 @@ type TypedValue struct {
 func ToGnmiSetRequest(sr *ConfigGnmiSimple) *gnmi.SetRequest {
 	gnmiSr := gnmi.SetRequest{
 		Prefix: &gnmi.Path{
-			Elem:   sr.SetRequest.Prefix.Elem,
-			Target: sr.SetRequest.Prefix.Target,
+			Elem:   sr.SetRequest.Prefix.GetElem(),
+			Target: sr.SetRequest.Prefix.GetTarget(),
 		},
 		Update:    make([]*gnmi.Update, 0),
 		Replace:   make([]*gnmi.Update, 0),
@@ -81,6 +81,13 @@ func ToGnmiSetRequest(sr *ConfigGnmiSimple) *gnmi.SetRequest {
 			Val:        fromStructTypeValueToGnmi(up.Val),
 		})
 	}
+	for _, rep := range sr.SetRequest.Replace {
+		gnmiSr.Replace = append(gnmiSr.Replace, &gnmi.Update{
+			Path:       rep.Path,
+			Duplicates: rep.Duplicates,
+			Val:        fromStructTypeValueToGnmi(rep.Val),
+		})
+	}
 	for _, e := range sr.SetRequest.Extension {
 		gnmiRegExt := fromStructExtensionToGnmi(e)
 		gnmiSr.Extension = append(gnmiSr.Extension, gnmiRegExt)
@@ -0,0 +1,54 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+)
+
+// Settings is the subset of the onos-config configuration that may be changed at runtime
+type Settings struct {
+	// ControllerTimeout is the timeout of a single controller reconciliation
+	ControllerTimeout time.Duration
+	// OPAURL is the base URL of the OPA sidecar
+	OPAURL string
+	// TransactionStrategy is the strategy of transactions whose requests do not specify one
+	TransactionStrategy configapi.TransactionStrategy
+}
+
+var (
+	settings = Settings{
+		ControllerTimeout: DefaultControllerTimeout,
+		OPAURL:            DefaultOPAURL,
+	}
+	settingsMu sync.RWMutex
+)
+
+// GetSettings returns the current runtime settings
+func GetSettings() Settings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return settings
+}
+
+// SetSettings replaces the current runtime settings
+func SetSettings(s Settings) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settings = s
+}
@@ -0,0 +1,200 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"fmt"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/utils/tree"
+	"github.com/onosproject/onos-lib-go/pkg/controller"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// reconcileAdoption imports the configuration found on a newly registered target as its initial configuration.
+// Adoption is only performed if it's enabled in the target's sync options and no configuration has yet been
+// created for the target, i.e. no changes have been proposed to the target.
+func (r *Reconciler) reconcileAdoption(ctx context.Context, targetID configapi.TargetID) (controller.Result, error) {
+	target, err := r.topo.Get(ctx, topoapi.ID(targetID))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed fetching target Entity '%s' from topo", targetID, err)
+			return controller.Result{}, err
+		}
+		return controller.Result{}, nil
+	}
+
+	syncOptions, err := topo.GetSyncOptions(target)
+	if err != nil {
+		log.Warnf("Failed reading sync options for target '%s'", targetID, err)
+		return controller.Result{}, nil
+	}
+	if !syncOptions.Adopt {
+		return controller.Result{}, nil
+	}
+
+	// Persistent targets have no device configuration to adopt
+	configurable := topoapi.Configurable{}
+	_ = target.GetAspect(&configurable)
+	if configurable.Persistent {
+		return controller.Result{}, nil
+	}
+
+	// If the master node ID is not set, wait for a master to be elected.
+	mastership := topoapi.MastershipState{}
+	_ = target.GetAspect(&mastership)
+	if mastership.NodeId == "" {
+		log.Debugf("No master for target '%s'", targetID)
+		return controller.Result{}, nil
+	}
+
+	// Only the master for the target adopts its configuration
+	conn, err := r.getMasterConn(ctx, targetID, mastership)
+	if err != nil {
+		return controller.Result{}, err
+	} else if conn == nil {
+		return controller.Result{}, nil
+	}
+
+	plugin, ok := r.getModelPlugin(target)
+	if !ok {
+		log.Warnf("Cannot adopt configuration of target '%s': no model plugin for type '%s' version '%s'",
+			targetID, configurable.Type, configurable.Version)
+		return controller.Result{}, nil
+	}
+
+	log.Infof("Adopting existing configuration of target '%s'", targetID)
+	values, err := r.getTargetValues(ctx, target, conn, plugin)
+	if err != nil {
+		log.Warnf("Failed reading configuration of target '%s'", targetID, err)
+		return controller.Result{}, err
+	}
+	if len(values) == 0 {
+		log.Infof("No configuration to adopt for target '%s'", targetID)
+		return controller.Result{}, nil
+	}
+
+	// Validate the device configuration against the model before recording it
+	jsonTree, err := tree.BuildTree(values, true)
+	if err != nil {
+		log.Warnf("Failed adopting configuration of target '%s'", targetID, err)
+		return controller.Result{}, nil
+	}
+	if err := plugin.Validate(ctx, jsonTree); err != nil {
+		log.Warnf("Cannot adopt configuration of target '%s': validation failed", targetID, err)
+		return controller.Result{}, nil
+	}
+
+	transaction := newInitialTransaction(targetID, values)
+	log.Infof("Recording adopted configuration of target '%s' in Transaction '%s'", targetID, transaction.ID)
+	if err := r.transactions.Create(ctx, transaction); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			log.Errorf("Failed creating Transaction '%s'", transaction.ID, err)
+			return controller.Result{}, err
+		}
+		transactionID := transaction.ID
+		transaction, err = r.transactions.Get(ctx, transactionID)
+		if err != nil {
+			log.Errorf("Failed fetching Transaction '%s'", transactionID, err)
+			return controller.Result{}, err
+		}
+	}
+	return controller.Result{}, r.markAdopted(ctx, targetID, transaction, values, mastership)
+}
+
+// markAdopted records the adopted values as already applied to the target in the configuration's status.
+// The adopted values were read from the target, so once the transaction is committed its proposal is marked
+// applied without sending them back to the target in a Set request.
+func (r *Reconciler) markAdopted(ctx context.Context, targetID configapi.TargetID, transaction *configapi.Transaction,
+	values []*configapi.PathValue, mastership topoapi.MastershipState) error {
+	appliedValues := make(map[string]*configapi.PathValue, len(values))
+	for _, value := range values {
+		appliedValues[value.Path] = value
+	}
+	mastershipTerm := configapi.MastershipTerm(mastership.Term)
+
+	configID := configuration.NewID(targetID)
+	config := &configapi.Configuration{
+		ID:       configID,
+		TargetID: targetID,
+		Status: configapi.ConfigurationStatus{
+			State: configapi.ConfigurationStatus_SYNCHRONIZED,
+			Mastership: configapi.MastershipInfo{
+				Master: mastership.NodeId,
+				Term:   mastershipTerm,
+			},
+			Applied: configapi.AppliedConfigurationStatus{
+				Index: transaction.Index,
+				Mastership: configapi.MastershipInfo{
+					Master: mastership.NodeId,
+					Term:   mastershipTerm,
+				},
+				Values: appliedValues,
+			},
+		},
+	}
+	log.Infof("Marking Transaction '%s' applied to Configuration '%s'", transaction.ID, configID)
+	err := r.configurations.Create(ctx, config)
+	if err == nil {
+		return nil
+	} else if !errors.IsAlreadyExists(err) {
+		log.Errorf("Failed creating Configuration '%s'", configID, err)
+		return err
+	}
+
+	// The configuration may have been created by the transaction's proposal first
+	config, err = r.configurations.Get(ctx, configID)
+	if err != nil {
+		log.Errorf("Failed fetching Configuration '%s'", configID, err)
+		return err
+	}
+	if config.Status.Applied.Index >= transaction.Index || config.Status.Mastership.Term > mastershipTerm {
+		return nil
+	}
+	config.Status.State = configapi.ConfigurationStatus_SYNCHRONIZED
+	config.Status.Mastership.Master = mastership.NodeId
+	config.Status.Mastership.Term = mastershipTerm
+	config.Status.Applied.Index = transaction.Index
+	config.Status.Applied.Mastership.Master = mastership.NodeId
+	config.Status.Applied.Mastership.Term = mastershipTerm
+	config.Status.Applied.Values = appliedValues
+	return r.updateConfigurationStatus(ctx, config)
+}
+
+// newInitialTransaction creates a change transaction recording the configuration adopted from a target.
+// The transaction ID is derived from the target ID to ensure the configuration is only adopted once.
+func newInitialTransaction(targetID configapi.TargetID, values []*configapi.PathValue) *configapi.Transaction {
+	changeValues := make(map[string]*configapi.PathValue, len(values))
+	for _, value := range values {
+		changeValues[value.Path] = value
+	}
+	return &configapi.Transaction{
+		ID: configapi.TransactionID(fmt.Sprintf("adopt-%s", targetID)),
+		Details: &configapi.Transaction_Change{
+			Change: &configapi.ChangeTransaction{
+				Values: map[configapi.TargetID]*configapi.PathValues{
+					targetID: {
+						Values: changeValues,
+					},
+				},
+			},
+		},
+		Username: systemUsername,
+	}
+}
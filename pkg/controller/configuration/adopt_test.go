@@ -0,0 +1,231 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
+	gnmitest "github.com/onosproject/onos-config/pkg/northbound/gnmi/test"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/store/transaction"
+	"github.com/onosproject/onos-config/pkg/utils"
+	pathutils "github.com/onosproject/onos-config/pkg/utils/path"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTarget   = "target-1"
+	testRelation = "relation-1"
+)
+
+// testConn is a southbound connection returning the configured values from Get and recording Get and Set requests.
+// Set requests fail with setErr if it is set.
+type testConn struct {
+	gnmi.Conn
+	values   map[string]string
+	gets     []*gpb.GetRequest
+	sets     int
+	requests []*gpb.SetRequest
+	setErr   error
+}
+
+func (c *testConn) Get(ctx context.Context, request *gpb.GetRequest) (*gpb.GetResponse, error) {
+	c.gets = append(c.gets, request)
+	notification := &gpb.Notification{}
+	for path, value := range c.values {
+		gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+		if err != nil {
+			return nil, err
+		}
+		notification.Update = append(notification.Update, &gpb.Update{
+			Path: gnmiPath,
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: value}},
+		})
+	}
+	return &gpb.GetResponse{Notification: []*gpb.Notification{notification}}, nil
+}
+
+func (c *testConn) Set(ctx context.Context, request *gpb.SetRequest) (*gpb.SetResponse, error) {
+	c.sets++
+	c.requests = append(c.requests, request)
+	if c.setErr != nil {
+		return nil, c.setErr
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+// testConnManager is a connection manager returning a single connection
+type testConnManager struct {
+	gnmi.ConnManager
+	conn gnmi.Conn
+}
+
+func (m *testConnManager) Get(ctx context.Context, connID gnmi.ConnID) (gnmi.Conn, bool) {
+	return m.conn, connID == testRelation
+}
+
+func newTestAdoptionReconciler(t *testing.T, conn gnmi.Conn) *Reconciler {
+	mctl := gomock.NewController(t)
+
+	target := &topoapi.Object{
+		ID:   testTarget,
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+	assert.NoError(t, target.SetAspect(&topoapi.Configurable{
+		Type:    "devicesim",
+		Version: "1.0.0",
+	}))
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{
+		Term:   1,
+		NodeId: testRelation,
+	}))
+	assert.NoError(t, target.SetAspectBytes(topo.SyncOptionsAspect, []byte(`{"adopt": true}`)))
+	relation := &topoapi.Object{
+		ID:   testRelation,
+		Type: topoapi.Object_RELATION,
+		Obj: &topoapi.Object_Relation{
+			Relation: &topoapi.Relation{
+				SrcEntityID: controllerutils.GetOnosConfigID(),
+				TgtEntityID: testTarget,
+			},
+		},
+	}
+	topoMock := gnmitest.NewMockStore(mctl)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(topoapi.ID(testTarget))).AnyTimes().Return(target, nil)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(topoapi.ID(testRelation))).AnyTimes().Return(relation, nil)
+	topoMock.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	rwPaths := pathutils.ReadWritePathMap{}
+	for _, path := range []string{"/system/config/hostname", "/system/config/domain-name"} {
+		rwPaths[path] = pathutils.ReadWritePathElem{
+			ReadOnlyAttrib: pathutils.ReadOnlyAttrib{
+				ValueType: configapi.ValueType_STRING,
+			},
+		}
+	}
+	plugin := gnmitest.NewMockModelPlugin(mctl)
+	plugin.EXPECT().GetInfo().AnyTimes().Return(&pluginregistry.ModelPluginInfo{ReadWritePaths: rwPaths})
+	plugin.EXPECT().Validate(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	registryMock := gnmitest.NewMockPluginRegistry(mctl)
+	registryMock.EXPECT().GetPlugin(configapi.TargetType("devicesim"), configapi.TargetVersion("1.0.0")).AnyTimes().Return(plugin, true)
+
+	return &Reconciler{
+		conns:          &testConnManager{conn: conn},
+		topo:           topoMock,
+		configurations: configuration.NewMemoryStore(),
+		statuses:       configuration.NewMemoryStatusStore(),
+		transactions:   transaction.NewMemoryStore(),
+		pluginRegistry: registryMock,
+	}
+}
+
+func TestReconcileAdoption(t *testing.T) {
+	conn := &testConn{
+		values: map[string]string{
+			"/system/config/hostname":    "switch-1",
+			"/system/config/domain-name": "example.com",
+		},
+	}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+
+	_, err := r.reconcileAdoption(ctx, testTarget)
+	assert.NoError(t, err)
+
+	// The adopted values are recorded in a transaction
+	transaction, err := r.transactions.Get(ctx, "adopt-"+testTarget)
+	assert.NoError(t, err)
+	assert.Len(t, transaction.GetChange().Values[testTarget].Values, 2)
+
+	// The transaction is marked applied to the target without sending the values back to it
+	config, err := r.configurations.Get(ctx, configuration.NewID(testTarget))
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+	assert.Equal(t, transaction.Index, config.Status.Applied.Index)
+	assert.Equal(t, configapi.MastershipTerm(1), config.Status.Applied.Mastership.Term)
+	assert.Equal(t, "switch-1", config.Status.Applied.Values["/system/config/hostname"].Value.ValueToString())
+	assert.Equal(t, 0, conn.sets)
+
+	// Adoption is idempotent
+	_, err = r.reconcileAdoption(ctx, testTarget)
+	assert.NoError(t, err)
+	transactions, err := r.transactions.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, 0, conn.sets)
+}
+
+func TestReconcileAdoptionAfterProposal(t *testing.T) {
+	conn := &testConn{
+		values: map[string]string{
+			"/system/config/hostname": "switch-1",
+		},
+	}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+
+	// The configuration may be created by the adopted transaction's proposal before it's marked applied
+	config := &configapi.Configuration{
+		ID:       configuration.NewID(testTarget),
+		TargetID: testTarget,
+		Status: configapi.ConfigurationStatus{
+			Proposed: configapi.ProposedConfigurationStatus{
+				Index: 1,
+			},
+		},
+	}
+	assert.NoError(t, r.configurations.Create(ctx, config))
+
+	_, err := r.reconcileAdoption(ctx, testTarget)
+	assert.NoError(t, err)
+
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.Index(1), config.Status.Applied.Index)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+	assert.Len(t, config.Status.Applied.Values, 1)
+	assert.Equal(t, 0, conn.sets)
+}
+
+func TestReconcileAdoptionDisabled(t *testing.T) {
+	conn := &testConn{
+		values: map[string]string{
+			"/system/config/hostname": "switch-1",
+		},
+	}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+	target, err := r.topo.Get(ctx, testTarget)
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspectBytes(topo.SyncOptionsAspect, []byte(`{}`)))
+
+	_, err = r.reconcileAdoption(ctx, testTarget)
+	assert.NoError(t, err)
+	transactions, err := r.transactions.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+}
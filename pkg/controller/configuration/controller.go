@@ -16,9 +16,14 @@ package configuration
 
 import (
 	"context"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi_ext"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+
 	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
@@ -31,18 +36,15 @@ import (
 	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
 	"github.com/onosproject/onos-config/pkg/store/configuration"
 	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/store/transaction"
 	"github.com/onosproject/onos-lib-go/pkg/controller"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 )
 
 var log = logging.GetLogger("controller", "configuration")
 
-const (
-	defaultTimeout = 30 * time.Second
-)
-
 // NewController returns a configuration controller
-func NewController(topo topo.Store, conns gnmi.ConnManager, configurations configuration.Store) *controller.Controller {
+func NewController(topo topo.Store, conns gnmi.ConnManager, configurations configuration.Store, statuses configuration.StatusStore, transactions transaction.Store, pluginRegistry pluginregistry.PluginRegistry) *controller.Controller {
 	c := controller.NewController("configuration")
 	c.Watch(&Watcher{
 		configurations: configurations,
@@ -54,6 +56,10 @@ func NewController(topo topo.Store, conns gnmi.ConnManager, configurations confi
 		conns:          conns,
 		topo:           topo,
 		configurations: configurations,
+		statuses:       statuses,
+		transactions:   transactions,
+		pluginRegistry: pluginRegistry,
+		driftChecks:    make(map[configapi.TargetID]time.Time),
 	})
 	return c
 }
@@ -63,11 +69,16 @@ type Reconciler struct {
 	conns          gnmi.ConnManager
 	topo           topo.Store
 	configurations configuration.Store
+	statuses       configuration.StatusStore
+	transactions   transaction.Store
+	pluginRegistry pluginregistry.PluginRegistry
+	driftChecks    map[configapi.TargetID]time.Time
+	driftChecksMu  sync.Mutex
 }
 
 // Reconcile reconciles target configurations
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
 	defer cancel()
 
 	configurationID := id.Value.(configapi.ConfigurationID)
@@ -78,7 +89,7 @@ func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
 			return controller.Result{}, err
 		}
 		log.Debugf("Configuration '%s' not found", configurationID)
-		return controller.Result{}, nil
+		return r.reconcileAdoption(ctx, configapi.TargetID(configurationID))
 	}
 
 	log.Infof("Reconciling Configuration '%s'", config.ID)
@@ -117,6 +128,11 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 	_ = target.GetAspect(&mastership)
 	mastershipTerm := configapi.MastershipTerm(mastership.Term)
 
+	// Flag the configuration if it is not validated by a model plugin
+	if err := r.reconcileValidation(ctx, config, configurable, mastership); err != nil {
+		return controller.Result{}, err
+	}
+
 	// If the target is persistent, mark the configuration PERSISTED.
 	if configurable.Persistent {
 		if config.Status.State != configapi.ConfigurationStatus_PERSISTED {
@@ -142,6 +158,11 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 		return controller.Result{}, nil
 	}
 
+	// If the configuration is SYNCHRONIZED, check the target for configuration drift.
+	if config.Status.State == configapi.ConfigurationStatus_SYNCHRONIZED {
+		return r.reconcileDrift(ctx, config, target, mastership)
+	}
+
 	// If the configuration is not SYNCHRONIZING, skip synchronization.
 	if config.Status.State != configapi.ConfigurationStatus_SYNCHRONIZING {
 		return controller.Result{}, nil
@@ -164,25 +185,11 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 	}
 
 	// If we've made it this far, we know there's a master relation.
-	// Get the relation and check whether this node is the source
-	relation, err := r.topo.Get(ctx, topoapi.ID(mastership.NodeId))
+	// Get the master connection if this node is the master
+	conn, err := r.getMasterConn(ctx, config.TargetID, mastership)
 	if err != nil {
-		if !errors.IsNotFound(err) {
-			log.Errorf("Failed fetching master Relation '%s' from topo", mastership.NodeId, err)
-			return controller.Result{}, err
-		}
-		log.Warnf("Master relation not found for target '%s'", config.TargetID)
-		return controller.Result{}, nil
-	}
-	if relation.GetRelation().SrcEntityID != controllerutils.GetOnosConfigID() {
-		log.Debugf("Not the master for target '%s'", config.TargetID)
-		return controller.Result{}, nil
-	}
-
-	// Get the master connection
-	conn, ok := r.conns.Get(ctx, gnmi.ConnID(relation.ID))
-	if !ok {
-		log.Warnf("Connection not found for target '%s'", config.TargetID)
+		return controller.Result{}, err
+	} else if conn == nil {
 		return controller.Result{}, nil
 	}
 
@@ -192,10 +199,44 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 			pathValues = append(pathValues, appliedValue)
 		}
 	}
-	log.Infof("Updating %d paths on target '%s'", len(pathValues), config.TargetID)
 
-	// Create a gNMI set request
-	setRequest, err := utilsv2.PathValuesToGnmiChange(pathValues)
+	// Sort the paths to ensure the request is split into the same chunks if it's retried
+	sort.Slice(pathValues, func(i, j int) bool {
+		return pathValues[i].Path < pathValues[j].Path
+	})
+
+	syncOptions, err := topo.GetSyncOptions(target)
+	if err != nil {
+		log.Warnf("Failed reading sync options for target '%s'; using defaults", config.TargetID, err)
+		syncOptions = &topo.SyncOptions{}
+	}
+
+	// A replace of the full configuration tree without any applied values would delete the target's
+	// configuration, so the applied paths are updated instead
+	replace := syncOptions.Replace
+	if replace && !hasAppliedValues(pathValues) {
+		log.Infof("No applied values to replace the configuration of target '%s' with; updating the applied paths", config.TargetID)
+		replace = false
+	}
+
+	// Create a gNMI set request, either replacing the full configuration tree or updating the applied paths
+	var setRequest *gpb.SetRequest
+	if replace {
+		encoding, encodingErr := topo.GetReplaceEncoding(target)
+		if encodingErr != nil {
+			log.Errorf("Cannot replace configuration of target '%s'; disable replace in its sync options", config.TargetID, encodingErr)
+			return controller.Result{}, nil
+		}
+		log.Infof("Replacing configuration with %d paths on target '%s'", len(pathValues), config.TargetID)
+		setRequest, err = utilsv2.PathValuesToGnmiReplace(pathValues, encoding)
+	} else {
+		encoding, encodingErr := topo.GetEncoding(target, gpb.Encoding_PROTO)
+		if encodingErr != nil {
+			log.Warnf("Failed reading encoding for target '%s'; using %s", config.TargetID, encoding, encodingErr)
+		}
+		log.Infof("Updating %d paths on target '%s'", len(pathValues), config.TargetID)
+		setRequest, err = utilsv2.PathValuesToGnmiChangeWithEncoding(pathValues, encoding)
+	}
 	if err != nil {
 		log.Errorf("Failed constructing SetRequest for Configuration '%s'", config.ID, err)
 		return controller.Result{}, nil
@@ -216,9 +257,16 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 	})
 
 	// Execute the set request
-	log.Debugf("Sending SetRequest %+v", setRequest)
-	setResponse, err := conn.Set(ctx, setRequest)
+	chunk, chunks, err := controllerutils.SetChunked(ctx, r.topo, target, topo.SetOperationSync,
+		uint64(config.Status.Applied.Index), uint64(mastershipTerm), setRequest, conn.Set, conn.Get)
 	if err != nil {
+		// A request that cannot be sent within the target's limits would be rejected again if retried.
+		// The configuration is reconciled again once the target's sync options are changed. Chunks
+		// that fail on the target are retried, resuming after the last applied chunk.
+		if chunk == 0 && errors.IsInvalid(err) {
+			log.Errorf("Cannot synchronize Configuration '%s'", config.ID, err)
+			return controller.Result{}, nil
+		}
 		// The gNMI Set request can be denied if this master has been superseded by a master in a later term.
 		// Rather than reverting to the STALE state now, wait for this node to see the mastership state change
 		// to avoid flapping between states while the system converges.
@@ -226,10 +274,9 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 			log.Warnf("Configuration '%s' mastership superseded for term %d", config.ID, mastershipTerm)
 			return controller.Result{}, nil
 		}
-		log.Errorf("Failed sending SetRequest %+v", setRequest, err)
+		log.Errorf("Failed sending chunk %d of %d of SetRequest %+v", chunk, chunks, setRequest, err)
 		return controller.Result{}, err
 	}
-	log.Debugf("Received SetResponse %+v", setResponse)
 
 	// Update the configuration state and path statuses
 	log.Infof("Configuration '%s' synchronization complete", config.ID)
@@ -242,6 +289,52 @@ func (r *Reconciler) reconcileConfiguration(ctx context.Context, config *configa
 	return controller.Result{}, nil
 }
 
+// hasAppliedValues returns whether any of the given values sets a path rather than deleting it
+func hasAppliedValues(pathValues []*configapi.PathValue) bool {
+	for _, pathValue := range pathValues {
+		if !pathValue.Deleted {
+			return true
+		}
+	}
+	return false
+}
+
+// getMasterConn returns the connection to the given target if this node is the master for the target.
+// If this node is not the master or the connection is not available, a nil connection is returned.
+func (r *Reconciler) getMasterConn(ctx context.Context, targetID configapi.TargetID, mastership topoapi.MastershipState) (gnmi.Conn, error) {
+	relation, err := r.getMasterRelation(ctx, targetID, mastership)
+	if err != nil || relation == nil {
+		return nil, err
+	}
+
+	// Get the master connection
+	conn, ok := r.conns.Get(ctx, gnmi.ConnID(relation.ID))
+	if !ok {
+		log.Warnf("Connection not found for target '%s'", targetID)
+		return nil, nil
+	}
+	return conn, nil
+}
+
+// getMasterRelation returns the master relation for the target if this node is the master, or nil otherwise
+func (r *Reconciler) getMasterRelation(ctx context.Context, targetID configapi.TargetID, mastership topoapi.MastershipState) (*topoapi.Object, error) {
+	// Get the master relation and check whether this node is the source
+	relation, err := r.topo.Get(ctx, topoapi.ID(mastership.NodeId))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed fetching master Relation '%s' from topo", mastership.NodeId, err)
+			return nil, err
+		}
+		log.Warnf("Master relation not found for target '%s'", targetID)
+		return nil, nil
+	}
+	if relation.GetRelation().SrcEntityID != controllerutils.GetOnosConfigID() {
+		log.Debugf("Not the master for target '%s'", targetID)
+		return nil, nil
+	}
+	return relation, nil
+}
+
 func (r *Reconciler) updateConfigurationStatus(ctx context.Context, configuration *configapi.Configuration) error {
 	log.Debug(configuration.Status)
 	err := r.configurations.UpdateStatus(ctx, configuration)
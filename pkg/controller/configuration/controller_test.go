@@ -0,0 +1,159 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestSyncConfiguration creates a configuration synchronizing the given applied values in the target's mastership term
+func newTestSyncConfiguration(t *testing.T, r *Reconciler, values ...*configapi.PathValue) *configapi.Configuration {
+	appliedValues := make(map[string]*configapi.PathValue)
+	for _, value := range values {
+		appliedValues[value.Path] = value
+	}
+	config := &configapi.Configuration{
+		ID:       configuration.NewID(testTarget),
+		TargetID: testTarget,
+		Status: configapi.ConfigurationStatus{
+			State: configapi.ConfigurationStatus_SYNCHRONIZING,
+			Mastership: configapi.MastershipInfo{
+				Master: testRelation,
+				Term:   1,
+			},
+			Applied: configapi.AppliedConfigurationStatus{
+				Index:  1,
+				Values: appliedValues,
+			},
+		},
+	}
+	assert.NoError(t, r.configurations.Create(context.Background(), config))
+	return config
+}
+
+func setTestSyncOptions(t *testing.T, r *Reconciler, options string) {
+	target, err := r.topo.Get(context.Background(), testTarget)
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspectBytes(topo.SyncOptionsAspect, []byte(options)))
+}
+
+func TestSynchronizeReplace(t *testing.T) {
+	conn := &testConn{}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+	setTestSyncOptions(t, r, `{"replace": true}`)
+	config := newTestSyncConfiguration(t, r, newStringValue("/system/config/hostname", "switch-1"))
+
+	_, err := r.reconcileConfiguration(ctx, config)
+	assert.NoError(t, err)
+
+	// The configuration root is replaced with the applied values
+	assert.Len(t, conn.requests, 1)
+	assert.Len(t, conn.requests[0].Update, 0)
+	assert.Len(t, conn.requests[0].Replace, 1)
+	assert.Len(t, conn.requests[0].Replace[0].Path.GetElem(), 0)
+	assert.JSONEq(t, `{"system": {"config": {"hostname": "switch-1"}}}`,
+		string(conn.requests[0].Replace[0].Val.GetJsonIetfVal()))
+
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+}
+
+func TestSynchronizeReplaceEncoding(t *testing.T) {
+	conn := &testConn{}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+	setTestSyncOptions(t, r, `{"replace": true, "encoding": "json"}`)
+	config := newTestSyncConfiguration(t, r, newStringValue("/system/config/hostname", "switch-1"))
+
+	// The replace is encoded in the target's encoding
+	_, err := r.reconcileConfiguration(ctx, config)
+	assert.NoError(t, err)
+	assert.Len(t, conn.requests, 1)
+	assert.JSONEq(t, `{"system": {"config": {"hostname": "switch-1"}}}`,
+		string(conn.requests[0].Replace[0].Val.GetJsonVal()))
+
+	// A target that does not support a JSON encoding is not synchronized
+	conn.requests = nil
+	setTestSyncOptions(t, r, `{"replace": true, "encoding": "proto"}`)
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	config.Status.State = configapi.ConfigurationStatus_SYNCHRONIZING
+	assert.NoError(t, r.configurations.UpdateStatus(ctx, config))
+	_, err = r.reconcileConfiguration(ctx, config)
+	assert.NoError(t, err)
+	assert.Empty(t, conn.requests)
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZING, config.Status.State)
+}
+
+func TestSynchronizeReplaceWithoutValues(t *testing.T) {
+	conn := &testConn{}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+	setTestSyncOptions(t, r, `{"replace": true}`)
+	config := newTestSyncConfiguration(t, r, &configapi.PathValue{
+		Path:    "/system/config/hostname",
+		Deleted: true,
+	})
+
+	_, err := r.reconcileConfiguration(ctx, config)
+	assert.NoError(t, err)
+
+	// The configuration root is not replaced when there are no applied values
+	assert.Len(t, conn.requests, 1)
+	assert.Len(t, conn.requests[0].Replace, 0)
+	assert.Len(t, conn.requests[0].Delete, 1)
+
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+}
+
+func TestSynchronizeChunkFailure(t *testing.T) {
+	conn := &testConn{}
+	r := newTestAdoptionReconciler(t, conn)
+	ctx := context.Background()
+	setTestSyncOptions(t, r, `{"maxUpdatesPerSet": 1}`)
+	config := newTestSyncConfiguration(t, r,
+		newStringValue("/system/config/domain-name", "example.com"),
+		newStringValue("/system/config/hostname", "switch-1"))
+
+	// A chunk rejected by the target is returned so the synchronization is retried
+	conn.setErr = errors.NewInvalid("rejected")
+	_, err := r.reconcileConfiguration(ctx, config)
+	assert.True(t, errors.IsInvalid(err))
+	assert.Len(t, conn.requests, 1)
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZING, config.Status.State)
+
+	conn.setErr = nil
+	_, err = r.reconcileConfiguration(ctx, config)
+	assert.NoError(t, err)
+	assert.Len(t, conn.requests, 3)
+	config, err = r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+}
@@ -0,0 +1,330 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	pathutils "github.com/onosproject/onos-config/pkg/utils/path"
+	utilsv2 "github.com/onosproject/onos-config/pkg/utils/values/v2"
+	"github.com/onosproject/onos-lib-go/pkg/controller"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// systemUsername is the user to which transactions created by onos-config itself are attributed
+const systemUsername = "onos-config"
+
+// reconcileDrift periodically compares the configuration of a SYNCHRONIZED target with the intended configuration
+// and records the paths that differ in the configuration's drift status
+func (r *Reconciler) reconcileDrift(ctx context.Context, config *configapi.Configuration, target *topoapi.Object, mastership topoapi.MastershipState) (controller.Result, error) {
+	driftOptions, err := topo.GetDriftOptions(target)
+	if err != nil {
+		log.Warnf("Failed reading drift detection options for target '%s'", config.TargetID, err)
+		return controller.Result{}, nil
+	}
+
+	// If no interval is configured, drift detection is disabled for the target
+	interval := time.Duration(driftOptions.Interval)
+	if interval == 0 {
+		return controller.Result{}, nil
+	}
+
+	status, err := r.getConfigurationStatus(ctx, config)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	// If the target was checked recently, wait for the next check to become due
+	if status.Drift != nil && status.Drift.Checked != nil {
+		nextCheck := status.Drift.Checked.Add(interval)
+		if time.Now().Before(nextCheck) {
+			return r.scheduleDriftCheck(config.TargetID, nextCheck), nil
+		}
+	}
+
+	// If the master node ID is not set, skip the check.
+	if mastership.NodeId == "" {
+		log.Debugf("No master for target '%s'", config.TargetID)
+		return controller.Result{}, nil
+	}
+
+	// Only the master for the target checks it for drift
+	conn, err := r.getMasterConn(ctx, config.TargetID, mastership)
+	if err != nil {
+		return controller.Result{}, err
+	} else if conn == nil {
+		return controller.Result{}, nil
+	}
+
+	// If committed changes have not yet been applied, the target cannot be compared with the intended configuration
+	if config.Status.Applied.Index < config.Status.Committed.Index {
+		log.Debugf("Postponing drift check for target '%s': changes are being applied", config.TargetID)
+		return r.scheduleDriftCheck(config.TargetID, time.Now().Add(interval)), nil
+	}
+
+	log.Infof("Checking target '%s' for configuration drift", config.TargetID)
+	checked := time.Now()
+	drift := &adminapi.DriftStatus{
+		Checked: &checked,
+		Index:   config.Index,
+	}
+	plugin, _ := r.getModelPlugin(target)
+	actualValues, err := r.getTargetValues(ctx, target, conn, plugin)
+	if err != nil {
+		log.Warnf("Failed checking target '%s' for configuration drift", config.TargetID, err)
+		drift.Error = err.Error()
+	} else {
+		drift.DriftedPaths = getDriftedPaths(config.Values, actualValues)
+		if len(drift.DriftedPaths) > 0 {
+			log.Warnf("Detected configuration drift for %d paths on target '%s'", len(drift.DriftedPaths), config.TargetID)
+			if err := r.remediateDrift(ctx, config, driftOptions.Remediation, drift, actualValues); err != nil {
+				return controller.Result{}, err
+			}
+		}
+	}
+
+	status.Drift = drift
+	if err := r.statuses.Update(ctx, status); err != nil {
+		log.Warnf("Failed updating drift status for Configuration '%s'", config.ID, err)
+		return controller.Result{}, err
+	}
+	return r.scheduleDriftCheck(config.TargetID, checked.Add(interval)), nil
+}
+
+// getConfigurationStatus returns the status tracked for the given configuration, or an empty status if none exists
+func (r *Reconciler) getConfigurationStatus(ctx context.Context, config *configapi.Configuration) (*adminapi.ConfigurationStatus, error) {
+	status, err := r.statuses.Get(ctx, config.ID)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed reading status of Configuration '%s'", config.ID, err)
+			return nil, err
+		}
+		status = &adminapi.ConfigurationStatus{
+			ID:       config.ID,
+			TargetID: config.TargetID,
+		}
+	}
+	return status, nil
+}
+
+// remediateDrift takes the configured remediation action for the drifted paths in the given drift status
+func (r *Reconciler) remediateDrift(ctx context.Context, config *configapi.Configuration, remediation topo.DriftRemediation, drift *adminapi.DriftStatus, actualValues []*configapi.PathValue) error {
+	drift.Remediation = string(remediation)
+	switch remediation {
+	case topo.DriftRemediationReconcile:
+		// Revert the configuration to the SYNCHRONIZING state to push the applied configuration to the target
+		log.Infof("Reconciling drifted configuration on target '%s'", config.TargetID)
+		config.Status.State = configapi.ConfigurationStatus_SYNCHRONIZING
+		return r.updateConfigurationStatus(ctx, config)
+	case topo.DriftRemediationAdopt:
+		transaction := newAdoptTransaction(config, drift, actualValues)
+		transactionID, err := r.getAdoptTransactionID(ctx, transaction.ID)
+		if err != nil {
+			return err
+		}
+		drift.TransactionID = transactionID
+		if transactionID != transaction.ID {
+			log.Warnf("Transaction '%s' adopting drifted configuration on target '%s' failed", transaction.ID, config.TargetID)
+			transaction.ID = transactionID
+		}
+		log.Infof("Adopting drifted configuration on target '%s' in Transaction '%s'", config.TargetID, transaction.ID)
+		if err := r.transactions.Create(ctx, transaction); err != nil && !errors.IsAlreadyExists(err) {
+			log.Errorf("Failed creating Transaction '%s'", transaction.ID, err)
+			return err
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// getAdoptTransactionID returns the ID of the transaction adopting a drift. If transactions adopting the same
+// drift have failed, the ID of the next attempt is returned so that the drift is adopted again.
+func (r *Reconciler) getAdoptTransactionID(ctx context.Context, transactionID configapi.TransactionID) (configapi.TransactionID, error) {
+	for attempt := 0; ; attempt++ {
+		attemptID := transactionID
+		if attempt > 0 {
+			attemptID = configapi.TransactionID(fmt.Sprintf("%s-%d", transactionID, attempt))
+		}
+		transaction, err := r.transactions.Get(ctx, attemptID)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return attemptID, nil
+			}
+			log.Errorf("Failed reading Transaction '%s'", attemptID, err)
+			return "", err
+		}
+		if transaction.Status.State != configapi.TransactionStatus_FAILED {
+			return attemptID, nil
+		}
+	}
+}
+
+// newAdoptTransaction creates a change transaction setting the drifted paths to the values read from the target.
+// The transaction ID is derived from the configuration index and the adopted values to avoid adopting the same
+// drift more than once.
+func newAdoptTransaction(config *configapi.Configuration, drift *adminapi.DriftStatus, actualValues []*configapi.PathValue) *configapi.Transaction {
+	actualValuesByPath := make(map[string]*configapi.PathValue, len(actualValues))
+	for _, actualValue := range actualValues {
+		actualValuesByPath[actualValue.Path] = actualValue
+	}
+
+	values := make(map[string]*configapi.PathValue)
+	hash := sha256.New()
+	for _, driftedPath := range drift.DriftedPaths {
+		if actualValue, ok := actualValuesByPath[driftedPath.Path]; ok {
+			values[driftedPath.Path] = &configapi.PathValue{
+				Path:  driftedPath.Path,
+				Value: actualValue.Value,
+			}
+			fmt.Fprintf(hash, "%s\x00%d\x00%x\x00%v\n", driftedPath.Path, actualValue.Value.Type, actualValue.Value.Bytes, actualValue.Value.TypeOpts)
+		} else {
+			values[driftedPath.Path] = &configapi.PathValue{
+				Path:    driftedPath.Path,
+				Deleted: true,
+			}
+			fmt.Fprintf(hash, "%s\x00deleted\n", driftedPath.Path)
+		}
+	}
+
+	return &configapi.Transaction{
+		ID: configapi.TransactionID(fmt.Sprintf("drift-%s-%d-%x", config.TargetID, config.Index, hash.Sum(nil)[:8])),
+		Details: &configapi.Transaction_Change{
+			Change: &configapi.ChangeTransaction{
+				Values: map[configapi.TargetID]*configapi.PathValues{
+					config.TargetID: {
+						Values: values,
+					},
+				},
+			},
+		},
+		Username: systemUsername,
+	}
+}
+
+// getModelPlugin returns the model plugin for the given target, if one is registered
+func (r *Reconciler) getModelPlugin(target *topoapi.Object) (pluginregistry.ModelPlugin, bool) {
+	configurable := topoapi.Configurable{}
+	_ = target.GetAspect(&configurable)
+	return r.pluginRegistry.GetPlugin(configapi.TargetType(configurable.Type), configapi.TargetVersion(configurable.Version))
+}
+
+// getTargetValues reads the configuration from the target in its negotiated encoding and converts it to path values.
+// If a model plugin is provided, it is used to determine the types of the values returned by the target.
+func (r *Reconciler) getTargetValues(ctx context.Context, target *topoapi.Object, conn gnmi.Conn, plugin pluginregistry.ModelPlugin) ([]*configapi.PathValue, error) {
+	var rwPaths pathutils.ReadWritePathMap
+	var decodeJSON utilsv2.JSONDecoder
+	if plugin != nil {
+		rwPaths = plugin.GetInfo().ReadWritePaths
+		decodeJSON = func(pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error) {
+			return plugin.GetPathValues(ctx, pathPrefix, jsonData)
+		}
+	}
+
+	encoding, err := topo.GetEncoding(target, gpb.Encoding_PROTO)
+	if err != nil {
+		log.Warnf("Failed reading encoding for target '%s'; using %s", target.ID, encoding, err)
+	}
+
+	getRequest := &gpb.GetRequest{
+		Path:     []*gpb.Path{{}},
+		Type:     gpb.GetRequest_CONFIG,
+		Encoding: encoding,
+	}
+	log.Debugf("Sending GetRequest %+v", getRequest)
+	getResponse, err := conn.Get(ctx, getRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Received GetResponse %+v", getResponse)
+	return utilsv2.GnmiNotificationsToPathValues(getResponse.Notification, rwPaths, decodeJSON)
+}
+
+// scheduleDriftCheck requeues the configuration at the given time unless an earlier check is already scheduled.
+// Tracking scheduled checks prevents each reconciliation of the configuration from adding another timer.
+func (r *Reconciler) scheduleDriftCheck(targetID configapi.TargetID, checkTime time.Time) controller.Result {
+	r.driftChecksMu.Lock()
+	defer r.driftChecksMu.Unlock()
+	if scheduled, ok := r.driftChecks[targetID]; ok && scheduled.After(time.Now()) && !scheduled.After(checkTime) {
+		return controller.Result{}
+	}
+	r.driftChecks[targetID] = checkTime
+	return controller.Result{RequeueAt: checkTime}
+}
+
+// getDriftedPaths compares the intended path values with the actual path values read from the target
+func getDriftedPaths(intendedValues map[string]*configapi.PathValue, actualValues []*configapi.PathValue) []adminapi.DriftedPath {
+	actualValuesByPath := make(map[string]*configapi.PathValue, len(actualValues))
+	for _, actualValue := range actualValues {
+		actualValuesByPath[actualValue.Path] = actualValue
+	}
+
+	driftedPaths := make(map[string]adminapi.DriftedPath)
+	for _, intendedValue := range intendedValues {
+		if intendedValue.Deleted {
+			// Any value at or beneath a deleted path on the target is drift
+			for path, actualValue := range actualValuesByPath {
+				if path == intendedValue.Path || isChildPath(intendedValue.Path, path) {
+					driftedPaths[path] = adminapi.DriftedPath{
+						Path:   path,
+						Actual: actualValue.Value.ValueToString(),
+					}
+				}
+			}
+			continue
+		}
+
+		actualValue, ok := actualValuesByPath[intendedValue.Path]
+		if !ok {
+			driftedPaths[intendedValue.Path] = adminapi.DriftedPath{
+				Path:     intendedValue.Path,
+				Intended: intendedValue.Value.ValueToString(),
+			}
+		} else if actualValue.Value.Type != intendedValue.Value.Type ||
+			actualValue.Value.ValueToString() != intendedValue.Value.ValueToString() {
+			driftedPaths[intendedValue.Path] = adminapi.DriftedPath{
+				Path:     intendedValue.Path,
+				Intended: intendedValue.Value.ValueToString(),
+				Actual:   actualValue.Value.ValueToString(),
+			}
+		}
+	}
+
+	paths := make([]adminapi.DriftedPath, 0, len(driftedPaths))
+	for _, driftedPath := range driftedPaths {
+		paths = append(paths, driftedPath)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Path < paths[j].Path
+	})
+	return paths
+}
+
+// isChildPath returns whether the given path is beneath the given parent path
+func isChildPath(parent string, path string) bool {
+	return strings.HasPrefix(path, parent+"/") || strings.HasPrefix(path, parent+"[")
+}
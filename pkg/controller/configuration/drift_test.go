@@ -0,0 +1,237 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStringValue(path string, value string) *configapi.PathValue {
+	return &configapi.PathValue{
+		Path:  path,
+		Value: *configapi.NewTypedValueString(value),
+	}
+}
+
+func TestGetDriftedPaths(t *testing.T) {
+	intendedValues := map[string]*configapi.PathValue{
+		"/system/config/hostname":    newStringValue("/system/config/hostname", "switch-1"),
+		"/system/config/domain-name": newStringValue("/system/config/domain-name", "example.com"),
+		"/system/config/motd-banner": newStringValue("/system/config/motd-banner", "hello"),
+		"/system/ntp": {
+			Path:    "/system/ntp",
+			Deleted: true,
+		},
+	}
+
+	// A target matching the intended configuration has not drifted
+	actualValues := []*configapi.PathValue{
+		newStringValue("/system/config/hostname", "switch-1"),
+		newStringValue("/system/config/domain-name", "example.com"),
+		newStringValue("/system/config/motd-banner", "hello"),
+		newStringValue("/system/clock/config/timezone-name", "UTC"),
+	}
+	assert.Empty(t, getDriftedPaths(intendedValues, actualValues))
+
+	// Changed, missing and deleted paths have drifted
+	actualValues = []*configapi.PathValue{
+		newStringValue("/system/config/hostname", "switch-2"),
+		newStringValue("/system/config/domain-name", "example.com"),
+		newStringValue("/system/ntp/config/enabled", "true"),
+		newStringValue("/system/ntpd", "true"),
+	}
+	assert.Equal(t, []adminapi.DriftedPath{
+		{Path: "/system/config/hostname", Intended: "switch-1", Actual: "switch-2"},
+		{Path: "/system/config/motd-banner", Intended: "hello"},
+		{Path: "/system/ntp/config/enabled", Actual: "true"},
+	}, getDriftedPaths(intendedValues, actualValues))
+
+	// A value of a different type has drifted
+	actualValues = []*configapi.PathValue{
+		{
+			Path:  "/system/config/hostname",
+			Value: *configapi.NewTypedValueString("switch-1"),
+		},
+		{
+			Path:  "/system/config/domain-name",
+			Value: *configapi.NewTypedValueString("example.com"),
+		},
+		{
+			Path:  "/system/config/motd-banner",
+			Value: *configapi.NewTypedValueBytes([]byte("hello")),
+		},
+	}
+	driftedPaths := getDriftedPaths(intendedValues, actualValues)
+	assert.Len(t, driftedPaths, 1)
+	assert.Equal(t, "/system/config/motd-banner", driftedPaths[0].Path)
+}
+
+// newTestDriftReconciler returns a reconciler for a SYNCHRONIZED configuration of the test target whose hostname
+// has drifted, using the given drift remediation
+func newTestDriftReconciler(t *testing.T, remediation topo.DriftRemediation) (*Reconciler, *testConn, *configapi.Configuration) {
+	conn := &testConn{
+		values: map[string]string{
+			"/system/config/hostname":    "switch-2",
+			"/system/config/domain-name": "example.com",
+		},
+	}
+	r := newTestAdoptionReconciler(t, conn)
+	r.driftChecks = make(map[configapi.TargetID]time.Time)
+	target, err := r.topo.Get(context.Background(), testTarget)
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspectBytes(topo.DriftOptionsAspect, []byte(`{"interval": "1m", "remediation": "`+remediation+`"}`)))
+
+	config := &configapi.Configuration{
+		ID:       configuration.NewID(testTarget),
+		TargetID: testTarget,
+		Values: map[string]*configapi.PathValue{
+			"/system/config/hostname":    newStringValue("/system/config/hostname", "switch-1"),
+			"/system/config/domain-name": newStringValue("/system/config/domain-name", "example.com"),
+		},
+		Status: configapi.ConfigurationStatus{
+			State: configapi.ConfigurationStatus_SYNCHRONIZED,
+			Mastership: configapi.MastershipInfo{
+				Master: testRelation,
+				Term:   1,
+			},
+		},
+	}
+	assert.NoError(t, r.configurations.Create(context.Background(), config))
+	config, err = r.configurations.Get(context.Background(), config.ID)
+	assert.NoError(t, err)
+	return r, conn, config
+}
+
+// checkTestDrift checks the test target for drift and returns the recorded drift status
+func checkTestDrift(t *testing.T, r *Reconciler, config *configapi.Configuration) *adminapi.DriftStatus {
+	ctx := context.Background()
+	target, err := r.topo.Get(ctx, testTarget)
+	assert.NoError(t, err)
+
+	// Clear the last check so that the check is due
+	status, err := r.getConfigurationStatus(ctx, config)
+	assert.NoError(t, err)
+	status.Drift = nil
+	assert.NoError(t, r.statuses.Update(ctx, status))
+
+	_, err = r.reconcileDrift(ctx, config, target, topoapi.MastershipState{Term: 1, NodeId: testRelation})
+	assert.NoError(t, err)
+	status, err = r.statuses.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, status.Drift)
+	return status.Drift
+}
+
+func TestRemediateDriftAlert(t *testing.T) {
+	r, conn, config := newTestDriftReconciler(t, topo.DriftRemediationAlert)
+	ctx := context.Background()
+
+	// The drifted paths are only recorded in the drift status
+	drift := checkTestDrift(t, r, config)
+	assert.Equal(t, []adminapi.DriftedPath{
+		{Path: "/system/config/hostname", Intended: "switch-1", Actual: "switch-2"},
+	}, drift.DriftedPaths)
+	assert.Equal(t, string(topo.DriftRemediationAlert), drift.Remediation)
+	assert.Empty(t, drift.TransactionID)
+
+	config, err := r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, config.Status.State)
+	transactions, err := r.transactions.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+	assert.Equal(t, 0, conn.sets)
+}
+
+func TestRemediateDriftReconcile(t *testing.T) {
+	r, conn, config := newTestDriftReconciler(t, topo.DriftRemediationReconcile)
+	ctx := context.Background()
+
+	// The configuration is synchronized to the target again
+	drift := checkTestDrift(t, r, config)
+	assert.Len(t, drift.DriftedPaths, 1)
+	assert.Equal(t, string(topo.DriftRemediationReconcile), drift.Remediation)
+
+	config, err := r.configurations.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZING, config.Status.State)
+	transactions, err := r.transactions.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+	assert.Equal(t, 0, conn.sets)
+}
+
+func TestRemediateDriftAdopt(t *testing.T) {
+	r, conn, config := newTestDriftReconciler(t, topo.DriftRemediationAdopt)
+	ctx := context.Background()
+
+	// The drifted values are adopted in a transaction attributed to onos-config
+	drift := checkTestDrift(t, r, config)
+	assert.Equal(t, string(topo.DriftRemediationAdopt), drift.Remediation)
+	assert.True(t, strings.HasPrefix(string(drift.TransactionID), "drift-"+testTarget+"-"))
+	transaction, err := r.transactions.Get(ctx, drift.TransactionID)
+	assert.NoError(t, err)
+	assert.Equal(t, systemUsername, transaction.Username)
+	values := transaction.GetChange().Values[testTarget].Values
+	assert.Len(t, values, 1)
+	assert.Equal(t, "switch-2", values["/system/config/hostname"].Value.ValueToString())
+	assert.Equal(t, 0, conn.sets)
+
+	// The same drift is adopted only once
+	assert.Equal(t, drift.TransactionID, checkTestDrift(t, r, config).TransactionID)
+	transactions, err := r.transactions.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+
+	// A drift with other values is adopted in another transaction
+	conn.values["/system/config/hostname"] = "switch-3"
+	otherDrift := checkTestDrift(t, r, config)
+	assert.NotEqual(t, drift.TransactionID, otherDrift.TransactionID)
+	conn.values["/system/config/hostname"] = "switch-2"
+
+	// If adopting the drift failed, it is adopted again in a new transaction
+	transaction.Status.State = configapi.TransactionStatus_FAILED
+	assert.NoError(t, r.transactions.UpdateStatus(ctx, transaction))
+	retryDrift := checkTestDrift(t, r, config)
+	assert.Equal(t, drift.TransactionID+"-1", retryDrift.TransactionID)
+	_, err = r.transactions.Get(ctx, retryDrift.TransactionID)
+	assert.NoError(t, err)
+	assert.Equal(t, retryDrift.TransactionID, checkTestDrift(t, r, config).TransactionID)
+}
+
+func TestDriftCheckEncoding(t *testing.T) {
+	r, conn, config := newTestDriftReconciler(t, topo.DriftRemediationAlert)
+
+	// The target is read in PROTO encoding unless it does not support it
+	checkTestDrift(t, r, config)
+	assert.Len(t, conn.gets, 1)
+	assert.Equal(t, gpb.Encoding_PROTO, conn.gets[0].Encoding)
+
+	setTestSyncOptions(t, r, `{"encoding": "json_ietf"}`)
+	checkTestDrift(t, r, config)
+	assert.Len(t, conn.gets, 2)
+	assert.Equal(t, gpb.Encoding_JSON_IETF, conn.gets[1].Encoding)
+}
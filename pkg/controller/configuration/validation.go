@@ -0,0 +1,67 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+)
+
+// reconcileValidation records in the configuration status whether the target's configuration is validated by a
+// model plugin. Targets are only flagged once they are configured without validation, and the flag is cleared once
+// a plugin is registered for the target's type and version. Like the other configuration statuses, the validation
+// status is only written by the master for the target.
+func (r *Reconciler) reconcileValidation(ctx context.Context, config *configapi.Configuration, configurable topoapi.Configurable, mastership topoapi.MastershipState) error {
+	plugin, ok := r.pluginRegistry.GetPlugin(configapi.TargetType(configurable.Type), configapi.TargetVersion(configurable.Version))
+	validation := &adminapi.ValidationStatus{
+		Type:      configapi.TargetType(configurable.Type),
+		Version:   configapi.TargetVersion(configurable.Version),
+		Validated: ok && !plugin.GetInfo().Unvalidated,
+	}
+
+	status, err := r.getConfigurationStatus(ctx, config)
+	if err != nil {
+		return err
+	}
+	if status.Validation == nil {
+		if validation.Validated {
+			return nil
+		}
+	} else if *status.Validation == *validation {
+		return nil
+	}
+
+	if mastership.NodeId == "" {
+		return nil
+	}
+	if relation, err := r.getMasterRelation(ctx, config.TargetID, mastership); err != nil || relation == nil {
+		return err
+	}
+
+	if validation.Validated {
+		log.Infof("Configuration '%s' is validated by model plugin '%s/%s'", config.ID, configurable.Type, configurable.Version)
+	} else {
+		log.Warnf("Configuration '%s' is not validated: no model plugin for type '%s' version '%s'", config.ID, configurable.Type, configurable.Version)
+	}
+	status.Validation = validation
+	if err := r.statuses.Update(ctx, status); err != nil {
+		log.Warnf("Failed updating validation status for Configuration '%s'", config.ID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	gnmitest "github.com/onosproject/onos-config/pkg/northbound/gnmi/test"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileValidation(t *testing.T) {
+	r := newTestAdoptionReconciler(t, &testConn{})
+	ctx := context.Background()
+	config := &configapi.Configuration{
+		ID:       configuration.NewID(testTarget),
+		TargetID: testTarget,
+	}
+	configurable := topoapi.Configurable{Type: "devicesim", Version: "1.0.0"}
+	mastership := topoapi.MastershipState{Term: 1, NodeId: testRelation}
+
+	// Validated configurations are not flagged
+	assert.NoError(t, r.reconcileValidation(ctx, config, configurable, mastership))
+	_, err := r.statuses.Get(ctx, config.ID)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Configurations without a model plugin are flagged as unvalidated
+	plugin := gnmitest.NewMockModelPlugin(gomock.NewController(t))
+	plugin.EXPECT().GetInfo().AnyTimes().Return(&pluginregistry.ModelPluginInfo{Unvalidated: true})
+	registry := gnmitest.NewMockPluginRegistry(gomock.NewController(t))
+	registry.EXPECT().GetPlugin(gomock.Any(), gomock.Any()).AnyTimes().Return(plugin, true)
+	validatedRegistry := r.pluginRegistry
+	r.pluginRegistry = registry
+
+	// Only the master records the validation status
+	assert.NoError(t, r.reconcileValidation(ctx, config, configurable, topoapi.MastershipState{}))
+	_, err = r.statuses.Get(ctx, config.ID)
+	assert.True(t, errors.IsNotFound(err))
+
+	assert.NoError(t, r.reconcileValidation(ctx, config, configurable, mastership))
+	status, err := r.statuses.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.False(t, status.Validation.Validated)
+	assert.Equal(t, configapi.TargetType("devicesim"), status.Validation.Type)
+	assert.Equal(t, configapi.TargetVersion("1.0.0"), status.Validation.Version)
+
+	// The flag is cleared once a model plugin is registered for the target
+	r.pluginRegistry = validatedRegistry
+	assert.NoError(t, r.reconcileValidation(ctx, config, configurable, mastership))
+	status, err = r.statuses.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	assert.True(t, status.Validation.Validated)
+}
@@ -0,0 +1,195 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// verifyCapabilities compares the capabilities of the target behind the given connection with the
+// capabilities of the model plugin for the target's type and version, and records the result on the target.
+// If the target's type or version is not set or is "auto", they are first detected from the target capabilities.
+func (r *Reconciler) verifyCapabilities(ctx context.Context, conn gnmi.Conn) error {
+	target, err := r.topo.Get(ctx, conn.TargetID())
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed fetching target Entity '%s' from topo", conn.TargetID(), err)
+			return err
+		}
+		return nil
+	}
+
+	log.Infof("Verifying capabilities of target '%s'", conn.TargetID())
+	capabilities, err := conn.Capabilities(ctx, &gpb.CapabilityRequest{})
+	if err != nil {
+		log.Warnf("Failed verifying capabilities of target '%s'", conn.TargetID(), err)
+		return err
+	}
+
+	// If the target type or version is not set, detect them from the models supported by the target.
+	// A type or version that is set is kept, and only plugins matching it are considered.
+	configurable := topoapi.Configurable{}
+	_ = target.GetAspect(&configurable)
+	detected := false
+	if isAutoDetect(configurable.Type) || isAutoDetect(configurable.Version) {
+		plugin, ok := detectModelPlugin(ctx, r.pluginRegistry.GetPlugins(), capabilities, configurable.Type, configurable.Version)
+		if !ok {
+			log.Warnf("Cannot detect type and version of target '%s': no compatible model plugin found for type '%s' version '%s'",
+				conn.TargetID(), configurable.Type, configurable.Version)
+			return nil
+		}
+		configurable.Type = plugin.GetInfo().Info.Name
+		configurable.Version = plugin.GetInfo().Info.Version
+		log.Infof("Detected type '%s' version '%s' for target '%s'", configurable.Type, configurable.Version, conn.TargetID())
+		if err := target.SetAspect(&configurable); err != nil {
+			log.Errorf("Failed updating type and version of target '%s'", conn.TargetID(), err)
+			return nil
+		}
+		detected = true
+	}
+
+	plugin, ok := r.pluginRegistry.GetPlugin(configapi.TargetType(configurable.Type), configapi.TargetVersion(configurable.Version))
+	if !ok || plugin.GetInfo().Unvalidated {
+		log.Warnf("Cannot verify capabilities of target '%s': no model plugin for type '%s' version '%s'",
+			conn.TargetID(), configurable.Type, configurable.Version)
+		return nil
+	}
+
+	compatibility := checkCapabilities(plugin.Capabilities(ctx), capabilities)
+	compatibility.Type = configurable.Type
+	compatibility.Version = configurable.Version
+	if !compatibility.Compatible {
+		log.Warnf("Target '%s' is not compatible with type '%s' version '%s': missing models %v, common encodings %v",
+			conn.TargetID(), configurable.Type, configurable.Version, compatibility.MissingModels, compatibility.Encodings)
+	}
+
+	// Only update the target if the result has changed to avoid conflicting writes from each connected node
+	if current, err := topo.GetModelCompatibility(target); err == nil && !detected {
+		compatibility.Checked = current.Checked
+		if reflect.DeepEqual(current, compatibility) {
+			return nil
+		}
+	}
+	compatibility.Checked = time.Now()
+	if err := topo.SetModelCompatibility(target, compatibility); err != nil {
+		log.Errorf("Failed updating capabilities of target '%s'", conn.TargetID(), err)
+		return nil
+	}
+	if err := r.topo.Update(ctx, target); err != nil {
+		log.Warnf("Failed updating capabilities of target '%s'", conn.TargetID(), err)
+		return err
+	}
+	return nil
+}
+
+// autoDetect is the value of the target type or version indicating it should be detected from the target capabilities
+const autoDetect = "auto"
+
+// isAutoDetect returns whether the given target type or version should be detected from the target capabilities
+func isAutoDetect(value string) bool {
+	return value == "" || strings.EqualFold(value, autoDetect)
+}
+
+// detectModelPlugin returns the model plugin best matching the given target capabilities. Only plugins with the
+// given type and version are considered unless they are to be detected. The best plugin is the compatible plugin
+// with the most models; ties are broken in favor of the latest version.
+func detectModelPlugin(ctx context.Context, plugins []pluginregistry.ModelPlugin, targetCapabilities *gpb.CapabilityResponse,
+	targetType string, targetVersion string) (pluginregistry.ModelPlugin, bool) {
+	var bestPlugin pluginregistry.ModelPlugin
+	var bestModels int
+	for _, plugin := range plugins {
+		info := plugin.GetInfo()
+		if info.Error != "" || len(info.Info.ModelData) == 0 {
+			continue
+		}
+		if (!isAutoDetect(targetType) && info.Info.Name != targetType) ||
+			(!isAutoDetect(targetVersion) && info.Info.Version != targetVersion) {
+			continue
+		}
+		if !checkCapabilities(plugin.Capabilities(ctx), targetCapabilities).Compatible {
+			continue
+		}
+		models := len(info.Info.ModelData)
+		if bestPlugin == nil || models > bestModels ||
+			(models == bestModels && compareVersions(info.Info.Version, bestPlugin.GetInfo().Info.Version) > 0) {
+			bestPlugin = plugin
+			bestModels = models
+		}
+	}
+	return bestPlugin, bestPlugin != nil
+}
+
+// compareVersions compares two plugin versions as semantic versions, e.g. 1.10.0 is later than 1.9.0.
+// Versions that cannot be parsed as semantic versions are ordered before those that can and compared as strings.
+func compareVersions(version1, version2 string) int {
+	semver1, err1 := semver.NewVersion(version1)
+	semver2, err2 := semver.NewVersion(version2)
+	switch {
+	case err1 == nil && err2 == nil:
+		return semver1.Compare(semver2)
+	case err1 == nil:
+		return 1
+	case err2 == nil:
+		return -1
+	default:
+		return strings.Compare(version1, version2)
+	}
+}
+
+// checkCapabilities checks whether the target supports all models of the plugin and at least one of its encodings
+func checkCapabilities(pluginCapabilities *gpb.CapabilityResponse, targetCapabilities *gpb.CapabilityResponse) *topo.ModelCompatibility {
+	compatibility := &topo.ModelCompatibility{}
+	for _, pluginModel := range pluginCapabilities.SupportedModels {
+		supported := false
+		for _, targetModel := range targetCapabilities.SupportedModels {
+			if targetModel.Name == pluginModel.Name &&
+				(targetModel.Version == "" || pluginModel.Version == "" || targetModel.Version == pluginModel.Version) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			compatibility.MissingModels = append(compatibility.MissingModels, fmt.Sprintf("%s@%s", pluginModel.Name, pluginModel.Version))
+		}
+	}
+
+	for _, pluginEncoding := range pluginCapabilities.SupportedEncodings {
+		for _, targetEncoding := range targetCapabilities.SupportedEncodings {
+			if targetEncoding == pluginEncoding {
+				compatibility.Encodings = append(compatibility.Encodings, pluginEncoding.String())
+				break
+			}
+		}
+	}
+
+	// Targets that do not advertise their encodings are assumed to support the plugin's encodings
+	encodingsSupported := len(compatibility.Encodings) > 0 ||
+		len(pluginCapabilities.SupportedEncodings) == 0 || len(targetCapabilities.SupportedEncodings) == 0
+	compatibility.Compatible = len(compatibility.MissingModels) == 0 && encodingsSupported
+	return compatibility
+}
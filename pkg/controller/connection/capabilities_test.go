@@ -0,0 +1,211 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	adminapi "github.com/onosproject/onos-api/go/onos/config/admin"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	gnmitest "github.com/onosproject/onos-config/pkg/northbound/gnmi/test"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCapabilities(encodings []gpb.Encoding, models ...*gpb.ModelData) *gpb.CapabilityResponse {
+	return &gpb.CapabilityResponse{
+		SupportedModels:    models,
+		SupportedEncodings: encodings,
+	}
+}
+
+func newTestPlugin(mctl *gomock.Controller, name string, version string, errMsg string, models ...*gpb.ModelData) pluginregistry.ModelPlugin {
+	plugin := gnmitest.NewMockModelPlugin(mctl)
+	plugin.EXPECT().GetInfo().AnyTimes().Return(&pluginregistry.ModelPluginInfo{
+		Info: adminapi.ModelInfo{
+			Name:      name,
+			Version:   version,
+			ModelData: models,
+		},
+		Error: errMsg,
+	})
+	plugin.EXPECT().Capabilities(gomock.Any()).AnyTimes().Return(newCapabilities([]gpb.Encoding{gpb.Encoding_JSON_IETF}, models...))
+	return plugin
+}
+
+var (
+	interfacesModel = &gpb.ModelData{Name: "openconfig-interfaces", Version: "2.4.3"}
+	systemModel     = &gpb.ModelData{Name: "openconfig-system", Version: "0.10.0"}
+	platformModel   = &gpb.ModelData{Name: "openconfig-platform", Version: "0.12.2"}
+)
+
+func TestCheckCapabilities(t *testing.T) {
+	encodings := []gpb.Encoding{gpb.Encoding_JSON_IETF, gpb.Encoding_PROTO}
+	plugin := newCapabilities(encodings, interfacesModel, systemModel)
+
+	// A target supporting all models and encodings is compatible
+	compatibility := checkCapabilities(plugin, newCapabilities(encodings, interfacesModel, systemModel, platformModel))
+	assert.True(t, compatibility.Compatible)
+	assert.Empty(t, compatibility.MissingModels)
+	assert.Equal(t, []string{"JSON_IETF", "PROTO"}, compatibility.Encodings)
+
+	// A target missing a model or supporting another version of it is not compatible
+	compatibility = checkCapabilities(plugin, newCapabilities(encodings, interfacesModel,
+		&gpb.ModelData{Name: "openconfig-system", Version: "0.9.0"}))
+	assert.False(t, compatibility.Compatible)
+	assert.Equal(t, []string{"openconfig-system@0.10.0"}, compatibility.MissingModels)
+
+	// Models advertised without a version match any version
+	compatibility = checkCapabilities(plugin, newCapabilities(encodings, interfacesModel,
+		&gpb.ModelData{Name: "openconfig-system"}))
+	assert.True(t, compatibility.Compatible)
+
+	// A target supporting none of the plugin's encodings is not compatible
+	compatibility = checkCapabilities(plugin, newCapabilities([]gpb.Encoding{gpb.Encoding_ASCII}, interfacesModel, systemModel))
+	assert.False(t, compatibility.Compatible)
+	assert.Empty(t, compatibility.Encodings)
+
+	// A target that does not advertise its encodings is assumed to support the plugin's encodings
+	compatibility = checkCapabilities(plugin, newCapabilities(nil, interfacesModel, systemModel))
+	assert.True(t, compatibility.Compatible)
+}
+
+func TestDetectModelPlugin(t *testing.T) {
+	mctl := gomock.NewController(t)
+	target := newCapabilities([]gpb.Encoding{gpb.Encoding_JSON_IETF}, interfacesModel, systemModel)
+
+	// The compatible plugin with the most models is detected
+	plugins := []pluginregistry.ModelPlugin{
+		newTestPlugin(mctl, "interfaces", "1.0.0", "", interfacesModel),
+		newTestPlugin(mctl, "devicesim", "1.0.0", "", interfacesModel, systemModel),
+		newTestPlugin(mctl, "platform", "1.0.0", "", interfacesModel, systemModel, platformModel),
+		newTestPlugin(mctl, "failed", "1.0.0", "failed to load", interfacesModel, systemModel),
+	}
+	plugin, ok := detectModelPlugin(context.Background(), plugins, target, "", "")
+	assert.True(t, ok)
+	assert.Equal(t, "devicesim", plugin.GetInfo().Info.Name)
+
+	// Ties are broken in favor of the latest version
+	plugins = []pluginregistry.ModelPlugin{
+		newTestPlugin(mctl, "devicesim", "1.9.0", "", interfacesModel, systemModel),
+		newTestPlugin(mctl, "devicesim", "1.10.0", "", interfacesModel, systemModel),
+		newTestPlugin(mctl, "devicesim", "1.2.0", "", interfacesModel, systemModel),
+	}
+	plugin, ok = detectModelPlugin(context.Background(), plugins, target, "", "")
+	assert.True(t, ok)
+	assert.Equal(t, "1.10.0", plugin.GetInfo().Info.Version)
+
+	// A type that is set is kept and only its version is detected
+	plugins = []pluginregistry.ModelPlugin{
+		newTestPlugin(mctl, "interfaces", "1.0.0", "", interfacesModel),
+		newTestPlugin(mctl, "interfaces", "1.1.0", "", interfacesModel),
+		newTestPlugin(mctl, "devicesim", "1.0.0", "", interfacesModel, systemModel),
+	}
+	plugin, ok = detectModelPlugin(context.Background(), plugins, target, "interfaces", "auto")
+	assert.True(t, ok)
+	assert.Equal(t, "interfaces", plugin.GetInfo().Info.Name)
+	assert.Equal(t, "1.1.0", plugin.GetInfo().Info.Version)
+
+	// A version that is set is kept and only the type is detected
+	plugin, ok = detectModelPlugin(context.Background(), plugins, target, "", "1.0.0")
+	assert.True(t, ok)
+	assert.Equal(t, "devicesim", plugin.GetInfo().Info.Name)
+	_, ok = detectModelPlugin(context.Background(), plugins, target, "platform", "")
+	assert.False(t, ok)
+
+	// No plugin is detected if none is compatible
+	plugins = []pluginregistry.ModelPlugin{
+		newTestPlugin(mctl, "platform", "1.0.0", "", platformModel),
+	}
+	_, ok = detectModelPlugin(context.Background(), plugins, target, "", "")
+	assert.False(t, ok)
+}
+
+// testConn is a southbound connection returning the given capabilities
+type testConn struct {
+	gnmi.Conn
+	capabilities *gpb.CapabilityResponse
+}
+
+func (c *testConn) TargetID() topoapi.ID {
+	return "target-1"
+}
+
+func (c *testConn) Capabilities(ctx context.Context, request *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	return c.capabilities, nil
+}
+
+func TestVerifyCapabilitiesDetectVersion(t *testing.T) {
+	mctl := gomock.NewController(t)
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+	assert.NoError(t, target.SetAspect(&topoapi.Configurable{
+		Type:    "interfaces",
+		Version: "auto",
+	}))
+	topoMock := gnmitest.NewMockStore(mctl)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(target.ID)).AnyTimes().Return(target, nil)
+	topoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	// The devicesim plugin supports more of the target's models but the target type is set
+	interfacesPlugin := newTestPlugin(mctl, "interfaces", "1.1.0", "", interfacesModel)
+	plugins := []pluginregistry.ModelPlugin{
+		newTestPlugin(mctl, "interfaces", "1.0.0", "", interfacesModel),
+		interfacesPlugin,
+		newTestPlugin(mctl, "devicesim", "1.0.0", "", interfacesModel, systemModel),
+	}
+	registryMock := gnmitest.NewMockPluginRegistry(mctl)
+	registryMock.EXPECT().GetPlugins().AnyTimes().Return(plugins)
+	registryMock.EXPECT().GetPlugin(gomock.Any(), gomock.Any()).AnyTimes().Return(interfacesPlugin, true)
+
+	r := &Reconciler{
+		topo:           topoMock,
+		pluginRegistry: registryMock,
+	}
+	conn := &testConn{
+		capabilities: newCapabilities([]gpb.Encoding{gpb.Encoding_JSON_IETF}, interfacesModel, systemModel),
+	}
+	assert.NoError(t, r.verifyCapabilities(context.Background(), conn))
+
+	// Only the version is detected
+	configurable := topoapi.Configurable{}
+	assert.NoError(t, target.GetAspect(&configurable))
+	assert.Equal(t, "interfaces", configurable.Type)
+	assert.Equal(t, "1.1.0", configurable.Version)
+	compatibility, err := topo.GetModelCompatibility(target)
+	assert.NoError(t, err)
+	assert.True(t, compatibility.Compatible)
+	assert.Equal(t, "interfaces", compatibility.Type)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 1, compareVersions("1.10.0", "1.9.0"))
+	assert.Equal(t, -1, compareVersions("1.0.0", "2.0.0"))
+	assert.Equal(t, 0, compareVersions("1.0", "1.0.0"))
+	assert.Equal(t, 1, compareVersions("1.0.0", "1.0.0-rc1"))
+	assert.Equal(t, 1, compareVersions("1.0.0", "latest"))
+	assert.Equal(t, -1, compareVersions("latest", "1.0.0"))
+	assert.Equal(t, 1, compareVersions("b", "a"))
+}
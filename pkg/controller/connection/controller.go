@@ -15,10 +15,16 @@
 package connection
 
 import (
+	"bytes"
 	"context"
-	"github.com/onosproject/onos-config/pkg/controller/utils"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/onosproject/onos-config/pkg/controller/utils"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
@@ -31,11 +37,14 @@ import (
 var log = logging.GetLogger("controller", "connection")
 
 const (
-	defaultTimeout = 30 * time.Second
+	// certificateStatusInterval is the interval at which the certificate status of connections is refreshed
+	certificateStatusInterval = time.Minute
+	// certificateExpiryWarning is how long before a certificate expires a warning is logged
+	certificateExpiryWarning = 30 * 24 * time.Hour
 )
 
 // NewController returns a new gNMI connection  controller
-func NewController(topo topo.Store, conns gnmi.ConnManager) *controller.Controller {
+func NewController(topo topo.Store, conns gnmi.ConnManager, pluginRegistry pluginregistry.PluginRegistry) *controller.Controller {
 	c := controller.NewController("connection")
 	c.Watch(&ConnWatcher{
 		conns: conns,
@@ -44,21 +53,30 @@ func NewController(topo topo.Store, conns gnmi.ConnManager) *controller.Controll
 		topo: topo,
 	})
 	c.Reconcile(&Reconciler{
-		conns: conns,
-		topo:  topo,
+		conns:          conns,
+		topo:           topo,
+		pluginRegistry: pluginRegistry,
+		verified:       make(map[gnmi.ConnID]bool),
+		expiryWarnings: make(map[gnmi.ConnID]map[string]bool),
 	})
 	return c
 }
 
 // Reconciler reconciles gNMI connections
 type Reconciler struct {
-	conns gnmi.ConnManager
-	topo  topo.Store
+	conns          gnmi.ConnManager
+	topo           topo.Store
+	pluginRegistry pluginregistry.PluginRegistry
+	verified       map[gnmi.ConnID]bool
+	verifiedMu     sync.Mutex
+	// expiryWarnings are the certificates of each connection for which an expiry warning has been logged
+	expiryWarnings   map[gnmi.ConnID]map[string]bool
+	expiryWarningsMu sync.Mutex
 }
 
 // Reconcile reconciles a connection for a gnmi target
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), utils.GetTimeout())
 	defer cancel()
 
 	connID := id.Value.(gnmi.ConnID)
@@ -99,10 +117,87 @@ func (r *Reconciler) createRelation(ctx context.Context, conn gnmi.Conn) (contro
 			return controller.Result{}, nil
 		}
 	}
-	return controller.Result{}, nil
+
+	// Verify the target's capabilities once for each new connection
+	r.verifiedMu.Lock()
+	verified := r.verified[conn.ID()]
+	r.verifiedMu.Unlock()
+	if !verified {
+		if err := r.verifyCapabilities(ctx, conn); err != nil {
+			return controller.Result{}, err
+		}
+		r.verifiedMu.Lock()
+		r.verified[conn.ID()] = true
+		r.verifiedMu.Unlock()
+	}
+	return r.updateCertificateStatus(ctx, conn)
+}
+
+// updateCertificateStatus records the status of the connection's TLS certificates on its CONTROLS relation
+// and requeues the connection to pick up rotated certificates
+func (r *Reconciler) updateCertificateStatus(ctx context.Context, conn gnmi.Conn) (controller.Result, error) {
+	status := conn.CertificateStatus()
+	if status == nil {
+		return controller.Result{}, nil
+	}
+
+	for name, cert := range map[string]*topo.CertificateInfo{"client": status.Client, "CA": status.CA, "server": status.Server} {
+		if cert != nil && time.Until(cert.NotAfter) < certificateExpiryWarning && r.warnExpiry(conn.ID(), *cert) {
+			log.Warnf("The %s certificate '%s' for Conn '%s' expires at %s", name, cert.Subject, conn.ID(), cert.NotAfter)
+		}
+	}
+
+	relation, err := r.topo.Get(ctx, topoapi.ID(conn.ID()))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed reconciling Conn '%s'", conn.ID(), err)
+			return controller.Result{}, err
+		}
+		return controller.Result{}, nil
+	}
+	// Compare the encoded status since decoded times do not carry the location and monotonic clock
+	current, err := topo.GetCertificateStatus(relation)
+	if err != nil || !equalJSON(current, status) {
+		if err := topo.SetCertificateStatus(relation, status); err != nil {
+			log.Errorf("Failed updating certificate status for Conn '%s'", conn.ID(), err)
+			return controller.Result{}, nil
+		}
+		if err := r.topo.Update(ctx, relation); err != nil {
+			if !errors.IsNotFound(err) && !errors.IsConflict(err) {
+				log.Errorf("Failed updating certificate status for Conn '%s'", conn.ID(), err)
+			}
+			return controller.Result{}, err
+		}
+	}
+	return controller.Result{RequeueAfter: certificateStatusInterval}, nil
+}
+
+// warnExpiry returns whether an expiry warning should be logged for the given certificate of the given connection.
+// The warning is only logged once for each certificate, so a rotated certificate is warned about again.
+func (r *Reconciler) warnExpiry(connID gnmi.ConnID, cert topo.CertificateInfo) bool {
+	r.expiryWarningsMu.Lock()
+	defer r.expiryWarningsMu.Unlock()
+	warnings, ok := r.expiryWarnings[connID]
+	if !ok {
+		warnings = make(map[string]bool)
+		r.expiryWarnings[connID] = warnings
+	}
+	key := fmt.Sprintf("%s@%s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	if warnings[key] {
+		return false
+	}
+	warnings[key] = true
+	return true
 }
 
 func (r *Reconciler) deleteRelation(ctx context.Context, connID gnmi.ConnID) (controller.Result, error) {
+	r.verifiedMu.Lock()
+	delete(r.verified, connID)
+	r.verifiedMu.Unlock()
+	r.expiryWarningsMu.Lock()
+	delete(r.expiryWarnings, connID)
+	r.expiryWarningsMu.Unlock()
+
 	relation, err := r.topo.Get(ctx, topoapi.ID(connID))
 	if err != nil {
 		if !errors.IsNotFound(err) {
@@ -123,3 +218,15 @@ func (r *Reconciler) deleteRelation(ctx context.Context, connID gnmi.ConnID) (co
 	}
 	return controller.Result{}, nil
 }
+
+func equalJSON(a, b interface{}) bool {
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
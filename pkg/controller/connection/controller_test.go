@@ -0,0 +1,47 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnExpiry(t *testing.T) {
+	r := &Reconciler{
+		expiryWarnings: make(map[gnmi.ConnID]map[string]bool),
+	}
+	cert := topo.CertificateInfo{
+		Subject:  "CN=target-1",
+		NotAfter: time.Now().Add(time.Hour),
+	}
+
+	// The warning is only logged once for each certificate
+	assert.True(t, r.warnExpiry("conn-1", cert))
+	assert.False(t, r.warnExpiry("conn-1", cert))
+	assert.True(t, r.warnExpiry("conn-2", cert))
+
+	// A rotated certificate is warned about again
+	rotated := topo.CertificateInfo{
+		Subject:  "CN=target-1",
+		NotAfter: cert.NotAfter.Add(time.Hour),
+	}
+	assert.True(t, r.warnExpiry("conn-1", rotated))
+	assert.False(t, r.warnExpiry("conn-1", rotated))
+}
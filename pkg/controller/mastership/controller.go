@@ -17,19 +17,17 @@ package mastership
 import (
 	"context"
 	"math/rand"
-	"time"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
 	"github.com/onosproject/onos-config/pkg/store/topo"
 	"github.com/onosproject/onos-lib-go/pkg/controller"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 )
 
-const defaultTimeout = 30 * time.Second
-
 var log = logging.GetLogger("controller", "mastership")
 
 // NewController returns a new mastership controller
@@ -52,7 +50,7 @@ type Reconciler struct {
 
 // Reconcile reconciles the mastership state for a gnmi target
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
 	defer cancel()
 
 	targetID := id.Value.(topoapi.ID)
@@ -31,23 +31,22 @@ import (
 )
 
 const (
-	defaultTimeout            = 30 * time.Second
-	defaultExpirationDuration = 30 * time.Second
-	defaultGRPCPort           = 5150
-	defaultGNMIPort           = 9339
+	defaultGRPCPort = 5150
+	defaultGNMIPort = 9339
 )
 
 var log = logging.GetLogger("controller", "node")
 
-// NewController returns a new node controller
-func NewController(topo topo.Store) *controller.Controller {
+// NewController returns a new node controller maintaining a lease of the given duration
+func NewController(topo topo.Store, leaseDuration time.Duration) *controller.Controller {
 	c := controller.NewController("node")
 	c.Watch(&TopoWatcher{
 		topo: topo,
 	})
 
 	c.Reconcile(&Reconciler{
-		topo: topo,
+		topo:          topo,
+		leaseDuration: leaseDuration,
 	})
 
 	return c
@@ -55,7 +54,8 @@ func NewController(topo topo.Store) *controller.Controller {
 
 // Reconciler is a onos-config node reconciler
 type Reconciler struct {
-	topo topo.Store
+	topo          topo.Store
+	leaseDuration time.Duration
 }
 
 func (r *Reconciler) createOnosConfigEntity(ctx context.Context, onosConfigID topoapi.ID) error {
@@ -72,7 +72,7 @@ func (r *Reconciler) createOnosConfigEntity(ctx context.Context, onosConfigID to
 		Labels:  map[string]string{},
 	}
 
-	expiration := time.Now().Add(defaultExpirationDuration)
+	expiration := time.Now().Add(r.leaseDuration)
 	leaseAspect := &topoapi.Lease{
 		Expiration: &expiration,
 	}
@@ -94,7 +94,7 @@ func (r *Reconciler) createOnosConfigEntity(ctx context.Context, onosConfigID to
 
 // Reconcile reconciles the onos-config entities
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), utils.GetTimeout())
 	defer cancel()
 
 	onosConfigID := id.Value.(topoapi.ID)
@@ -135,16 +135,16 @@ func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
 			remainingTime := time.Until(*lease.GetExpiration())
 			// If the remaining time of lease is more than  half the lease duration, no need to renew the lease
 			// schedule the next renewal
-			if remainingTime > defaultExpirationDuration/2 {
+			if remainingTime > r.leaseDuration/2 {
 				log.Debugf("No need to renew the lease for %s, the remaining lease time is %v seconds", onosConfigID, remainingTime)
 				return controller.Result{
-					RequeueAfter: time.Until(lease.Expiration.Add(defaultExpirationDuration / 2 * -1)),
+					RequeueAfter: time.Until(lease.Expiration.Add(r.leaseDuration / 2 * -1)),
 				}, nil
 			}
 
 			// Renew the release to trigger the reconciler
 			log.Debugf("Renew the lease for onos-config with ID: %s", onosConfigID)
-			expiration := time.Now().Add(defaultExpirationDuration)
+			expiration := time.Now().Add(r.leaseDuration)
 			lease = &topoapi.Lease{
 				Expiration: &expiration,
 			}
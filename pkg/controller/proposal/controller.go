@@ -17,6 +17,7 @@ package proposal
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,9 +27,9 @@ import (
 	pathutils "github.com/onosproject/onos-config/pkg/utils/path"
 	"github.com/onosproject/onos-config/pkg/utils/tree"
 	utilsv2 "github.com/onosproject/onos-config/pkg/utils/values/v2"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi_ext"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"github.com/onosproject/onos-config/pkg/pluginregistry"
 
@@ -44,10 +45,6 @@ import (
 
 var log = logging.GetLogger("controller", "proposal")
 
-const (
-	defaultTimeout = 30 * time.Second
-)
-
 // NewController returns a proposal controller
 func NewController(topo topo.Store, conns gnmi.ConnManager, proposals proposalstore.Store, configurations configuration.Store, pluginRegistry pluginregistry.PluginRegistry) *controller.Controller {
 	c := controller.NewController("proposal")
@@ -89,7 +86,7 @@ type Reconciler struct {
 
 // Reconcile reconciles target proposals
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
 	defer cancel()
 
 	proposalID := id.Value.(configapi.ProposalID)
@@ -624,154 +621,293 @@ func (r *Reconciler) reconcileApply(ctx context.Context, proposal *configapi.Pro
 			return controller.Result{}, nil
 		}
 
-		// Get the set of changes. If the Proposal is a change, use the change values.
-		// If the proposal is a rollback, use the rollback values.
-		var changeValues map[string]*configapi.PathValue
-		switch details := proposal.Details.(type) {
-		case *configapi.Proposal_Change:
-			changeValues = details.Change.Values
-		case *configapi.Proposal_Rollback:
-			changeValues = proposal.Status.RollbackValues
+		// If the target is known not to support the models for its type and version, fail without sending changes.
+		configurable := topoapi.Configurable{}
+		_ = target.GetAspect(&configurable)
+		if compatibility, err := topo.GetModelCompatibility(target); err == nil &&
+			compatibility.Type == configurable.Type && compatibility.Version == configurable.Version && !compatibility.Compatible {
+			err := errors.NewNotSupported("target '%s' does not support type '%s' version '%s': missing models %v, common encodings %v",
+				proposal.TargetID, configurable.Type, configurable.Version, compatibility.MissingModels, compatibility.Encodings)
+			log.Warnf("Failed applying Proposal '%s'", proposal.ID, err)
+			return r.failApply(ctx, config, []*configapi.Proposal{proposal}, mastership, &configapi.Failure{
+				Type:        configapi.Failure_NOT_SUPPORTED,
+				Description: err.Error(),
+			})
 		}
 
-		// Create a list of PathValue pairs from which to construct a gNMI Set for the Proposal.
-		pathValues := make([]*configapi.PathValue, 0, len(changeValues))
-		for _, changeValue := range changeValues {
-			pathValues = append(pathValues, changeValue)
+		// Get the target synchronization options
+		syncOptions, err := topo.GetSyncOptions(target)
+		if err != nil {
+			log.Warnf("Failed reading synchronization options for target '%s'", proposal.TargetID, err)
+			syncOptions = &topo.SyncOptions{}
+		}
+
+		// By default, only this Proposal is applied. If the target is configured to coalesce changes,
+		// all subsequent Proposals awaiting application are merged into the same Set request.
+		proposals := []*configapi.Proposal{proposal}
+		if syncOptions.Coalesce {
+			pendingProposals, err := r.getPendingProposals(ctx, proposal)
+			if err != nil {
+				log.Errorf("Failed reconciling Transaction %d Proposal to target '%s'", proposal.TransactionIndex, proposal.TargetID, err)
+				return controller.Result{}, err
+			}
+			proposals = append(proposals, pendingProposals...)
 		}
-		pathValues = tree.PrunePathValues(pathValues, true)
+		return r.applyProposals(ctx, config, proposals, target, conn, mastership)
+	case configapi.ProposalApplyPhase_APPLIED:
+		if proposal.Status.NextIndex != 0 {
+			return controller.Result{
+				Requeue: controller.NewID(proposalstore.NewID(proposal.TargetID, proposal.Status.NextIndex)),
+			}, nil
+		}
+		return controller.Result{}, nil
+	default:
+		return controller.Result{}, nil
+	}
+}
+
+// failApply marks the apply phase of the given chain of Proposals FAILED with the given failure
+func (r *Reconciler) failApply(ctx context.Context, config *configapi.Configuration, proposals []*configapi.Proposal, mastership topoapi.MastershipState, failure *configapi.Failure) (controller.Result, error) {
+	proposal := proposals[0]
+	lastProposal := proposals[len(proposals)-1]
+	mastershipTerm := configapi.MastershipTerm(mastership.Term)
+
+	// Update the Configuration's applied index to indicate the Proposals were applied even though they failed.
+	log.Infof("Updating applied index for Configuration '%s' to %d in term %d", config.ID, lastProposal.TransactionIndex, mastershipTerm)
+	config.Status.Applied.Index = lastProposal.TransactionIndex
+	config.Status.Applied.Mastership.Master = mastership.NodeId
+	config.Status.Applied.Mastership.Term = mastershipTerm
+	if err := r.configurations.UpdateStatus(ctx, config); err != nil {
+		log.Errorf("Failed reconciling Transaction %d Proposal to target '%s'", proposal.TransactionIndex, proposal.TargetID, err)
+		return controller.Result{}, err
+	}
 
-		log.Infof("Updating %d paths on target '%s'", len(pathValues), config.TargetID)
+	// Add the failure to the proposals' apply phase state.
+	for _, failedProposal := range proposals {
+		failedProposal.Status.Phases.Apply.State = configapi.ProposalApplyPhase_FAILED
+		failedProposal.Status.Phases.Apply.Failure = failure
+		failedProposal.Status.Phases.Apply.Term = mastershipTerm
+		failedProposal.Status.Phases.Apply.End = getCurrentTimestamp()
+		if err := r.updateProposalStatus(ctx, failedProposal); err != nil {
+			return controller.Result{}, err
+		}
+	}
+	return controller.Result{}, nil
+}
 
-		// Create a gNMI set request
-		setRequest, err := utilsv2.PathValuesToGnmiChange(pathValues)
+// getPendingProposals returns the chain of Proposals following the given Proposal that are
+// committed and awaiting application to the target, in transaction order
+func (r *Reconciler) getPendingProposals(ctx context.Context, proposal *configapi.Proposal) ([]*configapi.Proposal, error) {
+	var pendingProposals []*configapi.Proposal
+	nextIndex := proposal.Status.NextIndex
+	for nextIndex != 0 {
+		nextProposal, err := r.proposals.Get(ctx, proposalstore.NewID(proposal.TargetID, nextIndex))
 		if err != nil {
-			log.Errorf("Failed constructing SetRequest for Configuration '%s'", config.ID, err)
-			return controller.Result{}, nil
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			return pendingProposals, nil
+		}
+		if nextProposal.Status.Phases.Apply == nil || nextProposal.Status.Phases.Apply.State != configapi.ProposalApplyPhase_APPLYING {
+			return pendingProposals, nil
 		}
+		pendingProposals = append(pendingProposals, nextProposal)
+		nextIndex = nextProposal.Status.NextIndex
+	}
+	return pendingProposals, nil
+}
 
-		// Add the master arbitration extension to provide concurrency control for multi-node controllers.
-		setRequest.Extension = append(setRequest.Extension, &gnmi_ext.Extension{
-			Ext: &gnmi_ext.Extension_MasterArbitration{
-				MasterArbitration: &gnmi_ext.MasterArbitration{
-					Role: &gnmi_ext.Role{
-						Id: "onos-config",
-					},
-					ElectionId: &gnmi_ext.Uint128{
-						Low: uint64(mastershipTerm),
-					},
+// getChangeValues returns the set of changes to apply for the given Proposal. If the Proposal is a change,
+// the change values are returned. If the Proposal is a rollback, the rollback values are returned.
+func getChangeValues(proposal *configapi.Proposal) map[string]*configapi.PathValue {
+	switch details := proposal.Details.(type) {
+	case *configapi.Proposal_Change:
+		return details.Change.Values
+	case *configapi.Proposal_Rollback:
+		return proposal.Status.RollbackValues
+	}
+	return nil
+}
+
+// getSetPathValues merges the changes of the given Proposals in order and returns the list of PathValue
+// pairs from which to construct a single gNMI Set for all the Proposals.
+func getSetPathValues(proposals []*configapi.Proposal) []*configapi.PathValue {
+	changeValues := make(map[string]*configapi.PathValue)
+	for path, changeValue := range getChangeValues(proposals[0]) {
+		changeValues[path] = changeValue
+	}
+
+	// Parent paths deleted by an earlier Proposal and then overwritten by a later one are dropped from the
+	// merged changes. Keep track of them so the deletes can still be sent to the target ahead of the updates.
+	deletedValues := make(map[string]*configapi.PathValue)
+	for _, proposal := range proposals[1:] {
+		for path, changeValue := range getChangeValues(proposal) {
+			deletedParentPath, deletedParentValue := applyChangeToConfig(changeValues, path, changeValue)
+			if deletedParentValue != nil {
+				deletedValues[deletedParentPath] = deletedParentValue
+			}
+		}
+	}
+
+	pathValues := make([]*configapi.PathValue, 0, len(changeValues)+len(deletedValues))
+	for _, changeValue := range changeValues {
+		pathValues = append(pathValues, changeValue)
+	}
+	pathValues = tree.PrunePathValues(pathValues, true)
+	for path, deletedValue := range deletedValues {
+		if _, ok := changeValues[path]; !ok {
+			pathValues = append(pathValues, deletedValue)
+		}
+	}
+	return pathValues
+}
+
+// applyProposals applies the given chain of Proposals to the target in a single gNMI Set request.
+// If the target limits the size of Set requests, the request is split into multiple chunks.
+func (r *Reconciler) applyProposals(ctx context.Context, config *configapi.Configuration, proposals []*configapi.Proposal, target *topoapi.Object, conn gnmi.Conn, mastership topoapi.MastershipState) (controller.Result, error) {
+	proposal := proposals[0]
+	lastProposal := proposals[len(proposals)-1]
+	mastershipTerm := configapi.MastershipTerm(mastership.Term)
+
+	// Create a list of PathValue pairs from which to construct a gNMI Set for the Proposals.
+	pathValues := getSetPathValues(proposals)
+	if len(proposals) > 1 {
+		log.Infof("Coalescing Transactions %d through %d Proposals to target '%s'", proposal.TransactionIndex, lastProposal.TransactionIndex, proposal.TargetID)
+	}
+	log.Infof("Updating %d paths on target '%s'", len(pathValues), config.TargetID)
+
+	// Sort the paths to ensure the request is split into the same chunks if it's retried
+	sort.Slice(pathValues, func(i, j int) bool {
+		return pathValues[i].Path < pathValues[j].Path
+	})
+
+	// Determine the encoding with which to send changes to the target
+	encoding, err := topo.GetEncoding(target, gpb.Encoding_PROTO)
+	if err != nil {
+		log.Warnf("Failed reading encoding for target '%s'; using %s", config.TargetID, encoding, err)
+	}
+
+	// Create a gNMI set request
+	setRequest, err := utilsv2.PathValuesToGnmiChangeWithEncoding(pathValues, encoding)
+	if err != nil {
+		log.Errorf("Failed constructing SetRequest for Configuration '%s'", config.ID, err)
+		return controller.Result{}, nil
+	}
+
+	// Add the master arbitration extension to provide concurrency control for multi-node controllers.
+	setRequest.Extension = append(setRequest.Extension, &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_MasterArbitration{
+			MasterArbitration: &gnmi_ext.MasterArbitration{
+				Role: &gnmi_ext.Role{
+					Id: "onos-config",
+				},
+				ElectionId: &gnmi_ext.Uint128{
+					Low: uint64(mastershipTerm),
 				},
 			},
-		})
+		},
+	})
 
-		// Execute the set request
-		log.Debugf("Sending SetRequest %+v", setRequest)
-		setResponse, err := conn.Set(ctx, setRequest)
-		if err != nil {
-			code := status.Code(err)
+	// Execute the set request
+	chunk, chunks, err := controllerutils.SetChunked(ctx, r.topo, target, topo.SetOperationApply,
+		uint64(lastProposal.TransactionIndex), uint64(mastershipTerm), setRequest, conn.Set, conn.Get)
+	if err != nil {
+		code := errors.Status(err).Code()
+		switch code {
+		case codes.Unavailable, codes.Canceled, codes.DeadlineExceeded:
+			log.Errorf("Failed sending SetRequest %+v", setRequest, err)
+			return controller.Result{}, err
+		case codes.PermissionDenied:
+			// The gNMI Set request can be denied if this master has been superseded by a master in a later term.
+			// Rather than reverting to the STALE state now, wait for this node to see the mastership state change
+			// to avoid flapping between states while the system converges.
+			log.Warnf("Configuration '%s' mastership superseded for term %d", config.ID, mastershipTerm)
+			return controller.Result{}, nil
+		default:
+			var failureType configapi.Failure_Type
 			switch code {
-			case codes.Unavailable, codes.Canceled, codes.DeadlineExceeded:
-				log.Errorf("Failed sending SetRequest %+v", setRequest, err)
-				return controller.Result{}, err
+			case codes.Unknown:
+				failureType = configapi.Failure_UNKNOWN
+			case codes.Canceled:
+				failureType = configapi.Failure_CANCELED
+			case codes.NotFound:
+				failureType = configapi.Failure_NOT_FOUND
+			case codes.AlreadyExists:
+				failureType = configapi.Failure_ALREADY_EXISTS
+			case codes.Unauthenticated:
+				failureType = configapi.Failure_UNAUTHORIZED
 			case codes.PermissionDenied:
-				// The gNMI Set request can be denied if this master has been superseded by a master in a later term.
-				// Rather than reverting to the STALE state now, wait for this node to see the mastership state change
-				// to avoid flapping between states while the system converges.
-				log.Warnf("Configuration '%s' mastership superseded for term %d", config.ID, mastershipTerm)
-				return controller.Result{}, nil
-			default:
-				var failureType configapi.Failure_Type
-				switch code {
-				case codes.Unknown:
-					failureType = configapi.Failure_UNKNOWN
-				case codes.Canceled:
-					failureType = configapi.Failure_CANCELED
-				case codes.NotFound:
-					failureType = configapi.Failure_NOT_FOUND
-				case codes.AlreadyExists:
-					failureType = configapi.Failure_ALREADY_EXISTS
-				case codes.Unauthenticated:
-					failureType = configapi.Failure_UNAUTHORIZED
-				case codes.PermissionDenied:
-					failureType = configapi.Failure_FORBIDDEN
-				case codes.FailedPrecondition:
-					failureType = configapi.Failure_CONFLICT
-				case codes.InvalidArgument:
-					failureType = configapi.Failure_INVALID
-				case codes.Unavailable:
-					failureType = configapi.Failure_UNAVAILABLE
-				case codes.Unimplemented:
-					failureType = configapi.Failure_NOT_SUPPORTED
-				case codes.DeadlineExceeded:
-					failureType = configapi.Failure_TIMEOUT
-				case codes.Internal:
-					failureType = configapi.Failure_INTERNAL
-				}
-
-				// Update the Configuration's applied index to indicate this Proposal was applied even though it failed.
-				log.Infof("Updating applied index for Configuration '%s' to %d in term %d", config.ID, proposal.TransactionIndex, mastershipTerm)
-				config.Status.Applied.Index = proposal.TransactionIndex
-				config.Status.Applied.Mastership.Master = mastership.NodeId
-				config.Status.Applied.Mastership.Term = mastershipTerm
-				if err := r.configurations.UpdateStatus(ctx, config); err != nil {
-					log.Errorf("Failed reconciling Transaction %d Proposal to target '%s'", proposal.TransactionIndex, proposal.TargetID, err)
-					return controller.Result{}, err
-				}
-
-				// Add the failure to the proposal's apply phase state.
+				failureType = configapi.Failure_FORBIDDEN
+			case codes.FailedPrecondition:
+				failureType = configapi.Failure_CONFLICT
+			case codes.InvalidArgument:
+				failureType = configapi.Failure_INVALID
+			case codes.Unavailable:
+				failureType = configapi.Failure_UNAVAILABLE
+			case codes.Unimplemented:
+				failureType = configapi.Failure_NOT_SUPPORTED
+			case codes.DeadlineExceeded:
+				failureType = configapi.Failure_TIMEOUT
+			case codes.Internal:
+				failureType = configapi.Failure_INTERNAL
+			}
+
+			// If the Set request merged multiple Proposals, the failure cannot be attributed to any single Proposal.
+			// If no changes have been applied to the target yet, fall back to applying this Proposal alone so
+			// failures are recorded on the Proposal that caused them. Once chunks of the merged changes have been
+			// applied, the Proposals can no longer be applied separately, so the failure is recorded on all of them.
+			if len(proposals) > 1 && chunk <= 1 {
+				log.Warnf("Failed applying coalesced Transactions %d through %d Proposals to target '%s'", proposal.TransactionIndex, lastProposal.TransactionIndex, proposal.TargetID, err)
+				return r.applyProposals(ctx, config, proposals[:1], target, conn, mastership)
+			}
+
+			// If the request was split into chunks, report which chunk failed.
+			description := err.Error()
+			if chunks > 1 {
+				description = fmt.Sprintf("chunk %d of %d failed: %s", chunk, chunks, description)
+			}
+			if len(proposals) > 1 {
+				log.Warnf("Failed applying coalesced Transactions %d through %d Proposals to target '%s'", proposal.TransactionIndex, lastProposal.TransactionIndex, proposal.TargetID, err)
+			} else {
 				log.Warnf("Failed applying Proposal '%s'", proposal.ID, err)
-				proposal.Status.Phases.Apply.State = configapi.ProposalApplyPhase_FAILED
-				proposal.Status.Phases.Apply.Failure = &configapi.Failure{
-					Type:        failureType,
-					Description: err.Error(),
-				}
-				proposal.Status.Phases.Apply.Term = mastershipTerm
-				proposal.Status.Phases.Apply.End = getCurrentTimestamp()
-				if err := r.updateProposalStatus(ctx, proposal); err != nil {
-					return controller.Result{}, err
-				}
-				return controller.Result{}, nil
 			}
+			return r.failApply(ctx, config, proposals, mastership, &configapi.Failure{
+				Type:        failureType,
+				Description: description,
+			})
 		}
-		log.Debugf("Received SetResponse %+v", setResponse)
+	}
 
-		// Update the Configuration's applied index to indicate this Proposal was applied.
-		log.Infof("Updating applied index for Configuration '%s' to %d in term %d", config.ID, proposal.TransactionIndex, mastershipTerm)
-		config.Status.Applied.Index = proposal.TransactionIndex
-		config.Status.Applied.Mastership.Master = mastership.NodeId
-		config.Status.Applied.Mastership.Term = mastershipTerm
-		if config.Status.Applied.Values == nil {
-			config.Status.Applied.Values = make(map[string]*configapi.PathValue)
-		}
-		for path, changeValue := range changeValues {
+	// Update the Configuration's applied index to indicate the Proposals were applied.
+	// Subsequent Proposals covered by the Set request will be marked APPLIED once reconciled.
+	log.Infof("Updating applied index for Configuration '%s' to %d in term %d", config.ID, lastProposal.TransactionIndex, mastershipTerm)
+	config.Status.Applied.Index = lastProposal.TransactionIndex
+	config.Status.Applied.Mastership.Master = mastership.NodeId
+	config.Status.Applied.Mastership.Term = mastershipTerm
+	if config.Status.Applied.Values == nil {
+		config.Status.Applied.Values = make(map[string]*configapi.PathValue)
+	}
+	for _, appliedProposal := range proposals {
+		for path, changeValue := range getChangeValues(appliedProposal) {
 			config.Status.Applied.Values[path] = changeValue
 		}
-		config.Status.Applied.Values = tree.PrunePathMap(config.Status.Applied.Values, true)
+	}
+	config.Status.Applied.Values = tree.PrunePathMap(config.Status.Applied.Values, true)
 
-		if err := r.configurations.UpdateStatus(ctx, config); err != nil {
-			log.Errorf("Failed reconciling Transaction %d Proposal to target '%s'", proposal.TransactionIndex, proposal.TargetID, err)
-			return controller.Result{}, err
-		}
+	if err := r.configurations.UpdateStatus(ctx, config); err != nil {
+		log.Errorf("Failed reconciling Transaction %d Proposal to target '%s'", proposal.TransactionIndex, proposal.TargetID, err)
+		return controller.Result{}, err
+	}
 
-		// Update the proposal state to APPLIED.
-		log.Infof("Applied Proposal '%s'", proposal.ID)
-		proposal.Status.Phases.Apply.State = configapi.ProposalApplyPhase_APPLIED
-		proposal.Status.Phases.Apply.Term = mastershipTerm
-		proposal.Status.Phases.Apply.End = getCurrentTimestamp()
-		if err := r.updateProposalStatus(ctx, proposal); err != nil {
-			return controller.Result{}, err
-		}
-		return controller.Result{}, nil
-	case configapi.ProposalApplyPhase_APPLIED:
-		if proposal.Status.NextIndex != 0 {
-			return controller.Result{
-				Requeue: controller.NewID(proposalstore.NewID(proposal.TargetID, proposal.Status.NextIndex)),
-			}, nil
-		}
-		return controller.Result{}, nil
-	default:
-		return controller.Result{}, nil
+	// Update the proposal state to APPLIED.
+	log.Infof("Applied Proposal '%s'", proposal.ID)
+	proposal.Status.Phases.Apply.State = configapi.ProposalApplyPhase_APPLIED
+	proposal.Status.Phases.Apply.Term = mastershipTerm
+	proposal.Status.Phases.Apply.End = getCurrentTimestamp()
+	if err := r.updateProposalStatus(ctx, proposal); err != nil {
+		return controller.Result{}, err
 	}
+	return controller.Result{}, nil
 }
 
 func (r *Reconciler) updateProposalStatus(ctx context.Context, proposal *configapi.Proposal) error {
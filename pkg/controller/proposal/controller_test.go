@@ -0,0 +1,302 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	proposalstore "github.com/onosproject/onos-config/pkg/store/proposal"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTarget = "target-1"
+
+// testConn is a southbound connection that records the Set requests sent to the target
+type testConn struct {
+	gnmi.Conn
+	requests []*gpb.SetRequest
+	set      func(request *gpb.SetRequest) error
+}
+
+func (c *testConn) Set(ctx context.Context, request *gpb.SetRequest) (*gpb.SetResponse, error) {
+	c.requests = append(c.requests, request)
+	if c.set != nil {
+		if err := c.set(request); err != nil {
+			return nil, err
+		}
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+func newTestReconciler(t *testing.T, syncOptions string) (*Reconciler, *topoapi.Object) {
+	path := filepath.Join(t.TempDir(), "topo.yaml")
+	contents := `
+targets:
+  - id: ` + testTarget + `
+    aspects:
+      onos.topo.Configurable:
+        address: target-1:11161
+        type: devicesim
+        version: 1.0.0
+      onos.config.SyncOptions: ` + syncOptions + `
+`
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	topoStore, err := topo.NewFileStore(path)
+	assert.NoError(t, err)
+	target, err := topoStore.Get(context.Background(), testTarget)
+	assert.NoError(t, err)
+
+	return &Reconciler{
+		topo:           topoStore,
+		proposals:      proposalstore.NewMemoryStore(),
+		configurations: configuration.NewMemoryStore(),
+	}, target
+}
+
+func newTestConfiguration(t *testing.T, r *Reconciler) *configapi.Configuration {
+	config := &configapi.Configuration{
+		ID:       configuration.NewID(testTarget),
+		TargetID: testTarget,
+		Status: configapi.ConfigurationStatus{
+			State: configapi.ConfigurationStatus_SYNCHRONIZED,
+		},
+	}
+	assert.NoError(t, r.configurations.Create(context.Background(), config))
+	config, err := r.configurations.Get(context.Background(), config.ID)
+	assert.NoError(t, err)
+	return config
+}
+
+func newTestProposal(t *testing.T, r *Reconciler, index configapi.Index, values ...*configapi.PathValue) *configapi.Proposal {
+	changeValues := make(map[string]*configapi.PathValue)
+	for _, value := range values {
+		changeValues[value.Path] = value
+	}
+	proposal := &configapi.Proposal{
+		ID:               proposalstore.NewID(testTarget, index),
+		TargetID:         testTarget,
+		TransactionIndex: index,
+		Details: &configapi.Proposal_Change{
+			Change: &configapi.ChangeProposal{
+				Values: changeValues,
+			},
+		},
+		Status: configapi.ProposalStatus{
+			Phases: configapi.ProposalPhases{
+				Apply: &configapi.ProposalApplyPhase{
+					State: configapi.ProposalApplyPhase_APPLYING,
+				},
+			},
+		},
+	}
+	assert.NoError(t, r.proposals.Create(context.Background(), proposal))
+	proposal, err := r.proposals.Get(context.Background(), proposal.ID)
+	assert.NoError(t, err)
+	return proposal
+}
+
+func newValue(path string, value string) *configapi.PathValue {
+	return &configapi.PathValue{
+		Path:  path,
+		Value: *configapi.NewTypedValueString(value),
+	}
+}
+
+func newDelete(path string) *configapi.PathValue {
+	return &configapi.PathValue{
+		Path:    path,
+		Deleted: true,
+	}
+}
+
+func getPaths(pathValues []*configapi.PathValue) map[string]bool {
+	paths := make(map[string]bool)
+	for _, pathValue := range pathValues {
+		paths[pathValue.Path] = pathValue.Deleted
+	}
+	return paths
+}
+
+func getApplyState(t *testing.T, r *Reconciler, index configapi.Index) configapi.ProposalApplyPhase_State {
+	proposal, err := r.proposals.Get(context.Background(), proposalstore.NewID(testTarget, index))
+	assert.NoError(t, err)
+	return proposal.Status.Phases.Apply.State
+}
+
+func TestGetSetPathValues(t *testing.T) {
+	proposals := []*configapi.Proposal{
+		{
+			TransactionIndex: 1,
+			Details: &configapi.Proposal_Change{
+				Change: &configapi.ChangeProposal{
+					Values: map[string]*configapi.PathValue{
+						"/a/b": newValue("/a/b", "1"),
+						"/c":   newValue("/c", "1"),
+					},
+				},
+			},
+		},
+		{
+			TransactionIndex: 2,
+			Details: &configapi.Proposal_Change{
+				Change: &configapi.ChangeProposal{
+					Values: map[string]*configapi.PathValue{
+						"/a/b": newValue("/a/b", "2"),
+						"/d":   newDelete("/d"),
+					},
+				},
+			},
+		},
+		{
+			TransactionIndex: 3,
+			Details: &configapi.Proposal_Change{
+				Change: &configapi.ChangeProposal{
+					Values: map[string]*configapi.PathValue{
+						"/d/e": newValue("/d/e", "3"),
+					},
+				},
+			},
+		},
+	}
+
+	// A single Proposal's changes are sent as is
+	pathValues := getSetPathValues(proposals[:1])
+	assert.Equal(t, map[string]bool{"/a/b": false, "/c": false}, getPaths(pathValues))
+
+	// Later changes to the same path replace earlier ones
+	pathValues = getSetPathValues(proposals[:2])
+	assert.Equal(t, map[string]bool{"/a/b": false, "/c": false, "/d": true}, getPaths(pathValues))
+	for _, pathValue := range pathValues {
+		if pathValue.Path == "/a/b" {
+			assert.Equal(t, "2", pathValue.Value.ValueToString())
+		}
+	}
+
+	// A parent deleted by an earlier Proposal and recreated by a later one is still deleted
+	pathValues = getSetPathValues(proposals)
+	assert.Equal(t, map[string]bool{"/a/b": false, "/c": false, "/d": true, "/d/e": false}, getPaths(pathValues))
+}
+
+func TestApplyCoalescedProposals(t *testing.T) {
+	r, target := newTestReconciler(t, "{coalesce: true}")
+	config := newTestConfiguration(t, r)
+	proposals := []*configapi.Proposal{
+		newTestProposal(t, r, 1, newValue("/a", "1")),
+		newTestProposal(t, r, 2, newValue("/b", "2")),
+		newTestProposal(t, r, 3, newValue("/a", "3")),
+	}
+	conn := &testConn{}
+	mastership := topoapi.MastershipState{Term: 1, NodeId: "node-1"}
+
+	_, err := r.applyProposals(context.Background(), config, proposals, target, conn, mastership)
+	assert.NoError(t, err)
+
+	// All Proposals are sent in a single Set request
+	assert.Len(t, conn.requests, 1)
+	assert.Len(t, conn.requests[0].Update, 2)
+
+	config, err = r.configurations.Get(context.Background(), config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.Index(3), config.Status.Applied.Index)
+	assert.Equal(t, "3", config.Status.Applied.Values["/a"].Value.ValueToString())
+	assert.Equal(t, "2", config.Status.Applied.Values["/b"].Value.ValueToString())
+	assert.Equal(t, configapi.ProposalApplyPhase_APPLIED, getApplyState(t, r, 1))
+}
+
+func TestApplyCoalescedProposalsFallback(t *testing.T) {
+	r, target := newTestReconciler(t, "{coalesce: true}")
+	config := newTestConfiguration(t, r)
+	proposals := []*configapi.Proposal{
+		newTestProposal(t, r, 1, newValue("/a", "1")),
+		newTestProposal(t, r, 2, newValue("/b", "2")),
+	}
+
+	// Fail any request including the second Proposal's change
+	conn := &testConn{
+		set: func(request *gpb.SetRequest) error {
+			if len(request.Update) > 1 {
+				return errors.NewInvalid("invalid path /b")
+			}
+			return nil
+		},
+	}
+	mastership := topoapi.MastershipState{Term: 1, NodeId: "node-1"}
+
+	_, err := r.applyProposals(context.Background(), config, proposals, target, conn, mastership)
+	assert.NoError(t, err)
+
+	// The first Proposal is applied alone after the coalesced request fails
+	assert.Len(t, conn.requests, 2)
+	assert.Len(t, conn.requests[1].Update, 1)
+
+	config, err = r.configurations.Get(context.Background(), config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.Index(1), config.Status.Applied.Index)
+	assert.Equal(t, configapi.ProposalApplyPhase_APPLIED, getApplyState(t, r, 1))
+	assert.Equal(t, configapi.ProposalApplyPhase_APPLYING, getApplyState(t, r, 2))
+}
+
+func TestApplyCoalescedProposalsPartialFailure(t *testing.T) {
+	r, target := newTestReconciler(t, "{coalesce: true, maxUpdatesPerSet: 1}")
+	config := newTestConfiguration(t, r)
+	proposals := []*configapi.Proposal{
+		newTestProposal(t, r, 1, newValue("/a", "1")),
+		newTestProposal(t, r, 2, newValue("/b", "2")),
+	}
+
+	// Fail the second chunk after the first has been applied
+	conn := &testConn{
+		set: func(request *gpb.SetRequest) error {
+			paths := make([]string, 0)
+			for _, update := range request.Update {
+				paths = append(paths, update.Path.Elem[0].Name)
+			}
+			sort.Strings(paths)
+			if paths[0] == "b" {
+				return errors.NewInvalid("invalid path /b")
+			}
+			return nil
+		},
+	}
+	mastership := topoapi.MastershipState{Term: 1, NodeId: "node-1"}
+
+	_, err := r.applyProposals(context.Background(), config, proposals, target, conn, mastership)
+	assert.NoError(t, err)
+
+	// The Proposals are not applied separately once a chunk has been applied
+	assert.Len(t, conn.requests, 2)
+
+	config, err = r.configurations.Get(context.Background(), config.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.Index(2), config.Status.Applied.Index)
+	for _, index := range []configapi.Index{1, 2} {
+		proposal, err := r.proposals.Get(context.Background(), proposalstore.NewID(testTarget, index))
+		assert.NoError(t, err)
+		assert.Equal(t, configapi.ProposalApplyPhase_FAILED, proposal.Status.Phases.Apply.State)
+		assert.Equal(t, configapi.Failure_INVALID, proposal.Status.Phases.Apply.Failure.Type)
+		assert.Contains(t, proposal.Status.Phases.Apply.Failure.Description, "chunk 2 of 2")
+	}
+}
@@ -16,11 +16,11 @@ package target
 
 import (
 	"context"
-	"time"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
 	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
 	"github.com/onosproject/onos-config/pkg/store/topo"
 	"github.com/onosproject/onos-lib-go/pkg/controller"
@@ -29,10 +29,6 @@ import (
 
 var log = logging.GetLogger("controller", "target")
 
-const (
-	defaultTimeout = 30 * time.Second
-)
-
 // NewController returns a new gNMI connection  controller
 func NewController(topo topo.Store, conns gnmi.ConnManager) *controller.Controller {
 	c := controller.NewController("connection")
@@ -54,7 +50,7 @@ type Reconciler struct {
 
 // Reconcile reconciles a connection for a gnmi target
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
 	defer cancel()
 
 	targetID := id.Value.(topoapi.ID)
@@ -0,0 +1,272 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/controller"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var log = logging.GetLogger("controller", "telemetry")
+
+const (
+	// minRetryDelay is the delay before the first attempt to reopen a failed subscription
+	minRetryDelay = time.Second
+	// maxRetryDelay is the upper bound of the delay between attempts to reopen a failed subscription
+	maxRetryDelay = 30 * time.Second
+)
+
+// NewController returns a new telemetry cache controller
+func NewController(topo topo.Store, conns gnmi.ConnManager, cache telemetry.Cache) *controller.Controller {
+	c := controller.NewController("telemetry")
+	c.Watch(&TopoWatcher{
+		topo: topo,
+	})
+	c.Watch(&ConnWatcher{
+		conns: conns,
+	})
+	c.Reconcile(&Reconciler{
+		topo:          topo,
+		conns:         conns,
+		cache:         cache,
+		subscriptions: make(map[topoapi.ID]*subscription),
+	})
+	return c
+}
+
+// Reconciler reconciles the southbound subscriptions maintaining the telemetry cache
+type Reconciler struct {
+	topo          topo.Store
+	conns         gnmi.ConnManager
+	cache         telemetry.Cache
+	subscriptions map[topoapi.ID]*subscription
+	mu            sync.Mutex
+}
+
+// subscription is a southbound subscription to the cached paths of a target
+type subscription struct {
+	options  *topo.TelemetryCacheOptions
+	encoding gpb.Encoding
+	client   gnmi.Client
+	cancel   context.CancelFunc
+}
+
+// Reconcile reconciles the telemetry cache subscription for a target
+func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
+	defer cancel()
+
+	targetID := id.Value.(topoapi.ID)
+	log.Debugf("Reconciling telemetry cache for Target '%s'", targetID)
+	target, err := r.topo.Get(ctx, targetID)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed reconciling telemetry cache for Target '%s'", targetID, err)
+			return controller.Result{}, err
+		}
+		r.unsubscribe(targetID)
+		return controller.Result{}, nil
+	}
+
+	options, err := topo.GetTelemetryCacheOptions(target)
+	if err != nil {
+		log.Warnf("Failed reading telemetry cache options for Target '%s'", targetID, err)
+		r.unsubscribe(targetID)
+		return controller.Result{}, nil
+	}
+	if len(options.Paths) == 0 {
+		r.unsubscribe(targetID)
+		return controller.Result{}, nil
+	}
+
+	paths := make([]*gpb.Path, 0, len(options.Paths))
+	for _, path := range options.Paths {
+		gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+		if err != nil {
+			log.Warnf("Invalid telemetry cache path '%s' for Target '%s'", path, targetID, err)
+			r.unsubscribe(targetID)
+			return controller.Result{}, nil
+		}
+		paths = append(paths, gnmiPath)
+	}
+
+	if options.MasterOnly {
+		master, err := r.isMaster(ctx, target)
+		if err != nil {
+			log.Errorf("Failed reconciling telemetry cache for Target '%s'", targetID, err)
+			return controller.Result{}, err
+		}
+		if !master {
+			log.Debugf("Not the master for Target '%s'", targetID)
+			r.unsubscribe(targetID)
+			return controller.Result{}, nil
+		}
+	}
+
+	client, err := r.conns.GetByTarget(ctx, targetID)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Failed reconciling telemetry cache for Target '%s'", targetID, err)
+			return controller.Result{}, err
+		}
+		r.unsubscribe(targetID)
+		return controller.Result{}, nil
+	}
+
+	encoding, err := topo.GetEncoding(target, gpb.Encoding_PROTO)
+	if err != nil {
+		log.Warnf("Failed reading encoding for Target '%s'", targetID, err)
+		encoding = gpb.Encoding_PROTO
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sub, ok := r.subscriptions[targetID]; ok {
+		if sub.client == client && sub.encoding == encoding && reflect.DeepEqual(sub.options, options) {
+			return controller.Result{}, nil
+		}
+		sub.cancel()
+	}
+
+	subCtx, subCancel := context.WithCancel(context.Background())
+	r.subscriptions[targetID] = &subscription{
+		options:  options,
+		encoding: encoding,
+		client:   client,
+		cancel:   subCancel,
+	}
+	generation := r.cache.Open(targetID, paths, time.Duration(options.MaxAge))
+	go r.subscribe(subCtx, targetID, generation, client, newSubscribeRequest(targetID, paths, options, encoding))
+	return controller.Result{}, nil
+}
+
+// isMaster returns whether this node is the source of the target's master relation
+func (r *Reconciler) isMaster(ctx context.Context, target *topoapi.Object) (bool, error) {
+	mastership := topoapi.MastershipState{}
+	_ = target.GetAspect(&mastership)
+	if mastership.NodeId == "" {
+		return false, nil
+	}
+	relation, err := r.topo.Get(ctx, topoapi.ID(mastership.NodeId))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		return false, nil
+	}
+	return relation.GetRelation().GetSrcEntityID() == controllerutils.GetOnosConfigID(), nil
+}
+
+// unsubscribe cancels the subscription for a target and disables its cache
+func (r *Reconciler) unsubscribe(targetID topoapi.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sub, ok := r.subscriptions[targetID]; ok {
+		log.Infof("Canceling telemetry cache subscription for Target '%s'", targetID)
+		sub.cancel()
+		delete(r.subscriptions, targetID)
+		r.cache.Close(targetID)
+	}
+}
+
+// subscribe maintains a subscription to the target until the context is canceled, reopening it when it fails.
+// The subscription only writes to the cache generation opened for it, so a canceled subscription that is still
+// draining its stream cannot modify the cache opened for its replacement.
+func (r *Reconciler) subscribe(ctx context.Context, targetID topoapi.ID, generation telemetry.Generation, client gnmi.Client, request *gpb.SubscribeRequest) {
+	retryDelay := minRetryDelay
+	for {
+		synced, err := r.stream(ctx, targetID, generation, client, request)
+		r.cache.Unsync(targetID, generation)
+		if ctx.Err() != nil {
+			return
+		}
+		if synced {
+			retryDelay = minRetryDelay
+		}
+		log.Warnf("Telemetry cache subscription for Target '%s' failed, retrying in %s", targetID, retryDelay, err)
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return
+		}
+		retryDelay *= 2
+		if retryDelay > maxRetryDelay {
+			retryDelay = maxRetryDelay
+		}
+	}
+}
+
+// stream receives responses on a subscription and applies them to the cache until the subscription fails
+func (r *Reconciler) stream(ctx context.Context, targetID topoapi.ID, generation telemetry.Generation, client gnmi.Client, request *gpb.SubscribeRequest) (bool, error) {
+	log.Infof("Opening telemetry cache subscription for Target '%s'", targetID)
+	stream, err := client.SubscribeStream(ctx, request)
+	if err != nil {
+		return false, err
+	}
+	synced := false
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return synced, errors.FromGRPC(err)
+		}
+		switch resp := response.Response.(type) {
+		case *gpb.SubscribeResponse_Update:
+			r.cache.Update(targetID, generation, resp.Update)
+		case *gpb.SubscribeResponse_SyncResponse:
+			log.Infof("Telemetry cache for Target '%s' is synchronized", targetID)
+			r.cache.Sync(targetID, generation)
+			synced = true
+		}
+	}
+}
+
+// newSubscribeRequest creates a streaming subscription to the given paths
+func newSubscribeRequest(targetID topoapi.ID, paths []*gpb.Path, options *topo.TelemetryCacheOptions, encoding gpb.Encoding) *gpb.SubscribeRequest {
+	subscriptions := make([]*gpb.Subscription, 0, len(paths))
+	for _, path := range paths {
+		subscription := &gpb.Subscription{
+			Path: path,
+			Mode: gpb.SubscriptionMode_ON_CHANGE,
+		}
+		if options.Mode == topo.TelemetryCacheSample {
+			subscription.Mode = gpb.SubscriptionMode_SAMPLE
+			subscription.SampleInterval = uint64(time.Duration(options.SampleInterval).Nanoseconds())
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return &gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Prefix:       &gpb.Path{Target: string(targetID)},
+				Subscription: subscriptions,
+				Mode:         gpb.SubscriptionList_STREAM,
+				Encoding:     encoding,
+			},
+		},
+	}
+}
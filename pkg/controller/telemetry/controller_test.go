@@ -0,0 +1,151 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
+	gnmitest "github.com/onosproject/onos-config/pkg/northbound/gnmi/test"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/controller"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTarget        = "target-1"
+	testRelation      = "relation-1"
+	testOtherRelation = "relation-2"
+)
+
+// testClient is a southbound client failing all subscriptions
+type testClient struct {
+	gnmi.Client
+}
+
+func (c *testClient) SubscribeStream(ctx context.Context, request *gpb.SubscribeRequest) (gpb.GNMI_SubscribeClient, error) {
+	return nil, errors.NewUnavailable("target unavailable")
+}
+
+// testConnManager is a connection manager returning a single client
+type testConnManager struct {
+	gnmi.ConnManager
+	client gnmi.Client
+}
+
+func (m *testConnManager) GetByTarget(ctx context.Context, targetID topoapi.ID) (gnmi.Client, error) {
+	return m.client, nil
+}
+
+func newTestReconciler(t *testing.T, options string, master topoapi.ID) (*Reconciler, *topoapi.Object) {
+	mctl := gomock.NewController(t)
+
+	target := &topoapi.Object{
+		ID:   testTarget,
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+	assert.NoError(t, target.SetAspectBytes(topo.TelemetryCacheAspect, []byte(options)))
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{
+		Term:   1,
+		NodeId: string(master),
+	}))
+	relation := &topoapi.Object{
+		ID:   testRelation,
+		Type: topoapi.Object_RELATION,
+		Obj: &topoapi.Object_Relation{
+			Relation: &topoapi.Relation{
+				SrcEntityID: controllerutils.GetOnosConfigID(),
+				TgtEntityID: testTarget,
+			},
+		},
+	}
+	otherRelation := &topoapi.Object{
+		ID:   testOtherRelation,
+		Type: topoapi.Object_RELATION,
+		Obj: &topoapi.Object_Relation{
+			Relation: &topoapi.Relation{
+				SrcEntityID: "onos-config-2",
+				TgtEntityID: testTarget,
+			},
+		},
+	}
+	topoMock := gnmitest.NewMockStore(mctl)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(topoapi.ID(testTarget))).AnyTimes().Return(target, nil)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(topoapi.ID(testRelation))).AnyTimes().Return(relation, nil)
+	topoMock.EXPECT().Get(gomock.Any(), gomock.Eq(topoapi.ID(testOtherRelation))).AnyTimes().Return(otherRelation, nil)
+
+	r := &Reconciler{
+		topo:          topoMock,
+		conns:         &testConnManager{client: &testClient{}},
+		cache:         telemetry.NewCache(),
+		subscriptions: make(map[topoapi.ID]*subscription),
+	}
+	t.Cleanup(func() {
+		r.unsubscribe(testTarget)
+	})
+	return r, target
+}
+
+func isSubscribed(r *Reconciler) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.subscriptions[testTarget]
+	return ok
+}
+
+func TestReconcileEveryReplica(t *testing.T) {
+	// By default every replica subscribes to the target, whether or not it is the master
+	r, _ := newTestReconciler(t, `{"paths": ["/interfaces/interface[name=*]/state"]}`, testOtherRelation)
+	_, err := r.Reconcile(controller.NewID(topoapi.ID(testTarget)))
+	assert.NoError(t, err)
+	assert.True(t, isSubscribed(r))
+}
+
+func TestReconcileMasterOnly(t *testing.T) {
+	r, target := newTestReconciler(t, `{"paths": ["/interfaces/interface[name=*]/state"], "masterOnly": true}`, testOtherRelation)
+
+	// Replicas that do not master the target do not subscribe to it
+	_, err := r.Reconcile(controller.NewID(topoapi.ID(testTarget)))
+	assert.NoError(t, err)
+	assert.False(t, isSubscribed(r))
+
+	// The subscription is opened once this replica becomes the master
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{
+		Term:   2,
+		NodeId: testRelation,
+	}))
+	_, err = r.Reconcile(controller.NewID(topoapi.ID(testTarget)))
+	assert.NoError(t, err)
+	assert.True(t, isSubscribed(r))
+
+	// And closed when the mastership moves to another replica
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{
+		Term:   3,
+		NodeId: testOtherRelation,
+	}))
+	_, err = r.Reconcile(controller.NewID(topoapi.ID(testTarget)))
+	assert.NoError(t, err)
+	assert.False(t, isSubscribed(r))
+}
@@ -24,16 +24,13 @@ import (
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	controllerutils "github.com/onosproject/onos-config/pkg/controller/utils"
 	"github.com/onosproject/onos-lib-go/pkg/controller"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 )
 
 var log = logging.GetLogger("controller", "transaction")
 
-const (
-	defaultTimeout = 30 * time.Second
-)
-
 // NewController returns a transaction controller
 func NewController(transactions transactionstore.Store, proposals proposalstore.Store) *controller.Controller {
 	c := controller.NewController("transaction")
@@ -58,7 +55,7 @@ type Reconciler struct {
 
 // Reconcile reconciles target transactions
 func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), controllerutils.GetTimeout())
 	defer cancel()
 
 	index := id.Value.(configapi.Index)
@@ -0,0 +1,230 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	utilsv2 "github.com/onosproject/onos-config/pkg/utils/values/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var log = logging.GetLogger("controller", "utils")
+
+// setProgressInterval is the number of chunks applied between updates of the Set progress recorded on the target.
+// Each update notifies every topo watcher, so progress is recorded for groups of chunks rather than for each chunk;
+// at most this many chunks are sent again when an interrupted Set is resumed.
+const setProgressInterval = 10
+
+// SetFunc sends a gNMI SetRequest to a target
+type SetFunc func(ctx context.Context, request *gpb.SetRequest) (*gpb.SetResponse, error)
+
+// GetFunc sends a gNMI GetRequest to a target
+type GetFunc func(ctx context.Context, request *gpb.GetRequest) (*gpb.GetResponse, error)
+
+// SetChunked sends the given SetRequest to the given target, split into chunks within the limits configured in the
+// target's sync options. When the request is split, the progress is recorded on the target so that retrying the same
+// operation for the same index resumes after the last applied chunk. Progress recorded in an earlier mastership term
+// is only resumed if the values of the applied chunks are confirmed by reading them from the target.
+// Progress is recorded when a chunk fails and after every group of setProgressInterval chunks.
+// If a chunk fails, the error is returned along with the 1-based number of the failed chunk and the total number of chunks.
+// If the request cannot be sent within the configured limits, an Invalid error is returned for chunk 0.
+func SetChunked(ctx context.Context, topoStore topo.Store, target *topoapi.Object, operation topo.SetOperation,
+	index uint64, term uint64, request *gpb.SetRequest, set SetFunc, get GetFunc) (int, int, error) {
+	syncOptions, err := topo.GetSyncOptions(target)
+	if err != nil {
+		log.Warnf("Failed reading sync options for target '%s'; using defaults", target.ID, err)
+		syncOptions = &topo.SyncOptions{}
+	}
+
+	requests := utilsv2.SplitSetRequest(request, syncOptions.MaxUpdatesPerSet, syncOptions.MaxBytesPerSet)
+
+	// A replace of the configuration root cannot be split, so it must fit in a single request
+	if syncOptions.MaxBytesPerSet > 0 {
+		for _, update := range request.Replace {
+			if len(request.GetPrefix().GetElem()) == 0 && len(update.GetPath().GetElem()) == 0 {
+				if size := proto.Size(update); size > syncOptions.MaxBytesPerSet {
+					return 0, len(requests), errors.NewInvalid("replace of the configuration root of target '%s' is %d bytes, "+
+						"exceeding the limit of %d bytes per Set request; raise maxBytesPerSet or disable replace", target.ID, size, syncOptions.MaxBytesPerSet)
+				}
+			}
+		}
+	}
+
+	progress := &topo.SetProgress{
+		Operation: operation,
+		Index:     index,
+		Term:      term,
+		Chunks:    len(requests),
+	}
+
+	// If the same changes were already partially applied, resume after the last applied chunk.
+	// A master in a later term may have changed the target since, so chunks applied in an earlier
+	// term are only skipped if their values are still found on the target.
+	if len(requests) > 1 {
+		if lastProgress, err := topo.GetSetProgress(target); err == nil &&
+			lastProgress.Operation == operation && lastProgress.Index == index &&
+			lastProgress.Term <= term && lastProgress.Chunks == len(requests) && lastProgress.Applied > 0 {
+			if lastProgress.Term == term || confirmApplied(ctx, get, requests[:lastProgress.Applied]) {
+				progress.Applied = lastProgress.Applied
+				log.Infof("Resuming Set to target '%s' at chunk %d of %d", target.ID, progress.Applied+1, len(requests))
+			} else {
+				log.Infof("Cannot confirm chunks applied to target '%s' in term %d; restarting Set", target.ID, lastProgress.Term)
+			}
+		}
+	}
+
+	for i := progress.Applied; i < len(requests); i++ {
+		if len(requests) > 1 {
+			log.Infof("Sending chunk %d of %d to target '%s'", i+1, len(requests), target.ID)
+		}
+		log.Debugf("Sending SetRequest %+v", requests[i])
+		response, err := set(ctx, requests[i])
+		if err != nil {
+			if len(requests) > 1 {
+				progress.Applied = i
+				progress.Error = err.Error()
+				if err := updateSetProgress(ctx, topoStore, target.ID, progress); err != nil {
+					log.Warnf("Failed updating Set progress for target '%s'", target.ID, err)
+				}
+			}
+			return i + 1, len(requests), err
+		}
+		log.Debugf("Received SetResponse %+v", response)
+
+		if len(requests) > 1 && (i+1-progress.Applied >= setProgressInterval || i+1 == len(requests)) {
+			progress.Applied = i + 1
+			if err := updateSetProgress(ctx, topoStore, target.ID, progress); err != nil {
+				log.Warnf("Failed updating Set progress for target '%s'", target.ID, err)
+			}
+		}
+	}
+	return 0, len(requests), nil
+}
+
+// confirmApplied returns whether the changes of the given requests are found on the target, i.e. every updated or
+// replaced path has the value sent to the target and every deleted path does not exist
+func confirmApplied(ctx context.Context, get GetFunc, requests []*gpb.SetRequest) bool {
+	for _, request := range requests {
+		for _, path := range request.Delete {
+			response, err := get(ctx, &gpb.GetRequest{
+				Prefix:   request.Prefix,
+				Path:     []*gpb.Path{path},
+				Type:     gpb.GetRequest_CONFIG,
+				Encoding: gpb.Encoding_PROTO,
+			})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				log.Debugf("Failed confirming delete of %s", utils.StrPath(path), err)
+				return false
+			}
+			for _, notification := range response.Notification {
+				if len(notification.Update) > 0 {
+					return false
+				}
+			}
+		}
+		updates := make([]*gpb.Update, 0, len(request.Replace)+len(request.Update))
+		updates = append(updates, request.Replace...)
+		updates = append(updates, request.Update...)
+		for _, update := range updates {
+			response, err := get(ctx, &gpb.GetRequest{
+				Prefix:   request.Prefix,
+				Path:     []*gpb.Path{update.Path},
+				Type:     gpb.GetRequest_CONFIG,
+				Encoding: getValueEncoding(update.Val),
+			})
+			if err != nil {
+				log.Debugf("Failed confirming update of %s", utils.StrPath(update.Path), err)
+				return false
+			}
+			if !containsValue(response.Notification, update.Val) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getValueEncoding returns the encoding of the given value
+func getValueEncoding(value *gpb.TypedValue) gpb.Encoding {
+	switch value.GetValue().(type) {
+	case *gpb.TypedValue_JsonVal:
+		return gpb.Encoding_JSON
+	case *gpb.TypedValue_JsonIetfVal:
+		return gpb.Encoding_JSON_IETF
+	default:
+		return gpb.Encoding_PROTO
+	}
+}
+
+// containsValue returns whether the given notifications contain a single update with the given value.
+// JSON values are compared by their decoded content.
+func containsValue(notifications []*gpb.Notification, value *gpb.TypedValue) bool {
+	var updates []*gpb.Update
+	for _, notification := range notifications {
+		updates = append(updates, notification.Update...)
+	}
+	if len(updates) != 1 {
+		return false
+	}
+	actual := updates[0].Val
+	switch getValueEncoding(value) {
+	case gpb.Encoding_JSON, gpb.Encoding_JSON_IETF:
+		var expectedJSON, actualJSON interface{}
+		if err := json.Unmarshal(getJSON(value), &expectedJSON); err != nil {
+			return false
+		}
+		if err := json.Unmarshal(getJSON(actual), &actualJSON); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(expectedJSON, actualJSON)
+	default:
+		return proto.Equal(value, actual)
+	}
+}
+
+// getJSON returns the JSON or JSON_IETF encoded bytes of the given value
+func getJSON(value *gpb.TypedValue) []byte {
+	if jsonVal := value.GetJsonVal(); jsonVal != nil {
+		return jsonVal
+	}
+	return value.GetJsonIetfVal()
+}
+
+// updateSetProgress records the given Set progress on the target
+func updateSetProgress(ctx context.Context, topoStore topo.Store, targetID topoapi.ID, progress *topo.SetProgress) error {
+	target, err := topoStore.Get(ctx, targetID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := topo.SetSetProgress(target, progress); err != nil {
+		return err
+	}
+	return topoStore.Update(ctx, target)
+}
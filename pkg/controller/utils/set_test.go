@@ -0,0 +1,227 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTarget is a target storing the values set by Set requests and serving them to Get requests
+type testTarget struct {
+	values map[string]*gpb.TypedValue
+	sets   int
+	failAt int
+}
+
+func (t *testTarget) Set(ctx context.Context, request *gpb.SetRequest) (*gpb.SetResponse, error) {
+	t.sets++
+	if t.sets == t.failAt {
+		return nil, errors.NewUnavailable("target unavailable")
+	}
+	for _, path := range request.Delete {
+		delete(t.values, utils.StrPath(path))
+	}
+	for _, update := range append(append([]*gpb.Update{}, request.Replace...), request.Update...) {
+		t.values[utils.StrPath(update.Path)] = update.Val
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+func (t *testTarget) Get(ctx context.Context, request *gpb.GetRequest) (*gpb.GetResponse, error) {
+	value, ok := t.values[utils.StrPath(request.Path[0])]
+	if !ok {
+		return nil, errors.NewNotFound("path not found")
+	}
+	return &gpb.GetResponse{
+		Notification: []*gpb.Notification{
+			{
+				Update: []*gpb.Update{{Path: request.Path[0], Val: value}},
+			},
+		},
+	}, nil
+}
+
+func newTestTopo(t *testing.T, syncOptions string) (topo.Store, *topoapi.Object) {
+	path := filepath.Join(t.TempDir(), "topo.yaml")
+	contents := `
+targets:
+  - id: target-1
+    aspects:
+      onos.topo.Configurable:
+        address: target-1:11161
+        type: devicesim
+        version: 1.0.0
+      onos.config.SyncOptions: ` + syncOptions + `
+`
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	topoStore, err := topo.NewFileStore(path)
+	assert.NoError(t, err)
+	target, err := topoStore.Get(context.Background(), "target-1")
+	assert.NoError(t, err)
+	return topoStore, target
+}
+
+func newTestSetRequest(t *testing.T, values map[string]string) *gpb.SetRequest {
+	request := &gpb.SetRequest{}
+	for path, value := range values {
+		gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+		assert.NoError(t, err)
+		request.Update = append(request.Update, &gpb.Update{
+			Path: gnmiPath,
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: value}},
+		})
+	}
+	return request
+}
+
+func getTarget(t *testing.T, topoStore topo.Store) *topoapi.Object {
+	target, err := topoStore.Get(context.Background(), "target-1")
+	assert.NoError(t, err)
+	return target
+}
+
+func TestSetChunked(t *testing.T) {
+	topoStore, target := newTestTopo(t, "{maxUpdatesPerSet: 1}")
+	request := newTestSetRequest(t, map[string]string{"/a": "1", "/b": "2", "/c": "3"})
+	device := &testTarget{values: make(map[string]*gpb.TypedValue), failAt: 2}
+	ctx := context.Background()
+
+	// A failed chunk is reported with the total number of chunks
+	chunk, chunks, err := SetChunked(ctx, topoStore, target, topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.True(t, errors.IsUnavailable(err))
+	assert.Equal(t, 2, chunk)
+	assert.Equal(t, 3, chunks)
+	progress, err := topo.GetSetProgress(getTarget(t, topoStore))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, progress.Applied)
+
+	// Retrying in the same term resumes after the last applied chunk
+	_, _, err = SetChunked(ctx, topoStore, getTarget(t, topoStore), topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, device.sets)
+	assert.Len(t, device.values, 3)
+}
+
+// countingTopo is a topo store counting updates
+type countingTopo struct {
+	topo.Store
+	updates int
+}
+
+func (s *countingTopo) Update(ctx context.Context, object *topoapi.Object) error {
+	s.updates++
+	return s.Store.Update(ctx, object)
+}
+
+func TestSetChunkedProgressInterval(t *testing.T) {
+	fileStore, target := newTestTopo(t, "{maxUpdatesPerSet: 1}")
+	topoStore := &countingTopo{Store: fileStore}
+	values := make(map[string]string)
+	for i := 0; i < 2*setProgressInterval+5; i++ {
+		values[fmt.Sprintf("/path-%d", i)] = "value"
+	}
+	request := newTestSetRequest(t, values)
+	device := &testTarget{values: make(map[string]*gpb.TypedValue), failAt: setProgressInterval + 3}
+	ctx := context.Background()
+
+	// Progress is recorded for each group of chunks and for the failed chunk
+	_, _, err := SetChunked(ctx, topoStore, target, topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.Error(t, err)
+	assert.Equal(t, 2, topoStore.updates)
+	progress, err := topo.GetSetProgress(getTarget(t, topoStore))
+	assert.NoError(t, err)
+	assert.Equal(t, setProgressInterval+2, progress.Applied)
+
+	// Resuming records progress for the remaining groups of chunks
+	topoStore.updates = 0
+	_, _, err = SetChunked(ctx, topoStore, getTarget(t, topoStore), topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, topoStore.updates)
+	assert.Equal(t, 2*setProgressInterval+6, device.sets)
+	progress, err = topo.GetSetProgress(getTarget(t, topoStore))
+	assert.NoError(t, err)
+	assert.Equal(t, 2*setProgressInterval+5, progress.Applied)
+}
+
+func TestSetChunkedResumeInLaterTerm(t *testing.T) {
+	topoStore, target := newTestTopo(t, "{maxUpdatesPerSet: 1}")
+	request := newTestSetRequest(t, map[string]string{"/a": "1", "/b": "2", "/c": "3"})
+	device := &testTarget{values: make(map[string]*gpb.TypedValue), failAt: 3}
+	ctx := context.Background()
+
+	_, _, err := SetChunked(ctx, topoStore, target, topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.Error(t, err)
+
+	// Chunks applied in an earlier term are skipped if their values are found on the target
+	_, _, err = SetChunked(ctx, topoStore, getTarget(t, topoStore), topo.SetOperationApply, 1, 2, request, device.Set, device.Get)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, device.sets)
+	progress, err := topo.GetSetProgress(getTarget(t, topoStore))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), progress.Term)
+	assert.Equal(t, 3, progress.Applied)
+}
+
+func TestSetChunkedRestartInLaterTerm(t *testing.T) {
+	topoStore, target := newTestTopo(t, "{maxUpdatesPerSet: 1}")
+	request := newTestSetRequest(t, map[string]string{"/a": "1", "/b": "2", "/c": "3"})
+	device := &testTarget{values: make(map[string]*gpb.TypedValue), failAt: 3}
+	ctx := context.Background()
+
+	_, _, err := SetChunked(ctx, topoStore, target, topo.SetOperationApply, 1, 1, request, device.Set, device.Get)
+	assert.Error(t, err)
+
+	// All chunks are sent again if a value applied in an earlier term has changed on the target
+	for path := range device.values {
+		device.values[path] = &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "changed"}}
+	}
+	_, _, err = SetChunked(ctx, topoStore, getTarget(t, topoStore), topo.SetOperationApply, 1, 2, request, device.Set, device.Get)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, device.sets)
+	for _, value := range device.values {
+		assert.NotEqual(t, "changed", value.GetStringVal())
+	}
+}
+
+func TestSetChunkedRootReplaceTooLarge(t *testing.T) {
+	topoStore, target := newTestTopo(t, "{maxBytesPerSet: 16}")
+	request := &gpb.SetRequest{
+		Replace: []*gpb.Update{
+			{
+				Path: &gpb.Path{},
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(`{"system": {"config": {"hostname": "switch-1"}}}`)}},
+			},
+		},
+	}
+	assert.Greater(t, proto.Size(request.Replace[0]), 16)
+	device := &testTarget{values: make(map[string]*gpb.TypedValue)}
+
+	chunk, _, err := SetChunked(context.Background(), topoStore, target, topo.SetOperationSync, 1, 1, request, device.Set, device.Get)
+	assert.True(t, errors.IsInvalid(err))
+	assert.Equal(t, 0, chunk)
+	assert.Equal(t, 0, device.sets)
+}
@@ -15,7 +15,10 @@
 package utils
 
 import (
+	"time"
+
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/config"
 	"github.com/onosproject/onos-lib-go/pkg/env"
 	"github.com/onosproject/onos-lib-go/pkg/uri"
 )
@@ -26,3 +29,8 @@ func GetOnosConfigID() topoapi.ID {
 		uri.WithScheme("gnmi"),
 		uri.WithOpaque(env.GetPodID())).String())
 }
+
+// GetTimeout gets the timeout of a single controller reconciliation
+func GetTimeout() time.Duration {
+	return config.GetSettings().ControllerTimeout
+}
@@ -0,0 +1,166 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/config/load"
+	"github.com/onosproject/onos-config/pkg/store/transaction"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/uri"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// bootstrapRetryInterval is the interval at which a bootstrap file whose targets are not yet known is retried
+	bootstrapRetryInterval = 5 * time.Second
+	// bootstrapTimeout is the timeout for applying a single bootstrap file
+	bootstrapTimeout = time.Minute
+	// bootstrapMaxAttempts is the number of transactions submitted for a bootstrap file before it is abandoned
+	bootstrapMaxAttempts = 10
+)
+
+// bootstrapApplier applies a SetRequest as the transaction with the given ID
+type bootstrapApplier interface {
+	Apply(ctx context.Context, transactionID configapi.TransactionID, req *gpb.SetRequest) (*gpb.SetResponse, error)
+}
+
+// bootstrap applies the SetRequest files of the bootstrap directory in lexical order. Each file is applied
+// as a transaction whose ID is derived from the name and the contents of the file, so files that were
+// already applied are skipped when onos-config restarts. Files whose transaction failed are submitted
+// again as a new transaction, up to bootstrapMaxAttempts times.
+func (m *Manager) bootstrap(ctx context.Context, gnmi bootstrapApplier, transactions transaction.Store) {
+	files, err := listBootstrapFiles(m.Config.BootstrapPath)
+	if err != nil {
+		log.Errorf("Failed listing bootstrap files in %s", m.Config.BootstrapPath, err)
+		return
+	}
+
+	for _, file := range files {
+		var lastErr string
+		for {
+			err := m.bootstrapFile(ctx, gnmi, transactions, file)
+			if err == nil {
+				break
+			}
+			if !isRetryableBootstrapError(err) {
+				log.Errorf("Failed applying bootstrap file %s", file, err)
+				break
+			}
+			if err.Error() != lastErr {
+				log.Warnf("Failed applying bootstrap file %s, retrying", file, err)
+				lastErr = err.Error()
+			}
+			select {
+			case <-time.After(bootstrapRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	log.Infof("Applied bootstrap files in %s", m.Config.BootstrapPath)
+}
+
+// bootstrapFile applies the SetRequest in the given file unless it has already been applied.
+// If earlier transactions for the file failed, the file is applied as a new transaction.
+func (m *Manager) bootstrapFile(ctx context.Context, gnmi bootstrapApplier, transactions transaction.Store, file string) error {
+	ctx, cancel := context.WithTimeout(ctx, bootstrapTimeout)
+	defer cancel()
+
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.NewInvalid("failed reading bootstrap file: %v", err)
+	}
+
+	// Find the first attempt that has not failed
+	var transactionID configapi.TransactionID
+	for attempt := 0; ; attempt++ {
+		if attempt == bootstrapMaxAttempts {
+			return errors.NewInternal("abandoned after %d failed transactions", bootstrapMaxAttempts)
+		}
+		transactionID = newBootstrapTransactionID(file, contents, attempt)
+		existing, err := transactions.Get(ctx, transactionID)
+		if errors.IsNotFound(err) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if existing.Status.State != configapi.TransactionStatus_FAILED {
+			log.Infof("Skipping bootstrap file %s: already applied as transaction %s", file, transactionID)
+			return nil
+		}
+		log.Warnf("Bootstrap transaction %s for file %s failed", transactionID, file)
+	}
+
+	config, err := load.LoadConfigGnmi(file)
+	if err != nil {
+		return errors.NewInvalid(err.Error())
+	}
+	_, err = gnmi.Apply(ctx, transactionID, load.ToGnmiSetRequest(&config))
+	if err != nil {
+		if ctx.Err() != nil {
+			return errors.NewTimeout("timed out applying transaction %s", transactionID)
+		}
+		if errors.IsAlreadyExists(err) {
+			log.Infof("Skipping bootstrap file %s: already applied as transaction %s", file, transactionID)
+			return nil
+		}
+		return err
+	}
+	log.Infof("Applied bootstrap file %s as transaction %s", file, transactionID)
+	return nil
+}
+
+// isRetryableBootstrapError returns whether applying a bootstrap file may succeed if it is retried,
+// e.g. once its targets are known or reachable
+func isRetryableBootstrapError(err error) bool {
+	return errors.IsNotFound(err) || errors.IsUnavailable(err) || errors.IsTimeout(err) ||
+		errors.IsConflict(err) || errors.IsCanceled(err)
+}
+
+// listBootstrapFiles returns the YAML files in the given directory in lexical order
+func listBootstrapFiles(dir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// newBootstrapTransactionID returns the ID of the transaction applying the given bootstrap file contents.
+// The first attempt keeps the ID of the original format; later attempts are numbered.
+func newBootstrapTransactionID(file string, contents []byte, attempt int) configapi.TransactionID {
+	hash := sha256.Sum256(contents)
+	opaque := fmt.Sprintf("%s:%x", filepath.Base(file), hash[:8])
+	if attempt > 0 {
+		opaque = fmt.Sprintf("%s:%d", opaque, attempt)
+	}
+	return configapi.TransactionID(uri.NewURI(
+		uri.WithScheme("bootstrap"),
+		uri.WithOpaque(opaque)).String())
+}
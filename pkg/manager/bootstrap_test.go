@@ -0,0 +1,171 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/transaction"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBootstrapFile = `
+setrequest:
+  prefix:
+    elem:
+      - name: e2node
+    target: ""
+  delete: [ ]
+  replace: [ ]
+  update:
+    - path:
+        elem:
+          - name: intervals
+          - name: RadioMeasReportPerUe
+        target: target-1
+      val:
+        uintvalue:
+          uintval: 20
+`
+
+// testApplier records the transactions applied by the bootstrap and creates them in the transaction store
+// in the given state
+type testApplier struct {
+	transactions transaction.Store
+	state        configapi.TransactionStatus_State
+	err          error
+	applied      []configapi.TransactionID
+}
+
+func (a *testApplier) Apply(ctx context.Context, transactionID configapi.TransactionID, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	a.applied = append(a.applied, transactionID)
+	if a.err != nil {
+		return nil, a.err
+	}
+	err := a.transactions.Create(ctx, &configapi.Transaction{
+		ID: transactionID,
+		Status: configapi.TransactionStatus{
+			State: a.state,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+func newTestBootstrap(t *testing.T, files ...string) (*Manager, string) {
+	dir := t.TempDir()
+	for _, file := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(testBootstrapFile), 0644))
+	}
+	return &Manager{Config: Config{BootstrapPath: dir}}, dir
+}
+
+func TestListBootstrapFiles(t *testing.T) {
+	_, dir := newTestBootstrap(t, "b.yml", "c.yaml", "a.yaml", "README.md")
+
+	files, err := listBootstrapFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.yml"),
+		filepath.Join(dir, "c.yaml"),
+	}, files)
+
+	files, err = listBootstrapFiles(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestBootstrap(t *testing.T) {
+	m, _ := newTestBootstrap(t, "a.yaml", "b.yaml")
+	transactions := transaction.NewMemoryStore()
+	applier := &testApplier{
+		transactions: transactions,
+		state:        configapi.TransactionStatus_APPLIED,
+	}
+
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Len(t, applier.applied, 2)
+	assert.Contains(t, string(applier.applied[0]), "a.yaml")
+	assert.Contains(t, string(applier.applied[1]), "b.yaml")
+
+	// Files that were already applied are skipped when bootstrapping again
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Len(t, applier.applied, 2)
+}
+
+func TestBootstrapFailedTransaction(t *testing.T) {
+	m, _ := newTestBootstrap(t, "a.yaml")
+	transactions := transaction.NewMemoryStore()
+	applier := &testApplier{
+		transactions: transactions,
+		state:        configapi.TransactionStatus_FAILED,
+	}
+
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Len(t, applier.applied, 1)
+
+	// A file whose transaction failed is submitted again as a new transaction
+	applier.state = configapi.TransactionStatus_APPLIED
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Len(t, applier.applied, 2)
+	assert.NotEqual(t, applier.applied[0], applier.applied[1])
+	assert.Equal(t, string(applier.applied[0])+":1", string(applier.applied[1]))
+
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Len(t, applier.applied, 2)
+}
+
+func TestBootstrapAbandoned(t *testing.T) {
+	m, dir := newTestBootstrap(t, "a.yaml")
+	transactions := transaction.NewMemoryStore()
+	applier := &testApplier{
+		transactions: transactions,
+		state:        configapi.TransactionStatus_FAILED,
+	}
+
+	for i := 0; i < bootstrapMaxAttempts; i++ {
+		m.bootstrap(context.Background(), applier, transactions)
+	}
+	assert.Len(t, applier.applied, bootstrapMaxAttempts)
+
+	// The file is abandoned once the maximum number of transactions has failed
+	err := m.bootstrapFile(context.Background(), applier, transactions, filepath.Join(dir, "a.yaml"))
+	assert.True(t, errors.IsInternal(err))
+	assert.False(t, isRetryableBootstrapError(err))
+	assert.Len(t, applier.applied, bootstrapMaxAttempts)
+}
+
+func TestBootstrapInvalidFile(t *testing.T) {
+	m, dir := newTestBootstrap(t)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte("setrequest: ["), 0644))
+	transactions := transaction.NewMemoryStore()
+	applier := &testApplier{
+		transactions: transactions,
+		state:        configapi.TransactionStatus_APPLIED,
+	}
+
+	// Invalid files are not retried
+	m.bootstrap(context.Background(), applier, transactions)
+	assert.Empty(t, applier.applied)
+}
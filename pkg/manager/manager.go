@@ -16,12 +16,16 @@
 package manager
 
 import (
-	"github.com/atomix/atomix-go-client/pkg/atomix"
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/config"
 	configurationcontroller "github.com/onosproject/onos-config/pkg/controller/configuration"
 	"github.com/onosproject/onos-config/pkg/controller/connection"
 	mastershipcontroller "github.com/onosproject/onos-config/pkg/controller/mastership"
 	proposalcontroller "github.com/onosproject/onos-config/pkg/controller/proposal"
 	"github.com/onosproject/onos-config/pkg/controller/target"
+	telemetrycontroller "github.com/onosproject/onos-config/pkg/controller/telemetry"
 	"github.com/onosproject/onos-config/pkg/store/proposal"
 
 	"github.com/onosproject/onos-config/pkg/controller/node"
@@ -29,11 +33,14 @@ import (
 	gnminb "github.com/onosproject/onos-config/pkg/northbound/gnmi/v2"
 	"github.com/onosproject/onos-config/pkg/pluginregistry"
 	sb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/netconf"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
 	"github.com/onosproject/onos-config/pkg/store/configuration"
 	"github.com/onosproject/onos-config/pkg/store/topo"
 	"github.com/onosproject/onos-config/pkg/store/transaction"
 	"github.com/onosproject/onos-lib-go/pkg/certs"
 	"github.com/onosproject/onos-lib-go/pkg/northbound"
+	"go.etcd.io/bbolt"
 
 	"os"
 
@@ -53,13 +60,31 @@ type Config struct {
 	CertPath    string
 	GRPCPort    int
 	TopoAddress string
-	Plugins     []string
+	// TopoFile is the path of a topology file to use instead of onos-topo when set
+	TopoFile string
+	Plugins  []string
+	// DialOutPort is the port on which targets initiating the southbound connection are accepted; 0 disables it
+	DialOutPort int
+	// StoreBackend selects the backend of the transaction, proposal and configuration stores; defaults to Atomix
+	StoreBackend StoreBackend
+	// StorePath is the path of the database file used by the embedded store backend
+	StorePath string
+	// AllowUnvalidatedConfig allows configuring targets without a model plugin for their type and version
+	AllowUnvalidatedConfig bool
+	// NodeLeaseDuration is the duration of the onos-config node lease in topo
+	NodeLeaseDuration time.Duration
+	// BootstrapPath is the path of a directory of SetRequest files applied once at startup
+	BootstrapPath string
+	// ConfigFile is the path of the configuration file from which the runtime settings are loaded and reloaded
+	ConfigFile string
 }
 
 // Manager single point of entry for the config system.
 type Manager struct {
 	Config         Config
 	pluginRegistry pluginregistry.PluginRegistry
+	db             *bbolt.DB
+	cancel         context.CancelFunc
 }
 
 // NewManager initializes the network config manager subsystem.
@@ -87,7 +112,8 @@ func (m *Manager) startNorthboundServer(
 	transactionsStore transaction.Store,
 	proposalsStore proposal.Store,
 	configurationsStore configuration.Store,
-	pluginRegistry pluginregistry.PluginRegistry, conns sb.ConnManager) error {
+	statusesStore configuration.StatusStore,
+	pluginRegistry pluginregistry.PluginRegistry, conns sb.ConnManager, cache telemetry.Cache) error {
 	authorization := false
 	if oidcURL := os.Getenv(OIDCServerURL); oidcURL != "" {
 		authorization = true
@@ -107,8 +133,8 @@ func (m *Manager) startNorthboundServer(
 
 	s.AddService(logging.Service{})
 
-	adminService := admin.NewService(transactionsStore, configurationsStore, pluginRegistry)
-	gnmi := gnminb.NewService(topo, transactionsStore, proposalsStore, configurationsStore, pluginRegistry, conns)
+	adminService := admin.NewService(transactionsStore, configurationsStore, statusesStore, pluginRegistry, topo, conns)
+	gnmi := gnminb.NewService(topo, transactionsStore, proposalsStore, configurationsStore, pluginRegistry, conns, cache)
 	s.AddService(adminService)
 	s.AddService(gnmi)
 
@@ -125,15 +151,54 @@ func (m *Manager) startNorthboundServer(
 	return <-doneCh
 }
 
+// startDialOutListener accepts southbound connections from dial-out targets
+func (m *Manager) startDialOutListener(conns sb.ConnManager) error {
+	lis, err := sb.NewDialOutListener(m.Config.DialOutPort, m.Config.CAPath, m.Config.KeyPath, m.Config.CertPath)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := conns.ServeDialOut(lis); err != nil {
+			log.Errorf("Stopped accepting dial-out targets", err)
+		}
+	}()
+	return nil
+}
+
+// watchConfig applies the runtime settings of the configuration file and reloads them when the file changes
+func (m *Manager) watchConfig(ctx context.Context) error {
+	initial, err := config.Watch(ctx, m.Config.ConfigFile, func(prev, next *config.Config) {
+		settings, err := next.Settings()
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		config.SetSettings(settings)
+		log.Infof("Reloaded settings from configuration file %s", m.Config.ConfigFile)
+		if next.RequiresRestart(prev) {
+			log.Warnf("Changes to configuration file %s other than the controllers timeout, the OPA URL and the transaction strategy require a restart", m.Config.ConfigFile)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	settings, err := initial.Settings()
+	if err != nil {
+		return err
+	}
+	config.SetSettings(settings)
+	return nil
+}
+
 // startNodeController starts node controller
 func (m *Manager) startNodeController(topo topo.Store) error {
-	nodeController := node.NewController(topo)
+	nodeController := node.NewController(topo, m.Config.NodeLeaseDuration)
 	return nodeController.Start()
 }
 
 // startConnController starts connection controller
-func (m *Manager) startConnController(topo topo.Store, conns sb.ConnManager) error {
-	connController := connection.NewController(topo, conns)
+func (m *Manager) startConnController(topo topo.Store, conns sb.ConnManager, pluginRegistry pluginregistry.PluginRegistry) error {
+	connController := connection.NewController(topo, conns, pluginRegistry)
 	return connController.Start()
 }
 
@@ -143,14 +208,20 @@ func (m *Manager) startTargetController(topo topo.Store, conns sb.ConnManager) e
 	return targetController.Start()
 }
 
+// startTelemetryController starts telemetry cache controller
+func (m *Manager) startTelemetryController(topo topo.Store, conns sb.ConnManager, cache telemetry.Cache) error {
+	telemetryController := telemetrycontroller.NewController(topo, conns, cache)
+	return telemetryController.Start()
+}
+
 // startMastershipController starts mastership controller
 func (m *Manager) startMastershipController(topo topo.Store) error {
 	mastershipController := mastershipcontroller.NewController(topo)
 	return mastershipController.Start()
 }
 
-func (m *Manager) startConfigurationController(topo topo.Store, conns sb.ConnManager, configurations configuration.Store) error {
-	configurationController := configurationcontroller.NewController(topo, conns, configurations)
+func (m *Manager) startConfigurationController(topo topo.Store, conns sb.ConnManager, configurations configuration.Store, statuses configuration.StatusStore, transactions transaction.Store, pluginRegistry pluginregistry.PluginRegistry) error {
+	configurationController := configurationcontroller.NewController(topo, conns, configurations, statuses, transactions, pluginRegistry)
 	return configurationController.Start()
 }
 
@@ -171,28 +242,29 @@ func (m *Manager) Start() error {
 		return err
 	}
 
-	atomixClient := atomix.NewClient(atomix.WithClientID(os.Getenv("POD_NAME")))
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
 
-	// Create new topo store
-	topoStore, err := topo.NewStore(m.Config.TopoAddress, opts...)
-	if err != nil {
-		return err
+	if m.Config.ConfigFile != "" {
+		if err := m.watchConfig(ctx); err != nil {
+			return err
+		}
 	}
 
-	// Create the transactions store
-	transactions, err := transaction.NewAtomixStore(atomixClient)
-	if err != nil {
-		return err
+	// Create new topo store
+	var topoStore topo.Store
+	if m.Config.TopoFile != "" {
+		log.Infof("Using topology file %s", m.Config.TopoFile)
+		topoStore, err = topo.NewFileStore(m.Config.TopoFile)
+	} else {
+		topoStore, err = topo.NewStore(m.Config.TopoAddress, opts...)
 	}
-
-	// Create the proposals store
-	proposals, err := proposal.NewAtomixStore(atomixClient)
 	if err != nil {
 		return err
 	}
 
-	// Create the configurations store
-	configurations, err := configuration.NewAtomixStore(atomixClient)
+	// Create the transactions, proposals, configurations and configuration statuses stores
+	transactions, proposals, configurations, statuses, err := m.newStores()
 	if err != nil {
 		return err
 	}
@@ -200,14 +272,26 @@ func (m *Manager) Start() error {
 	// Create new plugin registry
 	m.pluginRegistry = pluginregistry.NewPluginRegistry(m.Config.Plugins...)
 	m.pluginRegistry.Start()
+	if m.Config.AllowUnvalidatedConfig {
+		log.Warn("Configuration of targets without a model plugin is allowed and will not be validated")
+		m.pluginRegistry = pluginregistry.NewUnvalidatedRegistry(m.pluginRegistry)
+	}
 
 	conns := sb.NewConnManager()
+	conns.RegisterDialer(topo.SouthboundProtocolNETCONF, netconf.NewDialer(m.pluginRegistry))
+	if m.Config.DialOutPort != 0 {
+		err = m.startDialOutListener(conns)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = m.startNodeController(topoStore)
 	if err != nil {
 		return err
 	}
 
-	err = m.startConnController(topoStore, conns)
+	err = m.startConnController(topoStore, conns, m.pluginRegistry)
 	if err != nil {
 		return err
 	}
@@ -217,7 +301,13 @@ func (m *Manager) Start() error {
 		return err
 	}
 
-	err = m.startConfigurationController(topoStore, conns, configurations)
+	cache := telemetry.NewCache()
+	err = m.startTelemetryController(topoStore, conns, cache)
+	if err != nil {
+		return err
+	}
+
+	err = m.startConfigurationController(topoStore, conns, configurations, statuses, transactions, m.pluginRegistry)
 	if err != nil {
 		return err
 	}
@@ -237,11 +327,16 @@ func (m *Manager) Start() error {
 		return err
 	}
 
-	err = m.startNorthboundServer(topoStore, transactions, proposals, configurations, m.pluginRegistry, conns)
+	err = m.startNorthboundServer(topoStore, transactions, proposals, configurations, statuses, m.pluginRegistry, conns, cache)
 	if err != nil {
 		return err
 	}
 
+	if m.Config.BootstrapPath != "" {
+		gnmi := gnminb.NewService(topoStore, transactions, proposals, configurations, m.pluginRegistry, conns, cache)
+		go m.bootstrap(ctx, gnmi, transactions)
+	}
+
 	log.Info("Manager started")
 	return nil
 }
@@ -249,5 +344,13 @@ func (m *Manager) Start() error {
 // Close kills the manager
 func (m *Manager) Close() {
 	log.Info("Closing Manager")
+	if m.cancel != nil {
+		m.cancel()
+	}
 	m.pluginRegistry.Stop()
+	if m.db != nil {
+		if err := m.db.Close(); err != nil {
+			log.Warn(err)
+		}
+	}
 }
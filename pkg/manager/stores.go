@@ -0,0 +1,102 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"os"
+	"time"
+
+	"github.com/atomix/atomix-go-client/pkg/atomix"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/proposal"
+	"github.com/onosproject/onos-config/pkg/store/transaction"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// StoreBackend is the backend used for the transaction, proposal, configuration and configuration status stores
+type StoreBackend string
+
+const (
+	// StoreBackendAtomix stores state in Atomix and is shared by all onos-config nodes
+	StoreBackendAtomix StoreBackend = "atomix"
+	// StoreBackendMemory keeps state in memory; it is lost when onos-config is stopped
+	StoreBackendMemory StoreBackend = "memory"
+	// StoreBackendEmbedded persists state in an embedded database on the local disk for single-node deployments
+	StoreBackendEmbedded StoreBackend = "embedded"
+)
+
+// defaultStorePath is the default path of the embedded store database
+const defaultStorePath = "onos-config.db"
+
+// openTimeout is how long to wait for the lock on the embedded store database
+const openTimeout = 10 * time.Second
+
+// newStores creates the transaction, proposal, configuration and configuration status stores for the configured backend
+func (m *Manager) newStores() (transaction.Store, proposal.Store, configuration.Store, configuration.StatusStore, error) {
+	switch m.Config.StoreBackend {
+	case "", StoreBackendAtomix:
+		atomixClient := atomix.NewClient(atomix.WithClientID(os.Getenv("POD_NAME")))
+		transactions, err := transaction.NewAtomixStore(atomixClient)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		proposals, err := proposal.NewAtomixStore(atomixClient)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		configurations, err := configuration.NewAtomixStore(atomixClient)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		statuses, err := configuration.NewAtomixStatusStore(atomixClient)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return transactions, proposals, configurations, statuses, nil
+	case StoreBackendMemory:
+		return transaction.NewMemoryStore(), proposal.NewMemoryStore(), configuration.NewMemoryStore(), configuration.NewMemoryStatusStore(), nil
+	case StoreBackendEmbedded:
+		path := m.Config.StorePath
+		if path == "" {
+			path = defaultStorePath
+		}
+		log.Infof("Opening embedded store %s", path)
+		db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: openTimeout})
+		if err != nil {
+			return nil, nil, nil, nil, errors.NewUnavailable("failed to open embedded store %s: %v", path, err)
+		}
+		m.db = db
+		transactions, err := transaction.NewBoltStore(db)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		proposals, err := proposal.NewBoltStore(db)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		configurations, err := configuration.NewBoltStore(db)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		statuses, err := configuration.NewBoltStatusStore(db)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return transactions, proposals, configurations, statuses, nil
+	default:
+		return nil, nil, nil, nil, errors.NewInvalid("unknown store backend '%s'", m.Config.StoreBackend)
+	}
+}
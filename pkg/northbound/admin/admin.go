@@ -21,8 +21,11 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"github.com/onosproject/onos-api/go/onos/config/admin"
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	adminapi "github.com/onosproject/onos-config/api/admin"
 	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	sb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
 	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
 	"github.com/onosproject/onos-config/pkg/store/transaction"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
@@ -38,15 +41,21 @@ type Service struct {
 	northbound.Service
 	transactionsStore   transaction.Store
 	configurationsStore configuration.Store
+	statusesStore       configuration.StatusStore
 	pluginRegistry      pluginregistry.PluginRegistry
+	topo                topo.Store
+	conns               sb.ConnManager
 }
 
 // NewService allocates a Service struct with the given parameters
-func NewService(transactionsStore transaction.Store, configurationsStore configuration.Store, pluginRegistry pluginregistry.PluginRegistry) Service {
+func NewService(transactionsStore transaction.Store, configurationsStore configuration.Store, statusesStore configuration.StatusStore, pluginRegistry pluginregistry.PluginRegistry, topo topo.Store, conns sb.ConnManager) Service {
 	return Service{
 		transactionsStore:   transactionsStore,
 		configurationsStore: configurationsStore,
+		statusesStore:       statusesStore,
 		pluginRegistry:      pluginRegistry,
+		topo:                topo,
+		conns:               conns,
 	}
 }
 
@@ -55,17 +64,25 @@ func (s Service) Register(r *grpc.Server) {
 	server := Server{
 		transactionsStore:   s.transactionsStore,
 		configurationsStore: s.configurationsStore,
-		pluginRegistry:      s.pluginRegistry}
+		statusesStore:       s.statusesStore,
+		pluginRegistry:      s.pluginRegistry,
+		topo:                s.topo,
+		conns:               s.conns}
 	admin.RegisterConfigAdminServiceServer(r, server)
 	admin.RegisterConfigurationServiceServer(r, server)
 	admin.RegisterTransactionServiceServer(r, server)
+	adminapi.RegisterConfigurationStatusServiceServer(r, server)
+	adminapi.RegisterConnectionServiceServer(r, server)
 }
 
 // Server implements the gRPC service for administrative facilities.
 type Server struct {
 	transactionsStore   transaction.Store
 	configurationsStore configuration.Store
+	statusesStore       configuration.StatusStore
 	pluginRegistry      pluginregistry.PluginRegistry
+	topo                topo.Store
+	conns               sb.ConnManager
 }
 
 func logContext(ctx context.Context, name string) {
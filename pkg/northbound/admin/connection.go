@@ -0,0 +1,119 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"sort"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-config/pkg/controller/utils"
+	sb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// ListConnections streams the southbound connection status of each target
+func (s Server) ListConnections(req *adminapi.ListConnectionsRequest, stream adminapi.ConnectionService_ListConnectionsServer) error {
+	log.Infof("Received ListConnections request: %+v", req)
+	var statuses []sb.ConnStatus
+	if req.TargetID != "" {
+		status, err := s.conns.GetStatus(stream.Context(), req.TargetID)
+		if err != nil {
+			log.Warnf("ListConnections %+v failed: %v", req, err)
+			return errors.Status(err).Err()
+		}
+		statuses = []sb.ConnStatus{status}
+	} else {
+		statuses = s.conns.ListStatus(stream.Context())
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].TargetID < statuses[j].TargetID
+		})
+	}
+	for _, status := range statuses {
+		err := stream.Send(&adminapi.ListConnectionsResponse{Connection: s.newConnection(stream.Context(), status)})
+		if err != nil {
+			log.Warnf("ListConnections %+v failed: %v", req, err)
+			return errors.Status(err).Err()
+		}
+	}
+	return nil
+}
+
+// WatchConnections streams changes to the southbound connection status of targets
+func (s Server) WatchConnections(req *adminapi.WatchConnectionsRequest, stream adminapi.ConnectionService_WatchConnectionsServer) error {
+	log.Infof("Received WatchConnections request: %+v", req)
+	ch := make(chan sb.ConnStatus)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	if err := s.conns.WatchStatus(ctx, ch); err != nil {
+		log.Warnf("WatchConnections %+v failed: %v", req, err)
+		return errors.Status(err).Err()
+	}
+
+	for {
+		select {
+		case status, ok := <-ch:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				// The watch was removed by the connection manager because the stream could not keep up
+				err := errors.NewUnavailable("connection status watch closed: too many pending changes")
+				log.Warnf("WatchConnections %+v failed: %v", req, err)
+				return errors.Status(err).Err()
+			}
+			if req.TargetID != "" && status.TargetID != req.TargetID {
+				continue
+			}
+			err := stream.Send(&adminapi.WatchConnectionsResponse{Connection: s.newConnection(ctx, status)})
+			if err != nil {
+				log.Warnf("WatchConnections %+v failed: %v", req, err)
+				return errors.Status(err).Err()
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// newConnection adds the onos-config node holding the master relation for the target to the connection status
+func (s Server) newConnection(ctx context.Context, status sb.ConnStatus) *adminapi.Connection {
+	connection := &adminapi.Connection{
+		TargetID:      status.TargetID,
+		ConnID:        string(status.ConnID),
+		State:         status.State,
+		DialOut:       status.DialOut,
+		Connected:     status.Connected,
+		LastError:     status.LastError,
+		LastErrorTime: status.LastErrorTime,
+		Reconnects:    uint64(status.Reconnects),
+		Node:          string(utils.GetOnosConfigID()),
+	}
+	target, err := s.topo.Get(ctx, status.TargetID)
+	if err != nil {
+		return connection
+	}
+	mastership := &topoapi.MastershipState{}
+	if err := target.GetAspect(mastership); err != nil || mastership.NodeId == "" {
+		return connection
+	}
+	relation, err := s.topo.Get(ctx, topoapi.ID(mastership.NodeId))
+	if err != nil || relation.GetRelation() == nil {
+		return connection
+	}
+	connection.Master = string(relation.GetRelation().SrcEntityID)
+	return connection
+}
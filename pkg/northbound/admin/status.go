@@ -0,0 +1,55 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"sort"
+
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// GetConfigurationStatus returns response with the status of the requested configuration
+func (s Server) GetConfigurationStatus(ctx context.Context, req *adminapi.GetConfigurationStatusRequest) (*adminapi.GetConfigurationStatusResponse, error) {
+	log.Infof("Received GetConfigurationStatus request: %+v", req)
+	status, err := s.statusesStore.Get(ctx, req.ConfigurationID)
+	if err != nil {
+		log.Warnf("GetConfigurationStatus %+v failed: %v", req, err)
+		return nil, errors.Status(err).Err()
+	}
+	return &adminapi.GetConfigurationStatusResponse{Status: status}, nil
+}
+
+// ListConfigurationStatuses provides stream listing the status of all configurations
+func (s Server) ListConfigurationStatuses(req *adminapi.ListConfigurationStatusesRequest, stream adminapi.ConfigurationStatusService_ListConfigurationStatusesServer) error {
+	log.Infof("Received ListConfigurationStatuses request: %+v", req)
+	statuses, err := s.statusesStore.List(stream.Context())
+	if err != nil {
+		log.Warnf("ListConfigurationStatuses %+v failed: %v", req, err)
+		return errors.Status(err).Err()
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	for _, status := range statuses {
+		err := stream.Send(&adminapi.ListConfigurationStatusesResponse{Status: status})
+		if err != nil {
+			log.Warnf("ListConfigurationStatuses %+v failed: %v", req, err)
+			return errors.Status(err).Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testListStatusesServer struct {
+	grpc.ServerStream
+	responses []*adminapi.ListConfigurationStatusesResponse
+}
+
+func (s *testListStatusesServer) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testListStatusesServer) Send(response *adminapi.ListConfigurationStatusesResponse) error {
+	s.responses = append(s.responses, response)
+	return nil
+}
+
+func newTestStatusServer(t *testing.T, targets ...string) Server {
+	statuses := configuration.NewMemoryStatusStore()
+	for _, target := range targets {
+		assert.NoError(t, statuses.Update(context.Background(), &adminapi.ConfigurationStatus{
+			ID:       configuration.NewID(configapi.TargetID(target)),
+			TargetID: configapi.TargetID(target),
+		}))
+	}
+	return Server{statusesStore: statuses}
+}
+
+func TestGetConfigurationStatus(t *testing.T) {
+	server := newTestStatusServer(t, "target-1")
+
+	response, err := server.GetConfigurationStatus(context.Background(), &adminapi.GetConfigurationStatusRequest{
+		ConfigurationID: configuration.NewID("target-1"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.TargetID("target-1"), response.Status.TargetID)
+
+	_, err = server.GetConfigurationStatus(context.Background(), &adminapi.GetConfigurationStatusRequest{
+		ConfigurationID: configuration.NewID("target-2"),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListConfigurationStatuses(t *testing.T) {
+	server := newTestStatusServer(t, "target-2", "target-1", "target-3")
+
+	stream := &testListStatusesServer{}
+	assert.NoError(t, server.ListConfigurationStatuses(&adminapi.ListConfigurationStatusesRequest{}, stream))
+	assert.Len(t, stream.responses, 3)
+	for i, target := range []configapi.TargetID{"target-1", "target-2", "target-3"} {
+		assert.Equal(t, target, stream.responses[i].Status.TargetID)
+	}
+}
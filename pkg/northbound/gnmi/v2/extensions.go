@@ -15,13 +15,40 @@
 package gnmi
 
 import (
+	"encoding/json"
+
 	"github.com/gogo/protobuf/proto"
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/config"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi_ext"
 )
 
+// TelemetryCacheExtensionID is the ID of the extension describing the freshness of state served from the telemetry cache.
+// The extension message is a JSON object mapping each target ID to its telemetry.CacheInfo.
+const TelemetryCacheExtensionID configapi.ExtensionID = 112
+
+// ValueSourceExtensionID is the ID of the extension describing the source of each value in the response to a Get of type ALL.
+// The extension message is a JSON object mapping each target ID to an object mapping each path to its ValueSource.
+const ValueSourceExtensionID configapi.ExtensionID = 113
+
+// StateUnavailableExtensionID is the ID of the extension listing the targets whose state could not be read for a Get
+// of type ALL, in which case only their configuration is returned.
+// The extension message is a JSON object mapping each of these target IDs to the error reading its state.
+const StateUnavailableExtensionID configapi.ExtensionID = 114
+
+// ValueSource is the source of a value in the response to a Get of type ALL
+type ValueSource string
+
+const (
+	// ValueSourceConfig indicates the value is from the configuration of the target in onos-config
+	ValueSourceConfig ValueSource = "config"
+	// ValueSourceState indicates the value is from the state of the target
+	ValueSourceState ValueSource = "state"
+)
+
 // extractExtension extract the value of an extension from a list given an extension ID
 // if extType is passed we assume the content of the extension is a proto that needs to be Unmarshalled,
 // if extType is nil we return the value as is
@@ -43,14 +70,17 @@ func extractExtension(ext []*gnmi_ext.Extension, extID configapi.ExtensionID, ex
 	return extType, nil
 }
 
+// getTransactionStrategy returns the strategy requested by the transaction strategy extension,
+// or the configured default strategy if the request does not carry the extension
 func getTransactionStrategy(request interface{}) (configapi.TransactionStrategy, error) {
 	var err error
 	var s interface{}
+	defaultStrategy := config.GetSettings().TransactionStrategy
 	switch req := request.(type) {
 	case *gnmi.SetRequest:
-		s, err = extractExtension(req.GetExtension(), configapi.TransactionStrategyExtensionID, &configapi.TransactionStrategy{})
+		s, err = extractExtension(req.GetExtension(), configapi.TransactionStrategyExtensionID, &defaultStrategy)
 	case *gnmi.GetRequest:
-		s, err = extractExtension(req.GetExtension(), configapi.TransactionStrategyExtensionID, &configapi.TransactionStrategy{})
+		s, err = extractExtension(req.GetExtension(), configapi.TransactionStrategyExtensionID, &defaultStrategy)
 	default:
 		return configapi.TransactionStrategy{}, errors.NewInvalid("invalid-request-type")
 	}
@@ -63,3 +93,67 @@ func getTransactionStrategy(request interface{}) (configapi.TransactionStrategy,
 	}
 	return *strategy, nil
 }
+
+// newValueSourceExtension creates an extension describing the source of each value returned for each target
+func newValueSourceExtension(targets map[configapi.TargetID]*targetInfo) (*gnmi_ext.Extension, error) {
+	sources := make(map[configapi.TargetID]map[string]ValueSource)
+	for targetID, target := range targets {
+		if target.sources != nil {
+			sources[targetID] = target.sources
+		}
+	}
+	bytes, err := json.Marshal(sources)
+	if err != nil {
+		return nil, errors.NewInternal(err.Error())
+	}
+	return &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_RegisteredExt{
+			RegisteredExt: &gnmi_ext.RegisteredExtension{
+				Id:  ValueSourceExtensionID,
+				Msg: bytes,
+			},
+		},
+	}, nil
+}
+
+// newStateUnavailableExtension creates an extension describing the targets whose state could not be read,
+// or nil if the state of all targets was read
+func newStateUnavailableExtension(targets map[configapi.TargetID]*targetInfo) (*gnmi_ext.Extension, error) {
+	stateErrs := make(map[configapi.TargetID]string)
+	for targetID, target := range targets {
+		if target.stateErr != nil {
+			stateErrs[targetID] = target.stateErr.Error()
+		}
+	}
+	if len(stateErrs) == 0 {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(stateErrs)
+	if err != nil {
+		return nil, errors.NewInternal(err.Error())
+	}
+	return &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_RegisteredExt{
+			RegisteredExt: &gnmi_ext.RegisteredExtension{
+				Id:  StateUnavailableExtensionID,
+				Msg: bytes,
+			},
+		},
+	}, nil
+}
+
+// newTelemetryCacheExtension creates an extension describing the freshness of the cached state of each target
+func newTelemetryCacheExtension(cacheInfo map[configapi.TargetID]*telemetry.CacheInfo) (*gnmi_ext.Extension, error) {
+	bytes, err := json.Marshal(cacheInfo)
+	if err != nil {
+		return nil, errors.NewInternal(err.Error())
+	}
+	return &gnmi_ext.Extension{
+		Ext: &gnmi_ext.Extension_RegisteredExt{
+			RegisteredExt: &gnmi_ext.RegisteredExtension{
+				Id:  TelemetryCacheExtensionID,
+				Msg: bytes,
+			},
+		},
+	}, nil
+}
@@ -17,6 +17,7 @@ package gnmi
 import (
 	"github.com/gogo/protobuf/proto"
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/config"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnmi/proto/gnmi_ext"
 	"gotest.tools/assert"
@@ -81,6 +82,27 @@ func TestGetTransactionStategy(t *testing.T) {
 	}
 }
 
+func TestGetDefaultTransactionStrategy(t *testing.T) {
+	settings := config.GetSettings()
+	defer config.SetSettings(settings)
+	config.SetSettings(config.Settings{
+		TransactionStrategy: configapi.TransactionStrategy{
+			Synchronicity: configapi.TransactionStrategy_SYNCHRONOUS,
+			Isolation:     configapi.TransactionStrategy_SERIALIZABLE,
+		},
+	})
+
+	res, err := getTransactionStrategy(&gnmi.SetRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, res.Synchronicity, configapi.TransactionStrategy_SYNCHRONOUS)
+	assert.Equal(t, res.Isolation, configapi.TransactionStrategy_SERIALIZABLE)
+
+	res, err = getTransactionStrategy(&gnmi.SetRequest{Extension: []*gnmi_ext.Extension{createExtension(t, configapi.TransactionStrategy_ASYNCHRONOUS)}})
+	assert.NilError(t, err)
+	assert.Equal(t, res.Synchronicity, configapi.TransactionStrategy_ASYNCHRONOUS)
+	assert.Equal(t, res.Isolation, configapi.TransactionStrategy_DEFAULT)
+}
+
 func TestExtractExtension(t *testing.T) {
 
 	type testArgs struct {
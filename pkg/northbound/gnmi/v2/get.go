@@ -18,7 +18,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/onosproject/onos-config/pkg/config"
 	gnmisb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
 	"github.com/openconfig/gnmi/proto/gnmi_ext"
 	"io/ioutil"
 	"net/http"
@@ -29,8 +31,10 @@ import (
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-config/pkg/store/topo"
 
 	"github.com/onosproject/onos-config/pkg/utils/tree"
+	valuesv2 "github.com/onosproject/onos-config/pkg/utils/values/v2"
 
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
 
@@ -124,6 +128,31 @@ func (s *Server) processRequest(ctx context.Context, req *gnmi.GetRequest, group
 		})
 	}
 
+	// For a request of type ALL, read the state of the requested paths to merge it with the configuration
+	if req.Type == gnmi.GetRequest_ALL {
+		statePaths := make(map[configapi.TargetID][]*gnmi.Path)
+		for _, pathInfo := range paths {
+			statePaths[pathInfo.targetID] = append(statePaths[pathInfo.targetID], &gnmi.Path{Elem: joinElems(prefix.GetElem(), pathInfo.path.GetElem())})
+		}
+		if len(req.GetPath()) == 0 && prefix.GetTarget() != "" {
+			targetID := configapi.TargetID(prefix.Target)
+			if _, ok := targets[targetID]; !ok {
+				if err := s.addTarget(ctx, targetID, targets); err != nil {
+					return nil, errors.NewInvalid(err.Error())
+				}
+			}
+			statePaths[targetID] = []*gnmi.Path{{Elem: prefix.Elem}}
+		}
+		for targetID, targetPaths := range statePaths {
+			// The intended configuration is still returned if the target state cannot be read
+			if err := s.addTargetState(ctx, targets[targetID], targetPaths); err != nil {
+				log.Warnf("Failed reading state of target '%s'", targetID, err)
+				targets[targetID].sources = make(map[string]ValueSource)
+				targets[targetID].stateErr = err
+			}
+		}
+	}
+
 	// if there's only the prefix
 	if len(req.GetPath()) == 0 && prefix != nil {
 		targetID := configapi.TargetID(prefix.Target)
@@ -259,13 +288,49 @@ func (s *Server) processRequest(ctx context.Context, req *gnmi.GetRequest, group
 	response := gnmi.GetResponse{
 		Notification: notifications,
 	}
+	if req.Type == gnmi.GetRequest_ALL {
+		ext, err := newValueSourceExtension(targets)
+		if err != nil {
+			return nil, err
+		}
+		response.Extension = append(response.Extension, ext)
+		ext, err = newStateUnavailableExtension(targets)
+		if err != nil {
+			return nil, err
+		}
+		if ext != nil {
+			response.Extension = append(response.Extension, ext)
+		}
+	}
 	return &response, nil
 }
 
+// addTargetState reads the state of the given paths from the target to merge it with the target configuration
+func (s *Server) addTargetState(ctx context.Context, target *targetInfo, paths []*gnmi.Path) error {
+	stateRequest := &gnmi.GetRequest{
+		Type:     gnmi.GetRequest_STATE,
+		Encoding: gnmi.Encoding_PROTO,
+	}
+	notifications, _, err := s.getState(ctx, target.targetID, nil, paths, stateRequest)
+	if err != nil {
+		return err
+	}
+
+	decodeJSON := s.newJSONDecoder(ctx, target.targetType, target.targetVersion)
+	stateValues, err := valuesv2.GnmiNotificationsToPathValues(notifications, nil, decodeJSON)
+	if err != nil {
+		return err
+	}
+	target.stateValues = append(target.stateValues, stateValues...)
+	target.sources = make(map[string]ValueSource)
+	return nil
+}
+
 func (s *Server) processStateOrOperationalRequest(ctx context.Context, req *gnmi.GetRequest) (*gnmi.GetResponse, error) {
 	prefix := req.GetPrefix()
 	paths := make(map[configapi.TargetID][]*gnmi.Path)
 	notifications := make([]*gnmi.Notification, 0)
+	cacheInfo := make(map[configapi.TargetID]*telemetry.CacheInfo)
 	for _, path := range req.GetPath() {
 		targetID := configapi.TargetID(path.Target)
 		if targetID == "" && prefix != nil {
@@ -285,34 +350,108 @@ func (s *Server) processStateOrOperationalRequest(ctx context.Context, req *gnmi
 	}
 
 	for targetID, paths := range paths {
-		roGetReq := &gnmi.GetRequest{
-			Encoding:  req.Encoding,
-			Type:      req.Type,
-			UseModels: req.UseModels,
-			Extension: req.Extension,
-			Path:      paths,
-		}
-
-		conn, err := s.conns.GetByTarget(ctx, topoapi.ID(targetID))
+		targetNotifications, info, err := s.getState(ctx, targetID, prefix, paths, req)
 		if err != nil {
-			if errors.IsNotFound(err) {
-				return nil, errors.NewUnavailable(err.Error())
-			}
 			return nil, err
 		}
-		resp, err := conn.Get(ctx, roGetReq)
-		if err != nil {
-			return nil, err
+		notifications = append(notifications, targetNotifications...)
+		if info != nil {
+			cacheInfo[targetID] = info
 		}
-		notifications = append(notifications, resp.Notification...)
 	}
 	response := gnmi.GetResponse{
 		Notification: notifications,
 	}
+	if len(cacheInfo) > 0 {
+		ext, err := newTelemetryCacheExtension(cacheInfo)
+		if err != nil {
+			return nil, err
+		}
+		response.Extension = append(response.Extension, ext)
+	}
 	return &response, nil
 
 }
 
+// getState reads the state of the given paths of a target, which are relative to the given prefix, as requested
+// by the given request. The state is served from the telemetry cache if it is synchronized and covers all the
+// paths, in which case the cache info is returned; otherwise it is read from the target.
+func (s *Server) getState(ctx context.Context, targetID configapi.TargetID, prefix *gnmi.Path, paths []*gnmi.Path,
+	req *gnmi.GetRequest) ([]*gnmi.Notification, *telemetry.CacheInfo, error) {
+	// Use the requested encoding if the target supports it; otherwise use the target's southbound encoding
+	// and convert the response to the requested encoding
+	encoding := req.Encoding
+	var decodeJSON valuesv2.JSONDecoder
+	if target, err := s.topo.Get(ctx, topoapi.ID(targetID)); err == nil {
+		encoding, err = topo.GetEncoding(target, req.Encoding)
+		if err != nil {
+			log.Warnf("Failed reading encoding for target '%s'", targetID, err)
+		}
+		configurable := topoapi.Configurable{}
+		_ = target.GetAspect(&configurable)
+		decodeJSON = s.newJSONDecoder(ctx, configapi.TargetType(configurable.Type), configapi.TargetVersion(configurable.Version))
+	}
+
+	// Serve the request from the telemetry cache if the cache is synchronized and covers all requested paths
+	if s.cache != nil {
+		cachePaths := make([]*gnmi.Path, 0, len(paths))
+		for _, path := range paths {
+			cachePaths = append(cachePaths, &gnmi.Path{Elem: joinElems(prefix.GetElem(), path.Elem)})
+		}
+		if s.cache.Covers(topoapi.ID(targetID), cachePaths) {
+			cached, info, err := s.cache.Get(topoapi.ID(targetID), cachePaths)
+			if err == nil {
+				cached, err = convertNotifications(cached, req.Encoding, decodeJSON)
+				if err != nil {
+					return nil, nil, err
+				}
+				return cached, info, nil
+			}
+			log.Debugf("Falling back to target '%s' for state request", targetID, err)
+		}
+	}
+
+	roGetReq := &gnmi.GetRequest{
+		Encoding:  encoding,
+		Type:      req.Type,
+		UseModels: req.UseModels,
+		Extension: req.Extension,
+		Path:      paths,
+	}
+
+	conn, err := s.conns.GetByTarget(ctx, topoapi.ID(targetID))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, errors.NewUnavailable(err.Error())
+		}
+		return nil, nil, err
+	}
+	resp, err := conn.Get(ctx, roGetReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	if encoding == req.Encoding {
+		return resp.Notification, nil, nil
+	}
+	notifications, err := convertNotifications(resp.Notification, req.Encoding, decodeJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+	return notifications, nil, nil
+}
+
+// newJSONDecoder returns a decoder for JSON values using the model plugin for the given target type and version,
+// or nil if no plugin is registered for it
+func (s *Server) newJSONDecoder(ctx context.Context, targetType configapi.TargetType, targetVersion configapi.TargetVersion) valuesv2.JSONDecoder {
+	plugin, ok := s.pluginRegistry.GetPlugin(targetType, targetVersion)
+	if !ok {
+		return nil
+	}
+	return func(pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error) {
+		return plugin.GetPathValues(ctx, pathPrefix, jsonData)
+	}
+}
+
 func (s *Server) addTarget(ctx context.Context, targetID configapi.TargetID, targets map[configapi.TargetID]*targetInfo) error {
 	configurable, err := s.getTargetConfigurable(ctx, topoapi.ID(targetID))
 	if err != nil {
@@ -358,13 +497,20 @@ func (s *Server) getUpdate(ctx context.Context, targetInfo *targetInfo, prefix *
 	}
 
 	var configValuesAllowed []*configapi.PathValue
+	stateValuesAllowed := targetInfo.stateValues
 	var err error
-	// Filter config values using open policy agent
+	// Filter config and state values using open policy agent
 	if len(os.Getenv(OIDCServerURL)) > 0 {
 		configValuesAllowed, err = s.checkOpaAllowed(ctx, targetInfo, configValues, groups)
 		if err != nil {
 			return nil, err
 		}
+		if len(stateValuesAllowed) > 0 {
+			stateValuesAllowed, err = s.checkOpaAllowed(ctx, targetInfo, stateValuesAllowed, groups)
+			if err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		configValuesAllowed = make([]*configapi.PathValue, len(configValues))
 		copy(configValuesAllowed, configValues)
@@ -378,6 +524,11 @@ func (s *Server) getUpdate(ctx context.Context, targetInfo *targetInfo, prefix *
 		}
 	}
 
+	// Merge the target state for a request of type ALL
+	if targetInfo.sources != nil {
+		filteredValues = mergeStateValues(filteredValues, stateValuesAllowed, pathRegexp, targetInfo.sources)
+	}
+
 	return createUpdate(prefix, pathInfo.path, filteredValues, encoding)
 }
 
@@ -395,7 +546,7 @@ func (s *Server) checkOpaAllowed(ctx context.Context, targetInfo *targetInfo, co
 
 	client := &http.Client{}
 	// POST to OPA sidecar
-	opaURL := fmt.Sprintf("http://localhost:%d/v1/data/%s_%s/allowed?pretty=%v&metrics=%v", 8181,
+	opaURL := fmt.Sprintf("%s/v1/data/%s_%s/allowed?pretty=%v&metrics=%v", config.GetSettings().OPAURL,
 		targetType, targetVersion, false, true)
 
 	log.Debugf("OPA URL is %s", opaURL)
@@ -16,10 +16,17 @@ package gnmi
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/onosproject/onos-config/pkg/config"
 	configurationcontroller "github.com/onosproject/onos-config/pkg/controller/configuration"
 	proposalcontroller "github.com/onosproject/onos-config/pkg/controller/proposal"
 	transactioncontroller "github.com/onosproject/onos-config/pkg/controller/transaction"
 	sb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
 	"github.com/onosproject/onos-config/pkg/store/proposal"
 	"github.com/onosproject/onos-lib-go/pkg/controller"
 	"sync"
@@ -63,6 +70,7 @@ func createServer(t *testing.T) *testContext {
 	registryMock := gnmitest.NewMockPluginRegistry(mctl)
 	topoMock := gnmitest.NewMockStore(mctl)
 	atomixTest, cfgStore, propStore, txStore := testStores(t)
+	conns := sb.NewConnManager()
 
 	return &testContext{
 		mctl:          mctl,
@@ -72,6 +80,7 @@ func createServer(t *testing.T) *testContext {
 		configuration: cfgStore,
 		proposal:      propStore,
 		transaction:   txStore,
+		conns:         conns,
 		server: &Server{
 			mu:             sync.RWMutex{},
 			pluginRegistry: registryMock,
@@ -79,16 +88,16 @@ func createServer(t *testing.T) *testContext {
 			transactions:   txStore,
 			proposals:      propStore,
 			configurations: cfgStore,
+			conns:          conns,
 		},
 	}
 }
 
 func (test *testContext) startControllers(t *testing.T) {
-	test.conns = sb.NewConnManager()
-	test.configurationController = configurationcontroller.NewController(test.topo, test.conns, test.server.configurations)
+	test.configurationController = configurationcontroller.NewController(test.topo, test.conns, test.server.configurations, configuration.NewMemoryStatusStore(), test.server.transactions, test.server.pluginRegistry)
 	assert.NoError(t, test.configurationController.Start())
 
-	test.proposalController = proposalcontroller.NewController(test.topo, test.conns, test.server.proposals, test.server.configurations, test.registry)
+	test.proposalController = proposalcontroller.NewController(test.topo, test.conns, test.server.proposals, test.server.configurations, test.server.pluginRegistry)
 	assert.NoError(t, test.proposalController.Start())
 
 	test.transactionController = transactioncontroller.NewController(test.server.transactions, test.server.proposals)
@@ -285,3 +294,190 @@ func Test_GetWithPrefixOnly(t *testing.T) {
 	assert.Equal(t, "{\n  \"foo\": \"Hello world!\"\n}",
 		string(result.Notification[0].Update[0].GetVal().GetJsonVal()))
 }
+
+func Test_GetAllMergesState(t *testing.T) {
+	test := createServer(t)
+	defer test.atomix.Stop()
+	defer test.mctl.Finish()
+
+	setupTopoAndRegistry(test, "target-1", "devicesim", "1.0.0", false)
+
+	targetID := configapi.TargetID("target-1")
+	targetConfig := &configapi.Configuration{
+		ID:       configapi.ConfigurationID(targetID),
+		TargetID: targetID,
+		Values: map[string]*configapi.PathValue{
+			"/some/nested/path": {
+				Path:  "/some/nested/path",
+				Value: *configapi.NewTypedValueString("config"),
+			},
+			"/foo": {
+				Path:  "/foo",
+				Value: *configapi.NewTypedValueString("Hello world!"),
+			},
+		},
+	}
+	assert.NoError(t, test.server.configurations.Create(context.TODO(), targetConfig))
+
+	cache := telemetry.NewCache()
+	generation := cache.Open(topoapi.ID(targetID), []*gnmi.Path{targetPath(t, "", "some")}, 0)
+	cache.Update(topoapi.ID(targetID), generation, &gnmi.Notification{
+		Update: []*gnmi.Update{
+			{
+				Path: targetPath(t, "", "some", "nested", "path"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: "state"}},
+			},
+			{
+				Path: targetPath(t, "", "some", "nested", "counter"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_UintVal{UintVal: 42}},
+			},
+			{
+				Path: targetPath(t, "", "some", "status"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: "UP"}},
+			},
+		},
+	})
+	cache.Sync(topoapi.ID(targetID), generation)
+	test.server.cache = cache
+
+	request := gnmi.GetRequest{
+		Path:     []*gnmi.Path{targetPath(t, targetID, "some")},
+		Type:     gnmi.GetRequest_ALL,
+		Encoding: gnmi.Encoding_JSON,
+	}
+	result, err := test.server.Get(context.TODO(), &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Notification, 1)
+	assert.Len(t, result.Notification[0].Update, 1)
+	assert.JSONEq(t, `{"some": {"nested": {"path": "config", "counter": 42}, "status": "UP"}}`,
+		string(result.Notification[0].Update[0].GetVal().GetJsonVal()))
+
+	assert.Len(t, result.Extension, 1)
+	assert.Equal(t, ValueSourceExtensionID, result.Extension[0].GetRegisteredExt().GetId())
+	sources := make(map[configapi.TargetID]map[string]ValueSource)
+	assert.NoError(t, json.Unmarshal(result.Extension[0].GetRegisteredExt().GetMsg(), &sources))
+	assert.Equal(t, map[string]ValueSource{
+		"/some/nested/path":    ValueSourceConfig,
+		"/some/nested/counter": ValueSourceState,
+		"/some/status":         ValueSourceState,
+	}, sources[targetID])
+
+	request.Encoding = gnmi.Encoding_PROTO
+	result, err = test.server.Get(context.TODO(), &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Notification, 1)
+	updates := result.Notification[0].Update
+	assert.Len(t, updates, 3)
+	assert.Equal(t, "/some/nested/counter", utils.StrPath(updates[0].Path))
+	assert.Equal(t, uint64(42), updates[0].Val.GetUintVal())
+	assert.Equal(t, "/some/nested/path", utils.StrPath(updates[1].Path))
+	assert.Equal(t, "config", updates[1].Val.GetStringVal())
+	assert.Equal(t, "/some/status", utils.StrPath(updates[2].Path))
+	assert.Equal(t, "UP", updates[2].Val.GetStringVal())
+
+	// Requests of type CONFIG do not include the state
+	request.Type = gnmi.GetRequest_CONFIG
+	result, err = test.server.Get(context.TODO(), &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Notification[0].Update, 1)
+	assert.Equal(t, "config", result.Notification[0].Update[0].Val.GetStringVal())
+	assert.Len(t, result.Extension, 0)
+}
+
+func Test_GetAllStateUnavailable(t *testing.T) {
+	test := createServer(t)
+	defer test.atomix.Stop()
+	defer test.mctl.Finish()
+
+	setupTopoAndRegistry(test, "target-1", "devicesim", "1.0.0", false)
+
+	targetID := configapi.TargetID("target-1")
+	targetConfig := &configapi.Configuration{
+		ID:       configapi.ConfigurationID(targetID),
+		TargetID: targetID,
+		Values: map[string]*configapi.PathValue{
+			"/some/nested/path": {
+				Path:  "/some/nested/path",
+				Value: *configapi.NewTypedValueString("config"),
+			},
+		},
+	}
+	assert.NoError(t, test.server.configurations.Create(context.TODO(), targetConfig))
+
+	// The target is not connected, so only its configuration is returned
+	request := gnmi.GetRequest{
+		Path:     []*gnmi.Path{targetPath(t, targetID, "some")},
+		Type:     gnmi.GetRequest_ALL,
+		Encoding: gnmi.Encoding_PROTO,
+	}
+	result, err := test.server.Get(context.TODO(), &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Notification, 1)
+	assert.Len(t, result.Notification[0].Update, 1)
+	assert.Equal(t, "config", result.Notification[0].Update[0].Val.GetStringVal())
+
+	assert.Len(t, result.Extension, 2)
+	assert.Equal(t, ValueSourceExtensionID, result.Extension[0].GetRegisteredExt().GetId())
+	assert.Equal(t, StateUnavailableExtensionID, result.Extension[1].GetRegisteredExt().GetId())
+	stateErrs := make(map[configapi.TargetID]string)
+	assert.NoError(t, json.Unmarshal(result.Extension[1].GetRegisteredExt().GetMsg(), &stateErrs))
+	assert.Contains(t, stateErrs, targetID)
+	assert.NotEmpty(t, stateErrs[targetID])
+}
+
+func Test_GetAllFiltersStateWithOpa(t *testing.T) {
+	test := createServer(t)
+	defer test.atomix.Stop()
+	defer test.mctl.Finish()
+
+	setupTopoAndRegistry(test, "target-1", "devicesim", "1.0.0", false)
+
+	// The policy denies access to all values
+	opa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result":[]}`))
+	}))
+	defer opa.Close()
+	settings := config.GetSettings()
+	defer config.SetSettings(settings)
+	opaSettings := settings
+	opaSettings.OPAURL = opa.URL
+	config.SetSettings(opaSettings)
+	assert.NoError(t, os.Setenv(OIDCServerURL, "http://oidc"))
+	defer os.Unsetenv(OIDCServerURL)
+
+	targetID := configapi.TargetID("target-1")
+	targetInfo := &targetInfo{
+		targetID:      targetID,
+		targetType:    "devicesim",
+		targetVersion: "1.0.0",
+		configuration: &configapi.Configuration{
+			ID:       configapi.ConfigurationID(targetID),
+			TargetID: targetID,
+			Values: map[string]*configapi.PathValue{
+				"/some/nested/path": {
+					Path:  "/some/nested/path",
+					Value: *configapi.NewTypedValueString("config"),
+				},
+			},
+		},
+		stateValues: []*configapi.PathValue{
+			{
+				Path:  "/some/status",
+				Value: *configapi.NewTypedValueString("UP"),
+			},
+		},
+		sources: make(map[string]ValueSource),
+	}
+
+	// Neither the configuration nor the state of denied paths is returned
+	path := targetPath(t, targetID, "some")
+	updates, err := test.server.getUpdate(context.TODO(), targetInfo, nil, &pathInfo{
+		targetID:     targetID,
+		path:         path,
+		pathAsString: utils.StrPath(path),
+	}, gnmi.Encoding_PROTO, []string{"users"})
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+	assert.Nil(t, updates[0].Val)
+	assert.Empty(t, targetInfo.sources)
+}
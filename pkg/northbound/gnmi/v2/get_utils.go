@@ -16,6 +16,7 @@ package gnmi
 
 import (
 	"regexp"
+	"sort"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
@@ -88,6 +89,93 @@ func createUpdate(prefix *gnmi.Path, path *gnmi.Path, configValues []*configapi.
 	}
 }
 
+// mergeStateValues merges the state values matching the given path regexp into the configuration values.
+// The configuration value is kept when both contain the same path. The source of each merged value is
+// recorded in the given sources map.
+func mergeStateValues(configValues []*configapi.PathValue, stateValues []*configapi.PathValue, pathRegexp *regexp.Regexp,
+	sources map[string]ValueSource) []*configapi.PathValue {
+	merged := make([]*configapi.PathValue, 0, len(configValues)+len(stateValues))
+	paths := make(map[string]bool)
+	for _, configValue := range configValues {
+		merged = append(merged, configValue)
+		paths[configValue.Path] = true
+		sources[configValue.Path] = ValueSourceConfig
+	}
+	for _, stateValue := range stateValues {
+		if !pathRegexp.MatchString(stateValue.Path) {
+			continue
+		}
+		if paths[stateValue.Path] {
+			log.Debugf("Configuration value overrides state value of %s", stateValue.Path)
+			continue
+		}
+		merged = append(merged, stateValue)
+		paths[stateValue.Path] = true
+		sources[stateValue.Path] = ValueSourceState
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Path < merged[j].Path
+	})
+	return merged
+}
+
+// convertNotifications converts the values of the given notifications read from a target to the given encoding.
+// Scalar values are encoded as a JSON tree if a JSON encoding is requested, and JSON values are decoded with the
+// given decoder if the PROTO encoding is requested. Notifications already in the requested encoding are returned as is.
+func convertNotifications(notifications []*gnmi.Notification, encoding gnmi.Encoding, decodeJSON valuesv2.JSONDecoder) ([]*gnmi.Notification, error) {
+	converted := make([]*gnmi.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		if !needsConversion(notification, encoding) {
+			converted = append(converted, notification)
+			continue
+		}
+		pathValues, err := valuesv2.GnmiNotificationsToPathValues([]*gnmi.Notification{notification}, nil, decodeJSON)
+		if err != nil {
+			return nil, errors.NewNotSupported("cannot convert state of target '%s' to %s: %v",
+				notification.GetPrefix().GetTarget(), encoding, err)
+		}
+		var updates []*gnmi.Update
+		if len(pathValues) > 0 {
+			updates, err = createUpdate(nil, &gnmi.Path{}, pathValues, encoding)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var prefix *gnmi.Path
+		if notification.Prefix != nil {
+			prefix = &gnmi.Path{
+				Target: notification.Prefix.Target,
+				Origin: notification.Prefix.Origin,
+			}
+		}
+		converted = append(converted, &gnmi.Notification{
+			Timestamp: notification.Timestamp,
+			Prefix:    prefix,
+			Update:    updates,
+			Delete:    notification.Delete,
+		})
+	}
+	return converted, nil
+}
+
+// needsConversion returns whether any of the values of the given notification is not in the given encoding
+func needsConversion(notification *gnmi.Notification, encoding gnmi.Encoding) bool {
+	for _, update := range notification.Update {
+		if update.Val == nil {
+			continue
+		}
+		var isJSON bool
+		switch update.Val.Value.(type) {
+		case *gnmi.TypedValue_JsonVal, *gnmi.TypedValue_JsonIetfVal:
+			isJSON = true
+		}
+		if isJSON != (encoding == gnmi.Encoding_JSON || encoding == gnmi.Encoding_JSON_IETF) {
+			return true
+		}
+	}
+	return false
+}
+
 func filterTargetForURL(target string) string {
 	re := regexp.MustCompile(`[.-]`)
 	return re.ReplaceAllString(target, "_")
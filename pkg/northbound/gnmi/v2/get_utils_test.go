@@ -0,0 +1,97 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"encoding/json"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStringUpdate(t *testing.T, path string, value string) *gnmi.Update {
+	gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+	assert.NoError(t, err)
+	return &gnmi.Update{
+		Path: gnmiPath,
+		Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: value}},
+	}
+}
+
+func TestConvertNotifications(t *testing.T) {
+	protoNotification := &gnmi.Notification{
+		Timestamp: 1,
+		Prefix:    &gnmi.Path{Target: "target-1"},
+		Update: []*gnmi.Update{
+			newStringUpdate(t, "/system/state/hostname", "switch-1"),
+			newStringUpdate(t, "/system/state/domain-name", "example.com"),
+		},
+	}
+
+	// Notifications already in the requested encoding are not converted
+	notifications, err := convertNotifications([]*gnmi.Notification{protoNotification}, gnmi.Encoding_PROTO, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*gnmi.Notification{protoNotification}, notifications)
+
+	// Scalar values are converted to a JSON tree
+	notifications, err = convertNotifications([]*gnmi.Notification{protoNotification}, gnmi.Encoding_JSON_IETF, nil)
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, int64(1), notifications[0].Timestamp)
+	assert.Equal(t, "target-1", notifications[0].Prefix.Target)
+	assert.Len(t, notifications[0].Update, 1)
+	jsonTree := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(notifications[0].Update[0].Val.GetJsonVal(), &jsonTree))
+	assert.Equal(t, map[string]interface{}{
+		"system": map[string]interface{}{
+			"state": map[string]interface{}{
+				"hostname":    "switch-1",
+				"domain-name": "example.com",
+			},
+		},
+	}, jsonTree)
+
+	// JSON values are decoded to scalar values with the model plugin
+	jsonNotification := &gnmi.Notification{
+		Prefix: &gnmi.Path{Target: "target-1"},
+		Update: []*gnmi.Update{
+			{
+				Path: &gnmi.Path{},
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"system": {}}`)}},
+			},
+		},
+	}
+	decodeJSON := func(pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error) {
+		return []*configapi.PathValue{
+			{
+				Path:  "/system/state/hostname",
+				Value: *configapi.NewTypedValueString("switch-1"),
+			},
+		}, nil
+	}
+	notifications, err = convertNotifications([]*gnmi.Notification{jsonNotification}, gnmi.Encoding_PROTO, decodeJSON)
+	assert.NoError(t, err)
+	assert.Len(t, notifications[0].Update, 1)
+	assert.Equal(t, "/system/state/hostname", utils.StrPath(notifications[0].Update[0].Path))
+	assert.Equal(t, "switch-1", notifications[0].Update[0].Val.GetStringVal())
+
+	// JSON values cannot be converted without a model plugin
+	_, err = convertNotifications([]*gnmi.Notification{jsonNotification}, gnmi.Encoding_PROTO, nil)
+	assert.True(t, errors.IsNotSupported(err))
+}
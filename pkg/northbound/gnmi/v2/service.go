@@ -26,6 +26,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	protobuf "github.com/golang/protobuf/protoc-gen-go/descriptor"
 
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"github.com/onosproject/onos-config/pkg/store/configuration"
@@ -36,6 +37,7 @@ import (
 	"github.com/onosproject/onos-config/pkg/store/transaction"
 
 	sb "github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
 	"github.com/onosproject/onos-lib-go/pkg/northbound"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"google.golang.org/grpc"
@@ -43,6 +45,9 @@ import (
 
 var log = logging.GetLogger("northbound", "gnmi")
 
+// applyUsername is the user name recorded in transactions applied by onos-config itself
+const applyUsername = "onos-config"
+
 // Service implements Service for GNMI
 type Service struct {
 	northbound.Service
@@ -52,6 +57,7 @@ type Service struct {
 	proposals      proposal.Store
 	configurations configuration.Store
 	conns          sb.ConnManager
+	cache          telemetry.Cache
 }
 
 // NewService allocates a Service struct with the given parameters
@@ -60,7 +66,7 @@ func NewService(
 	transactions transaction.Store,
 	proposals proposal.Store,
 	configurations configuration.Store,
-	pluginRegistry pluginregistry.PluginRegistry, conns sb.ConnManager) Service {
+	pluginRegistry pluginregistry.PluginRegistry, conns sb.ConnManager, cache telemetry.Cache) Service {
 	return Service{
 		pluginRegistry: pluginRegistry,
 		topo:           topo,
@@ -68,20 +74,35 @@ func NewService(
 		proposals:      proposals,
 		configurations: configurations,
 		conns:          conns,
+		cache:          cache,
 	}
 }
 
 // Register registers the GNMI server with grpc
 func (s Service) Register(r *grpc.Server) {
-	gnmi.RegisterGNMIServer(r,
-		&Server{
-			pluginRegistry: s.pluginRegistry,
-			topo:           s.topo,
-			transactions:   s.transactions,
-			proposals:      s.proposals,
-			configurations: s.configurations,
-			conns:          s.conns,
-		})
+	gnmi.RegisterGNMIServer(r, s.newServer())
+}
+
+// Apply applies the given SetRequest on behalf of onos-config as the transaction with the given ID.
+// An AlreadyExists error is returned if a transaction with the same ID has already been created.
+func (s Service) Apply(ctx context.Context, transactionID configapi.TransactionID, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	response, err := s.newServer().set(ctx, req, transactionID, applyUsername)
+	if err != nil {
+		return nil, errors.FromGRPC(err)
+	}
+	return response, nil
+}
+
+func (s Service) newServer() *Server {
+	return &Server{
+		pluginRegistry: s.pluginRegistry,
+		topo:           s.topo,
+		transactions:   s.transactions,
+		proposals:      s.proposals,
+		configurations: s.configurations,
+		conns:          s.conns,
+		cache:          s.cache,
+	}
 }
 
 // Server implements the grpc GNMI service
@@ -93,6 +114,7 @@ type Server struct {
 	proposals      proposal.Store
 	configurations configuration.Store
 	conns          sb.ConnManager
+	cache          telemetry.Cache
 }
 
 // Capabilities implements gNMI Capabilities
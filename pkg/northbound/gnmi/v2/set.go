@@ -56,7 +56,11 @@ func (s *Server) Set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetRespon
 			return nil, errors.Status(errors.NewUnauthorized(err.Error())).Err()
 		}
 	}
+	return s.set(ctx, req, newTransactionID(), userName)
+}
 
+// set applies the given SetRequest as the transaction with the given ID
+func (s *Server) set(ctx context.Context, req *gnmi.SetRequest, transactionID configapi.TransactionID, userName string) (*gnmi.SetResponse, error) {
 	prefixTargetID := configapi.TargetID(req.GetPrefix().GetTarget())
 	targets := make(map[configapi.TargetID]*targetInfo)
 
@@ -111,7 +115,7 @@ func (s *Server) Set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetRespon
 		}
 	}
 
-	transaction, err := newTransaction(targets, transactionStrategy, userName)
+	transaction, err := newTransaction(transactionID, targets, transactionStrategy, userName)
 	if err != nil {
 		log.Warn(err)
 		return nil, errors.Status(err).Err()
@@ -286,7 +290,12 @@ func (s *Server) doUpdateOrReplace(ctx context.Context, prefix *gnmi.Path, u *gn
 	jsonVal := u.GetVal().GetJsonVal()
 	if jsonVal != nil {
 		log.Debugf("Processing Json Value in set from base %s: %s", path, string(jsonVal))
-		pathValues, err := target.plugin.GetPathValues(ctx, prefixPath, jsonVal)
+		// Model plugins resolve the JSON value against their schema; without a schema the value is rooted at the update path
+		jsonPrefix := prefixPath
+		if target.plugin.GetInfo().Unvalidated {
+			jsonPrefix = path
+		}
+		pathValues, err := target.plugin.GetPathValues(ctx, jsonPrefix, jsonVal)
 		if err != nil {
 			return err
 		}
@@ -298,6 +307,13 @@ func (s *Server) doUpdateOrReplace(ctx context.Context, prefix *gnmi.Path, u *gn
 		for _, cv := range pathValues {
 			target.updates[cv.Path] = &cv.Value
 		}
+	} else if target.plugin.GetInfo().Unvalidated {
+		// Without a model the value is stored as given
+		updateValue, err := valueutils.GnmiTypedValueToNativeType(u.Val, nil)
+		if err != nil {
+			return err
+		}
+		target.updates[path] = updateValue
 	} else {
 		_, rwPathElem, err := pathutils.FindPathFromModel(path, target.plugin.GetInfo().ReadWritePaths, true)
 		if err != nil {
@@ -322,6 +338,10 @@ func (s *Server) doDelete(prefix *gnmi.Path, gnmiPath *gnmi.Path, target *target
 	if prefixPath != "/" {
 		path = fmt.Sprintf("%s%s", prefixPath, path)
 	}
+	if target.plugin.GetInfo().Unvalidated {
+		target.removes = append(target.removes, path)
+		return nil
+	}
 	// Checks for read only paths
 	isExactMatch, rwPath, err := pathutils.FindPathFromModel(path, target.plugin.GetInfo().ReadWritePaths, false)
 	if err != nil {
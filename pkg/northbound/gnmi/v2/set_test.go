@@ -17,7 +17,11 @@ package gnmi
 import (
 	"context"
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/store/configuration"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -162,6 +166,88 @@ func Test_NoPlugin(t *testing.T) {
 	assert.Contains(t, err.Error(), "plugin not found")
 }
 
+func Test_UnvalidatedSet(t *testing.T) {
+	test := createServer(t)
+	defer test.atomix.Stop()
+	defer test.mctl.Finish()
+
+	setupTopoAndRegistry(test, "target-1", "devicesim", "1.0.0", true)
+	test.server.pluginRegistry = pluginregistry.NewUnvalidatedRegistry(test.registry)
+	test.topo.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	test.startControllers(t)
+	defer test.stopControllers()
+
+	targetID := configapi.TargetID("target-1")
+	request := gnmi.SetRequest{
+		Update: []*gnmi.Update{
+			{
+				Path: targetPath(t, targetID, "foo"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: "Hello world!"}},
+			},
+			{
+				Path: targetPath(t, targetID, "system"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonVal{JsonVal: []byte(`{"mtu": 9000}`)}},
+			},
+		},
+		Delete: []*gnmi.Path{targetPath(t, targetID, "goo")},
+	}
+
+	result, err := test.server.Set(context.TODO(), &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Extension, 1)
+
+	transactionInfo := &configapi.TransactionInfo{}
+	assert.NoError(t, proto.Unmarshal(result.Extension[0].GetRegisteredExt().GetMsg(), transactionInfo))
+	tx, err := test.transaction.Get(context.TODO(), transactionInfo.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, tx.Status.Phases.Commit)
+	assert.Equal(t, configapi.TransactionCommitPhase_COMMITTED, tx.Status.Phases.Commit.State)
+
+	config, err := test.configuration.Get(context.TODO(), configuration.NewID(targetID))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world!", config.Values["/foo"].Value.ValueToString())
+	assert.Equal(t, "9000", config.Values["/system/mtu"].Value.ValueToString())
+	assert.True(t, config.Values["/goo"].Deleted)
+}
+
+func Test_ApplyWithTransactionID(t *testing.T) {
+	test := createServer(t)
+	defer test.atomix.Stop()
+	defer test.mctl.Finish()
+
+	setupTopoAndRegistry(test, "target-1", "devicesim", "1.0.0", false)
+
+	test.startControllers(t)
+	defer test.stopControllers()
+
+	service := NewService(test.topo, test.transaction, test.proposal, test.configuration, test.server.pluginRegistry, nil, nil)
+	targetID := configapi.TargetID("target-1")
+	request := gnmi.SetRequest{
+		Update: []*gnmi.Update{
+			{
+				Path: targetPath(t, targetID, "foo"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: "Hello world!"}},
+			},
+		},
+	}
+
+	transactionID := configapi.TransactionID("bootstrap:foo.yaml")
+	result, err := service.Apply(context.TODO(), transactionID, &request)
+	assert.NoError(t, err)
+	assert.Len(t, result.Extension, 1)
+
+	transactionInfo := &configapi.TransactionInfo{}
+	assert.NoError(t, proto.Unmarshal(result.Extension[0].GetRegisteredExt().GetMsg(), transactionInfo))
+	assert.Equal(t, transactionID, transactionInfo.ID)
+	tx, err := test.transaction.Get(context.TODO(), transactionID)
+	assert.NoError(t, err)
+	assert.Equal(t, "onos-config", tx.Username)
+
+	_, err = service.Apply(context.TODO(), transactionID, &request)
+	assert.True(t, errors.IsAlreadyExists(err))
+}
+
 func Test_SetDeleteSet(t *testing.T) {
 	test := createServer(t)
 	defer test.atomix.Stop()
@@ -74,14 +74,18 @@ func computeChange(target *targetInfo) (*configapi.PathValues, error) {
 	return changeElement, nil
 }
 
-func newTransaction(targets map[configapi.TargetID]*targetInfo, strategy configapi.TransactionStrategy, username string) (*configapi.Transaction, error) {
+// newTransactionID returns a new unique transaction ID
+func newTransactionID() configapi.TransactionID {
+	return configapi.TransactionID(uri.NewURI(
+		uri.WithScheme("uuid"),
+		uri.WithOpaque(uuid.New().String())).String())
+}
+
+func newTransaction(transactionID configapi.TransactionID, targets map[configapi.TargetID]*targetInfo, strategy configapi.TransactionStrategy, username string) (*configapi.Transaction, error) {
 	values, err := computeChanges(targets)
 	if err != nil {
 		return nil, err
 	}
-	transactionID := configapi.TransactionID(uri.NewURI(
-		uri.WithScheme("uuid"),
-		uri.WithOpaque(uuid.New().String())).String())
 	transaction := &configapi.Transaction{
 		ID: transactionID,
 		Details: &configapi.Transaction_Change{
@@ -15,10 +15,170 @@
 package gnmi
 
 import (
+	"io"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/openconfig/gnmi/proto/gnmi"
 )
 
-// Subscribe implements gNMI Subscribe
+// subscriptionBufferSize is the number of updates buffered for a streaming subscription before it is closed
+const subscriptionBufferSize = 1000
+
+// Subscribe implements gNMI Subscribe. Subscriptions are served from the telemetry cache and
+// are supported only for paths that are cached for the subscribed target.
 func (s *Server) Subscribe(stream gnmi.GNMI_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	subscriptionList := req.GetSubscribe()
+	if subscriptionList == nil {
+		return errors.Status(errors.NewInvalid("first message must be a subscription list")).Err()
+	}
+
+	targetID, paths, err := getSubscriptionPaths(subscriptionList)
+	if err != nil {
+		log.Warn(err)
+		return errors.Status(err).Err()
+	}
+	if s.cache == nil || !s.cache.Covers(targetID, paths) {
+		err = errors.NewNotSupported("subscribed paths are not cached for target '%s'", targetID)
+		log.Warn(err)
+		return errors.Status(err).Err()
+	}
+
+	switch subscriptionList.Mode {
+	case gnmi.SubscriptionList_ONCE:
+		err = s.sendSnapshot(stream, targetID, paths)
+	case gnmi.SubscriptionList_POLL:
+		err = s.poll(stream, targetID, paths)
+	case gnmi.SubscriptionList_STREAM:
+		err = s.stream(stream, targetID, paths, subscriptionList.UpdatesOnly)
+	default:
+		err = errors.NewInvalid("unknown subscription mode %s", subscriptionList.Mode)
+	}
+	if err != nil {
+		log.Warn(err)
+		return errors.Status(err).Err()
+	}
 	return nil
 }
+
+// poll sends a snapshot of the subscribed paths each time the client polls the subscription
+func (s *Server) poll(stream gnmi.GNMI_SubscribeServer, targetID topoapi.ID, paths []*gnmi.Path) error {
+	if err := s.sendSnapshot(stream, targetID, paths); err != nil {
+		return err
+	}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.FromGRPC(err)
+		}
+		if req.GetPoll() == nil {
+			return errors.NewInvalid("only poll requests are accepted on a poll subscription")
+		}
+		if err := s.sendSnapshot(stream, targetID, paths); err != nil {
+			return err
+		}
+	}
+}
+
+// stream sends the updates to the subscribed paths until the client cancels the subscription
+func (s *Server) stream(stream gnmi.GNMI_SubscribeServer, targetID topoapi.ID, paths []*gnmi.Path, updatesOnly bool) error {
+	// Start watching before taking the snapshot to avoid missing updates in between
+	ch := make(chan *gnmi.Notification, subscriptionBufferSize)
+	if err := s.cache.Watch(stream.Context(), targetID, paths, ch); err != nil {
+		return err
+	}
+
+	if updatesOnly {
+		if err := sendSyncResponse(stream); err != nil {
+			return err
+		}
+	} else if err := s.sendSnapshot(stream, targetID, paths); err != nil {
+		return err
+	}
+
+	for notification := range ch {
+		err := stream.Send(&gnmi.SubscribeResponse{
+			Response: &gnmi.SubscribeResponse_Update{
+				Update: notification,
+			},
+		})
+		if err != nil {
+			return errors.FromGRPC(err)
+		}
+	}
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	// The cache closes the watch when the subscription falls behind or the target's cache is closed
+	return errors.NewUnavailable("subscription to target '%s' closed by the telemetry cache", targetID)
+}
+
+// sendSnapshot sends the cached state of the subscribed paths followed by a sync response
+func (s *Server) sendSnapshot(stream gnmi.GNMI_SubscribeServer, targetID topoapi.ID, paths []*gnmi.Path) error {
+	notifications, _, err := s.cache.Get(targetID, paths)
+	if err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		err := stream.Send(&gnmi.SubscribeResponse{
+			Response: &gnmi.SubscribeResponse_Update{
+				Update: notification,
+			},
+		})
+		if err != nil {
+			return errors.FromGRPC(err)
+		}
+	}
+	return sendSyncResponse(stream)
+}
+
+func sendSyncResponse(stream gnmi.GNMI_SubscribeServer) error {
+	err := stream.Send(&gnmi.SubscribeResponse{
+		Response: &gnmi.SubscribeResponse_SyncResponse{
+			SyncResponse: true,
+		},
+	})
+	if err != nil {
+		return errors.FromGRPC(err)
+	}
+	return nil
+}
+
+// getSubscriptionPaths returns the target and the full paths of a subscription list
+func getSubscriptionPaths(subscriptionList *gnmi.SubscriptionList) (topoapi.ID, []*gnmi.Path, error) {
+	prefix := subscriptionList.GetPrefix()
+	targetID := topoapi.ID(prefix.GetTarget())
+	paths := make([]*gnmi.Path, 0, len(subscriptionList.Subscription))
+	for _, subscription := range subscriptionList.Subscription {
+		path := subscription.GetPath()
+		if path.GetTarget() != "" {
+			if targetID != "" && targetID != topoapi.ID(path.GetTarget()) {
+				return "", nil, errors.NewInvalid("subscriptions to multiple targets are not supported")
+			}
+			targetID = topoapi.ID(path.GetTarget())
+		}
+		paths = append(paths, &gnmi.Path{Elem: joinElems(prefix.GetElem(), path.GetElem())})
+	}
+	if targetID == "" {
+		return "", nil, errors.NewInvalid("has no target")
+	}
+	if len(paths) == 0 {
+		return "", nil, errors.NewInvalid("has no subscriptions")
+	}
+	return targetID, paths, nil
+}
+
+// joinElems returns the elements of a path relative to the given prefix elements
+func joinElems(prefix []*gnmi.PathElem, elems []*gnmi.PathElem) []*gnmi.PathElem {
+	joined := make([]*gnmi.PathElem, 0, len(prefix)+len(elems))
+	joined = append(joined, prefix...)
+	return append(joined, elems...)
+}
@@ -0,0 +1,194 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/southbound/telemetry"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const subscribeTarget = "target-1"
+
+// testSubscribeStream is a Subscribe stream fed by a channel of requests and recording its responses
+type testSubscribeStream struct {
+	gnmi.GNMI_SubscribeServer
+	ctx       context.Context
+	requests  chan *gnmi.SubscribeRequest
+	responses chan *gnmi.SubscribeResponse
+}
+
+func newTestSubscribeStream(ctx context.Context, request *gnmi.SubscribeRequest) *testSubscribeStream {
+	stream := &testSubscribeStream{
+		ctx:       ctx,
+		requests:  make(chan *gnmi.SubscribeRequest, 10),
+		responses: make(chan *gnmi.SubscribeResponse, 10),
+	}
+	stream.requests <- request
+	return stream
+}
+
+func (s *testSubscribeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testSubscribeStream) Recv() (*gnmi.SubscribeRequest, error) {
+	select {
+	case request, ok := <-s.requests:
+		if !ok {
+			return nil, io.EOF
+		}
+		return request, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *testSubscribeStream) Send(response *gnmi.SubscribeResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func (s *testSubscribeStream) next(t *testing.T) *gnmi.SubscribeResponse {
+	select {
+	case response := <-s.responses:
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("no subscribe response received")
+		return nil
+	}
+}
+
+func newSubscribeRequest(t *testing.T, mode gnmi.SubscriptionList_Mode, elems ...string) *gnmi.SubscribeRequest {
+	return &gnmi.SubscribeRequest{
+		Request: &gnmi.SubscribeRequest_Subscribe{
+			Subscribe: &gnmi.SubscriptionList{
+				Prefix: &gnmi.Path{Target: subscribeTarget},
+				Subscription: []*gnmi.Subscription{
+					{Path: targetPath(t, "", elems...)},
+				},
+				Mode: mode,
+			},
+		},
+	}
+}
+
+func newSubscribeNotification(t *testing.T, value string) *gnmi.Notification {
+	return &gnmi.Notification{
+		Update: []*gnmi.Update{
+			{
+				Path: targetPath(t, "", "system", "state", "hostname"),
+				Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: value}},
+			},
+		},
+	}
+}
+
+func newSubscribeServer(t *testing.T) (*Server, telemetry.Cache, telemetry.Generation) {
+	cache := telemetry.NewCache()
+	generation := cache.Open(subscribeTarget, []*gnmi.Path{targetPath(t, "", "system")}, 0)
+	cache.Update(subscribeTarget, generation, newSubscribeNotification(t, "switch-1"))
+	cache.Sync(subscribeTarget, generation)
+	return &Server{cache: cache}, cache, generation
+}
+
+func assertUpdate(t *testing.T, response *gnmi.SubscribeResponse, value string) {
+	update := response.GetUpdate()
+	if assert.NotNil(t, update) && assert.Len(t, update.Update, 1) {
+		assert.Equal(t, value, update.Update[0].Val.GetStringVal())
+	}
+}
+
+func assertSync(t *testing.T, response *gnmi.SubscribeResponse) {
+	assert.True(t, response.GetSyncResponse())
+}
+
+func TestSubscribeOnce(t *testing.T) {
+	server, _, _ := newSubscribeServer(t)
+	stream := newTestSubscribeStream(context.Background(), newSubscribeRequest(t, gnmi.SubscriptionList_ONCE, "system", "state"))
+	assert.NoError(t, server.Subscribe(stream))
+	assertUpdate(t, stream.next(t), "switch-1")
+	assertSync(t, stream.next(t))
+}
+
+func TestSubscribePoll(t *testing.T) {
+	server, cache, generation := newSubscribeServer(t)
+	stream := newTestSubscribeStream(context.Background(), newSubscribeRequest(t, gnmi.SubscriptionList_POLL, "system", "state"))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Subscribe(stream)
+	}()
+	assertUpdate(t, stream.next(t), "switch-1")
+	assertSync(t, stream.next(t))
+
+	// Each poll sends the current snapshot
+	cache.Update(subscribeTarget, generation, newSubscribeNotification(t, "switch-2"))
+	stream.requests <- &gnmi.SubscribeRequest{Request: &gnmi.SubscribeRequest_Poll{Poll: &gnmi.Poll{}}}
+	assertUpdate(t, stream.next(t), "switch-2")
+	assertSync(t, stream.next(t))
+
+	close(stream.requests)
+	assert.NoError(t, <-errCh)
+}
+
+func TestSubscribeStream(t *testing.T) {
+	server, cache, generation := newSubscribeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newTestSubscribeStream(ctx, newSubscribeRequest(t, gnmi.SubscriptionList_STREAM, "system", "state"))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Subscribe(stream)
+	}()
+	assertUpdate(t, stream.next(t), "switch-1")
+	assertSync(t, stream.next(t))
+
+	// Updates are streamed until the client cancels the subscription
+	cache.Update(subscribeTarget, generation, newSubscribeNotification(t, "switch-2"))
+	assertUpdate(t, stream.next(t), "switch-2")
+	cancel()
+	assert.NoError(t, <-errCh)
+
+	// The stream fails once the cache of the target is closed
+	stream = newTestSubscribeStream(context.Background(), newSubscribeRequest(t, gnmi.SubscriptionList_STREAM, "system", "state"))
+	go func() {
+		errCh <- server.Subscribe(stream)
+	}()
+	assertUpdate(t, stream.next(t), "switch-2")
+	assertSync(t, stream.next(t))
+	cache.Close(subscribeTarget)
+	assert.Equal(t, codes.Unavailable, status.Code(<-errCh))
+}
+
+func TestSubscribeUncachedPaths(t *testing.T) {
+	server, _, _ := newSubscribeServer(t)
+	stream := newTestSubscribeStream(context.Background(), newSubscribeRequest(t, gnmi.SubscriptionList_ONCE, "interfaces"))
+	assert.Equal(t, codes.Unimplemented, status.Code(server.Subscribe(stream)))
+
+	stream = newTestSubscribeStream(context.Background(), &gnmi.SubscribeRequest{
+		Request: &gnmi.SubscribeRequest_Subscribe{
+			Subscribe: &gnmi.SubscriptionList{
+				Subscription: []*gnmi.Subscription{{Path: targetPath(t, "", "system")}},
+			},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(server.Subscribe(stream)))
+}
@@ -29,6 +29,12 @@ type targetInfo struct {
 	updates       configapi.TypedValueMap
 	removes       []string
 	configuration *configapi.Configuration
+	// stateValues are the state values read from the target for a Get of type ALL
+	stateValues []*configapi.PathValue
+	// sources records the source of each value returned for a Get of type ALL
+	sources map[string]ValueSource
+	// stateErr is the error reading the state from the target for a Get of type ALL
+	stateErr error
 }
 
 type pathInfo struct {
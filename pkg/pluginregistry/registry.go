@@ -83,6 +83,8 @@ type ModelPluginInfo struct {
 	Status modelPluginStatus
 	// Error is an optional field populated only if the plugin failed to be correctly discovered
 	Error string
+	// Unvalidated indicates the plugin has no schema and does not validate configuration
+	Unvalidated bool
 }
 
 // PluginRegistry is a set of available configuration model plugins
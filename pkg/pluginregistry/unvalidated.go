@@ -0,0 +1,215 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	api "github.com/onosproject/onos-api/go/onos/config/admin"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NewUnvalidatedRegistry returns a plugin registry that falls back to an unvalidated plugin for target
+// types and versions without a registered model plugin. Unvalidated plugins accept any configuration,
+// so changes to those targets are stored as raw path/values.
+func NewUnvalidatedRegistry(registry PluginRegistry) PluginRegistry {
+	return &unvalidatedRegistry{
+		PluginRegistry: registry,
+		plugins:        make(map[string]ModelPlugin),
+	}
+}
+
+type unvalidatedRegistry struct {
+	PluginRegistry
+	plugins map[string]ModelPlugin
+	lock    sync.Mutex
+}
+
+// GetPlugin returns the plugin with the specified ID or an unvalidated plugin if none is registered
+func (r *unvalidatedRegistry) GetPlugin(modelType configapi.TargetType, version configapi.TargetVersion) (ModelPlugin, bool) {
+	if plugin, ok := r.PluginRegistry.GetPlugin(modelType, version); ok {
+		return plugin, true
+	}
+	id := strings.ToLower(fmt.Sprintf("%s-%s", modelType, version))
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	plugin, ok := r.plugins[id]
+	if !ok {
+		log.Warnw("Using unvalidated plugin", "id", id)
+		plugin = NewUnvalidatedPlugin(modelType, version)
+		r.plugins[id] = plugin
+	}
+	return plugin, true
+}
+
+// NewUnvalidatedPlugin returns a plugin for the given target type and version that has no schema.
+// The plugin accepts any configuration and decodes JSON values into path/values by their JSON types.
+func NewUnvalidatedPlugin(modelType configapi.TargetType, version configapi.TargetVersion) ModelPlugin {
+	return &unvalidatedPlugin{
+		info: &ModelPluginInfo{
+			ID: strings.ToLower(fmt.Sprintf("%s-%s", modelType, version)),
+			Info: api.ModelInfo{
+				Name:    string(modelType),
+				Version: string(version),
+			},
+			Status:      loaded,
+			Unvalidated: true,
+		},
+	}
+}
+
+type unvalidatedPlugin struct {
+	info *ModelPluginInfo
+}
+
+// GetInfo returns the model plugin info
+func (p *unvalidatedPlugin) GetInfo() *ModelPluginInfo {
+	return p.info
+}
+
+// Capabilities returns capabilities with no supported models
+func (p *unvalidatedPlugin) Capabilities(ctx context.Context) *gnmi.CapabilityResponse {
+	return &gnmi.CapabilityResponse{
+		SupportedEncodings: []gnmi.Encoding{gnmi.Encoding_JSON, gnmi.Encoding_JSON_IETF, gnmi.Encoding_PROTO},
+		GNMIVersion:        "0.7.0",
+	}
+}
+
+// Validate accepts any configuration
+func (p *unvalidatedPlugin) Validate(ctx context.Context, jsonData []byte) error {
+	return nil
+}
+
+// GetPathValues extracts path values from the specified configuration change JSON using the JSON value types.
+// Lists of objects cannot be decoded without a schema since their keys are unknown.
+func (p *unvalidatedPlugin) GetPathValues(ctx context.Context, pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.NewInvalid("failed decoding JSON: %v", err)
+	}
+	pathValues := make([]*configapi.PathValue, 0)
+	if err := appendPathValues(strings.TrimSuffix(pathPrefix, "/"), value, &pathValues); err != nil {
+		return nil, err
+	}
+	return pathValues, nil
+}
+
+// appendPathValues appends the leaves of the given JSON value to the given path values
+func appendPathValues(path string, value interface{}, pathValues *[]*configapi.PathValue) error {
+	if object, ok := value.(map[string]interface{}); ok {
+		for name, child := range object {
+			if err := appendPathValues(fmt.Sprintf("%s/%s", path, name), child, pathValues); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if path == "" {
+		return errors.NewInvalid("no path specified for value %v", value)
+	}
+	typedValue, err := newTypedValue(value)
+	if err != nil {
+		if errors.IsNotSupported(err) {
+			return errors.NewNotSupported("unsupported value for path %s: %v", path, err)
+		}
+		return errors.NewInvalid("invalid value for path %s: %v", path, err)
+	}
+	*pathValues = append(*pathValues, &configapi.PathValue{
+		Path:  path,
+		Value: *typedValue,
+	})
+	return nil
+}
+
+// newTypedValue converts a decoded JSON scalar or list of scalars to a typed value
+func newTypedValue(value interface{}) (*configapi.TypedValue, error) {
+	switch v := value.(type) {
+	case string:
+		return configapi.NewTypedValueString(v), nil
+	case bool:
+		return configapi.NewTypedValueBool(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return configapi.NewTypedValueInt(int(i), configapi.WidthSixtyFour), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return configapi.NewTypedValueFloat(f), nil
+	case []interface{}:
+		return newLeafListTypedValue(v)
+	case nil:
+		return configapi.NewTypedValueEmpty(), nil
+	default:
+		return nil, errors.NewNotSupported("unsupported value type %T", value)
+	}
+}
+
+// newLeafListTypedValue converts a decoded JSON list of scalars of the same type to a leaf-list typed value
+func newLeafListTypedValue(values []interface{}) (*configapi.TypedValue, error) {
+	if len(values) == 0 {
+		return configapi.NewLeafListStringTv([]string{}), nil
+	}
+	switch values[0].(type) {
+	case string:
+		stringValues := make([]string, 0, len(values))
+		for _, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.NewInvalid("leaf-list values must be of the same type")
+			}
+			stringValues = append(stringValues, s)
+		}
+		return configapi.NewLeafListStringTv(stringValues), nil
+	case bool:
+		bools := make([]bool, 0, len(values))
+		for _, value := range values {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, errors.NewInvalid("leaf-list values must be of the same type")
+			}
+			bools = append(bools, b)
+		}
+		return configapi.NewLeafListBoolTv(bools), nil
+	case json.Number:
+		ints := make([]int64, 0, len(values))
+		for _, value := range values {
+			n, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.NewInvalid("leaf-list values must be of the same type")
+			}
+			i, err := n.Int64()
+			if err != nil {
+				return nil, errors.NewNotSupported("leaf-list values must be integers")
+			}
+			ints = append(ints, i)
+		}
+		return configapi.NewLeafListIntTv(ints, configapi.WidthSixtyFour), nil
+	case map[string]interface{}:
+		return nil, errors.NewNotSupported("lists cannot be decoded without a model plugin")
+	default:
+		return nil, errors.NewNotSupported("unsupported leaf-list value type %T", values[0])
+	}
+}
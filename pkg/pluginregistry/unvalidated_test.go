@@ -0,0 +1,76 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginregistry
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnvalidatedRegistry(t *testing.T) {
+	registry := NewPluginRegistry().(*pluginRegistry)
+	registry.plugins["testmodel-1.0.0"] = &ModelPluginInfo{ID: "testmodel-1.0.0"}
+	unvalidated := NewUnvalidatedRegistry(registry)
+
+	plugin, ok := unvalidated.GetPlugin("testmodel", "1.0.0")
+	assert.True(t, ok)
+	assert.False(t, plugin.GetInfo().Unvalidated)
+
+	plugin, ok = unvalidated.GetPlugin("othermodel", "2.0.0")
+	assert.True(t, ok)
+	assert.True(t, plugin.GetInfo().Unvalidated)
+	assert.Equal(t, "othermodel", plugin.GetInfo().Info.Name)
+	assert.Equal(t, "2.0.0", plugin.GetInfo().Info.Version)
+	assert.NoError(t, plugin.Validate(context.TODO(), []byte(`{"any": "thing"}`)))
+	assert.Len(t, unvalidated.GetPlugins(), 1)
+
+	_, ok = registry.GetPlugin("othermodel", "2.0.0")
+	assert.False(t, ok)
+}
+
+func TestUnvalidatedPathValues(t *testing.T) {
+	plugin := NewUnvalidatedPlugin("testmodel", "1.0.0")
+	pathValues, err := plugin.GetPathValues(context.TODO(), "/system/", []byte(`{
+		"name": "switch-1",
+		"enabled": true,
+		"mtu": 9000,
+		"ratio": 0.5,
+		"config": {"servers": ["a", "b"], "ports": [1, 2]}
+	}`))
+	assert.NoError(t, err)
+	values := make(map[string]*configapi.TypedValue)
+	for _, pathValue := range pathValues {
+		value := pathValue.Value
+		values[pathValue.Path] = &value
+	}
+	assert.Len(t, values, 6)
+	assert.Equal(t, configapi.NewTypedValueString("switch-1"), values["/system/name"])
+	assert.Equal(t, configapi.NewTypedValueBool(true), values["/system/enabled"])
+	assert.Equal(t, configapi.NewTypedValueInt(9000, configapi.WidthSixtyFour), values["/system/mtu"])
+	assert.Equal(t, configapi.NewTypedValueFloat(0.5), values["/system/ratio"])
+	assert.Equal(t, configapi.NewLeafListStringTv([]string{"a", "b"}), values["/system/config/servers"])
+	assert.Equal(t, configapi.NewLeafListIntTv([]int64{1, 2}, configapi.WidthSixtyFour), values["/system/config/ports"])
+
+	_, err = plugin.GetPathValues(context.TODO(), "", []byte(`{"interfaces": [{"name": "eth0"}]}`))
+	assert.True(t, errors.IsNotSupported(err))
+	_, err = plugin.GetPathValues(context.TODO(), "", []byte(`{"tags": ["a", 1]}`))
+	assert.True(t, errors.IsInvalid(err))
+	_, err = plugin.GetPathValues(context.TODO(), "", []byte(`"value"`))
+	assert.True(t, errors.IsInvalid(err))
+}
@@ -17,6 +17,7 @@ package gnmi
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
@@ -25,6 +26,8 @@ import (
 	gclient "github.com/openconfig/gnmi/client/gnmi"
 
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Client gNMI client interface
@@ -37,35 +40,64 @@ type Client interface {
 	Set(ctx context.Context, r *gpb.SetRequest) (*gpb.SetResponse, error)
 	SetWithString(ctx context.Context, request string) (*gpb.SetResponse, error)
 	Subscribe(ctx context.Context, q baseClient.Query) error
+	SubscribeStream(ctx context.Context, r *gpb.SubscribeRequest) (gpb.GNMI_SubscribeClient, error)
 }
 
 // client gnmi client
 type client struct {
-	client *gclient.Client
+	client     *gclient.Client
+	gnmiClient gpb.GNMIClient
+	getTimeout time.Duration
+	setTimeout time.Duration
+	tlsCerts   *tlsCertificates
+	onError    func(error)
 }
 
 // Subscribe calls gNMI subscription based on a given query
 func (c *client) Subscribe(ctx context.Context, q baseClient.Query) error {
 	err := c.client.Subscribe(ctx, q)
-	return errors.FromGRPC(err)
+	return c.fromGRPC(err)
+}
+
+// SubscribeStream opens a subscription stream to the target and sends the given request.
+// Unlike Subscribe, the caller receives the responses from the returned stream.
+func (c *client) SubscribeStream(ctx context.Context, req *gpb.SubscribeRequest) (gpb.GNMI_SubscribeClient, error) {
+	stream, err := c.gnmiClient.Subscribe(ctx)
+	if err != nil {
+		return nil, c.fromGRPC(err)
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, c.fromGRPC(err)
+	}
+	return stream, nil
 }
 
 // Capabilities returns the capabilities of the target
 func (c *client) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
 	capResponse, err := c.client.Capabilities(ctx, req)
-	return capResponse, errors.FromGRPC(err)
+	return capResponse, c.fromGRPC(err)
 }
 
 // Get calls gnmi Get RPC
 func (c *client) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	if c.getTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.getTimeout)
+		defer cancel()
+	}
 	getResponse, err := c.client.Get(ctx, req)
-	return getResponse, errors.FromGRPC(err)
+	return getResponse, c.fromGRPC(err)
 }
 
 // Set calls gnmi Set RPC
 func (c *client) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if c.setTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.setTimeout)
+		defer cancel()
+	}
 	setResponse, err := c.client.Set(ctx, req)
-	return setResponse, errors.FromGRPC(err)
+	return setResponse, c.fromGRPC(err)
 }
 
 // CapabilitiesWithString allows a request for the capabilities by a string - can be empty
@@ -76,7 +108,7 @@ func (c *client) CapabilitiesWithString(ctx context.Context, request string) (*g
 		return nil, errors.NewInvalid("unable to unmarshal gnmi.CapabilityRequest from %v : %v", *reqProto, err)
 	}
 	capResponse, err := c.client.Capabilities(ctx, r)
-	return capResponse, errors.FromGRPC(err)
+	return capResponse, c.fromGRPC(err)
 }
 
 // GetWithString can make a get request based on a given a string request - can be empty
@@ -90,7 +122,7 @@ func (c *client) GetWithString(ctx context.Context, request string) (*gpb.GetRes
 		return nil, errors.NewInvalid("unable to unmarshal gnmi getRequest from %v : %v", *reqProto, err)
 	}
 	getResponse, err := c.client.Get(ctx, r)
-	return getResponse, errors.FromGRPC(err)
+	return getResponse, c.fromGRPC(err)
 }
 
 // SetWithString can make a set request based on a given string request
@@ -104,7 +136,18 @@ func (c *client) SetWithString(ctx context.Context, request string) (*gpb.SetRes
 		return nil, errors.NewInvalid("unable to unmarshal gnmi set request from %v: %v", *reqProto, err)
 	}
 	setResponse, err := c.client.Set(ctx, r)
-	return setResponse, errors.FromGRPC(err)
+	return setResponse, c.fromGRPC(err)
+}
+
+// fromGRPC converts the given gRPC error, reporting errors caused by the connection to the error handler
+func (c *client) fromGRPC(err error) error {
+	if err != nil && c.onError != nil {
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Unauthenticated, codes.PermissionDenied:
+			c.onError(err)
+		}
+	}
+	return errors.FromGRPC(err)
 }
 
 // Close closes the gnmi client
@@ -22,15 +22,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/onosproject/onos-lib-go/pkg/uri"
 
-	"google.golang.org/grpc"
-
-	"github.com/onosproject/onos-lib-go/pkg/certs"
-
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 
-	"crypto/tls"
+	"github.com/onosproject/onos-config/pkg/store/topo"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	baseClient "github.com/openconfig/gnmi/client"
@@ -52,19 +48,20 @@ type Conn interface {
 	Client
 	ID() ConnID
 	TargetID() topoapi.ID
+	CertificateStatus() *topo.CertificateStatus
 }
 
-func newDestination(target *topoapi.Object) (*baseClient.Destination, error) {
+func newDestination(target *topoapi.Object) (*baseClient.Destination, *tlsCertificates, error) {
 	configurable := &topoapi.Configurable{}
 	err := target.GetAspect(configurable)
 	if err != nil {
-		return nil, errors.NewInvalid("target entity %s must have 'onos.topo.Configurable' aspect to work with onos-config", target.ID)
+		return nil, nil, errors.NewInvalid("target entity %s must have 'onos.topo.Configurable' aspect to work with onos-config", target.ID)
 	}
 
 	tlsOptions := &topoapi.TLSOptions{}
 	err = target.GetAspect(tlsOptions)
 	if err != nil {
-		return nil, errors.NewInvalid("topo entity %s must have 'onos.topo.TLSOptions' aspect to work with onos-config", target.ID)
+		return nil, nil, errors.NewInvalid("topo entity %s must have 'onos.topo.TLSOptions' aspect to work with onos-config", target.ID)
 	}
 
 	timeout := defaultTimeout
@@ -78,54 +75,34 @@ func newDestination(target *topoapi.Object) (*baseClient.Destination, error) {
 		Timeout: timeout,
 	}
 
+	var tlsCerts *tlsCertificates
 	if tlsOptions.Plain {
 		log.Info("Plain (non TLS) connection to ", configurable.Address)
 	} else {
-		tlsConfig := &tls.Config{}
 		if tlsOptions.Insecure {
 			log.Info("Insecure TLS connection to ", configurable.Address)
-			tlsConfig = &tls.Config{InsecureSkipVerify: true}
 		} else {
 			log.Info("Secure TLS connection to ", configurable.Address)
 		}
-		if tlsOptions.CaCert == "" {
-			log.Info("Loading default CA onfca")
-			defaultCertPool, err := certs.GetCertPoolDefault()
-			if err != nil {
-				return nil, err
-			}
-			tlsConfig.RootCAs = defaultCertPool
-		} else {
-			certPool, err := certs.GetCertPool(tlsOptions.CaCert)
-			if err != nil {
-				return nil, err
-			}
-			tlsConfig.RootCAs = certPool
+		certOptions, err := topo.GetCertificateOptions(target)
+		if err != nil {
+			return nil, nil, err
 		}
-		if tlsOptions.Cert == "" && tlsOptions.Key == "" {
-			log.Info("Loading default certificates")
-			clientCerts, err := tls.X509KeyPair([]byte(certs.DefaultClientCrt), []byte(certs.DefaultClientKey))
-			if err != nil {
-				return nil, err
-			}
-			tlsConfig.Certificates = []tls.Certificate{clientCerts}
-		} else if tlsOptions.Cert != "" && tlsOptions.Key != "" {
-			// Load certs given for device
-			tlsConfig.Certificates = []tls.Certificate{setCertificate(tlsOptions.Cert, tlsOptions.Key)}
-		} else {
-			log.Errorf("Can't load Ca=%s , Cert=%s , key=%s for %v, trying with insecure connection",
+		tlsCerts, err = newTLSCertificates(target.ID, tlsOptions, certOptions)
+		if err != nil {
+			log.Errorf("Can't load Ca=%s , Cert=%s , key=%s for %v",
 				tlsOptions.CaCert, tlsOptions.Cert, tlsOptions.Key, configurable.Address)
-			tlsConfig = &tls.Config{InsecureSkipVerify: true}
+			return nil, nil, err
 		}
-		destination.TLS = tlsConfig
+		destination.TLS = tlsCerts.newTLSConfig(certOptions.ServerName)
 	}
 
 	err = destination.Validate()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return destination, nil
+	return destination, tlsCerts, nil
 }
 
 func newConnID() ConnID {
@@ -135,9 +112,10 @@ func newConnID() ConnID {
 	return connID
 }
 
-func newConn(targetID topoapi.ID, client *client) Conn {
+func newConn(targetID topoapi.ID, client Client, tlsCerts *tlsCertificates) Conn {
 	return &conn{
-		client:   client,
+		Client:   client,
+		tlsCerts: tlsCerts,
 		id:       newConnID(),
 		targetID: targetID,
 	}
@@ -145,10 +123,10 @@ func newConn(targetID topoapi.ID, client *client) Conn {
 
 // conn gNMI Connection
 type conn struct {
-	*client
-	clientConn *grpc.ClientConn
-	id         ConnID
-	targetID   topoapi.ID
+	Client
+	tlsCerts *tlsCertificates
+	id       ConnID
+	targetID topoapi.ID
 }
 
 // ID returns the gNMI connection ID
@@ -161,4 +139,12 @@ func (c *conn) TargetID() topoapi.ID {
 	return c.targetID
 }
 
+// CertificateStatus returns the status of the TLS certificates used by the connection, or nil if TLS is not used
+func (c *conn) CertificateStatus() *topo.CertificateStatus {
+	if c.tlsCerts == nil {
+		return nil
+	}
+	return c.tlsCerts.getStatus()
+}
+
 var _ Conn = &conn{}
@@ -16,14 +16,17 @@ package gnmi
 
 import (
 	"context"
-	"math"
+	"net"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	baseClient "github.com/openconfig/gnmi/client"
 	gclient "github.com/openconfig/gnmi/client/gnmi"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
@@ -36,27 +39,45 @@ type ConnManager interface {
 	Connect(ctx context.Context, target *topoapi.Object) error
 	Disconnect(ctx context.Context, targetID topoapi.ID) error
 	Watch(ctx context.Context, ch chan<- Conn) error
+	ServeDialOut(lis net.Listener) error
+	RegisterDialer(protocol topo.SouthboundProtocol, dialer Dialer)
+	GetStatus(ctx context.Context, targetID topoapi.ID) (ConnStatus, error)
+	ListStatus(ctx context.Context) []ConnStatus
+	WatchStatus(ctx context.Context, ch chan<- ConnStatus) error
 }
 
 // NewConnManager creates a new gNMI connection manager
 func NewConnManager() ConnManager {
 	mgr := &connManager{
-		targets:  make(map[topoapi.ID]*client),
-		conns:    make(map[ConnID]Conn),
-		watchers: make(map[uuid.UUID]chan<- Conn),
-		eventCh:  make(chan Conn),
+		targets:        make(map[topoapi.ID]Client),
+		conns:          make(map[ConnID]Conn),
+		watchers:       make(map[uuid.UUID]chan<- Conn),
+		eventCh:        make(chan Conn),
+		statuses:       make(map[topoapi.ID]*ConnStatus),
+		statusWatchers: make(map[uuid.UUID]*statusWatcher),
+		statusCh:       make(chan ConnStatus),
+		dialers:        make(map[topo.SouthboundProtocol]Dialer),
 	}
 	go mgr.processEvents()
+	go mgr.processStatusEvents()
 	return mgr
 }
 
 type connManager struct {
-	targets    map[topoapi.ID]*client
-	conns      map[ConnID]Conn
-	connsMu    sync.RWMutex
-	watchers   map[uuid.UUID]chan<- Conn
-	watchersMu sync.RWMutex
-	eventCh    chan Conn
+	targets          map[topoapi.ID]Client
+	conns            map[ConnID]Conn
+	connsMu          sync.RWMutex
+	watchers         map[uuid.UUID]chan<- Conn
+	watchersMu       sync.RWMutex
+	eventCh          chan Conn
+	tunnels          dialOutTunnels
+	statuses         map[topoapi.ID]*ConnStatus
+	statusMu         sync.RWMutex
+	statusWatchers   map[uuid.UUID]*statusWatcher
+	statusWatchersMu sync.RWMutex
+	statusCh         chan ConnStatus
+	dialers          map[topo.SouthboundProtocol]Dialer
+	dialersMu        sync.RWMutex
 }
 
 func (m *connManager) GetByTarget(ctx context.Context, targetID topoapi.ID) (Client, error) {
@@ -77,44 +98,102 @@ func (m *connManager) Get(ctx context.Context, connID ConnID) (Conn, bool) {
 
 func (m *connManager) Connect(ctx context.Context, target *topoapi.Object) error {
 	m.connsMu.RLock()
-	gnmiClient, ok := m.targets[target.ID]
+	_, ok := m.targets[target.ID]
 	m.connsMu.RUnlock()
 	if ok {
 		return errors.NewAlreadyExists("target '%s' already exists", target.ID)
 	}
 
+	if target.Type != topoapi.Object_ENTITY {
+		return errors.NewInvalid("object is not a topo entity %v+", target)
+	}
+
+	typeKindID := string(target.GetEntity().KindID)
+	if len(typeKindID) == 0 {
+		return errors.NewInvalid("target entity %s must have a 'kindID' to work with onos-config", target.ID)
+	}
+
+	// Targets that do not speak gNMI are connected with the dialer registered for their protocol
+	southboundOptions, err := topo.GetSouthboundOptions(target)
+	if err != nil {
+		log.Warnf("Failed to read southbound options for target %s: %s", target.ID, err)
+		return err
+	}
+	if southboundOptions.Protocol != topo.SouthboundProtocolGNMI {
+		return m.connectDialer(ctx, target, southboundOptions.Protocol)
+	}
+
 	m.connsMu.Lock()
 	defer m.connsMu.Unlock()
 
-	gnmiClient, ok = m.targets[target.ID]
+	_, ok = m.targets[target.ID]
 	if ok {
 		return errors.NewAlreadyExists("target '%s' already exists", target.ID)
 	}
 
-	if target.Type != topoapi.Object_ENTITY {
-		return errors.NewInvalid("object is not a topo entity %v+", target)
+	destination, tlsCerts, err := newDestination(target)
+	if err != nil {
+		log.Warnf("Failed to create a new target %s", err)
+		return err
 	}
 
-	typeKindID := string(target.GetEntity().KindID)
-	if len(typeKindID) == 0 {
-		return errors.NewInvalid("target entity %s must have a 'kindID' to work with onos-config", target.ID)
+	connOptions, err := topo.GetConnectionOptions(target)
+	if err != nil {
+		log.Warnf("Failed to read connection options for target %s: %s", target.ID, err)
+		return err
+	}
+	opts, err := newDialOptions(connOptions)
+	if err != nil {
+		log.Warnf("Invalid connection options for target %s: %s", target.ID, err)
+		return err
 	}
 
-	destination, err := newDestination(target)
+	// Dial-out targets are reached through the TLS connection they open to the dial-out listener
+	if connOptions.DialOut {
+		destination.TLS = nil
+		tlsCerts = nil
+	}
+
+	// Credentials are passed as dial options rather than in the destination to keep them out of the logs
+	creds, err := newTargetCredentials(target, destination.TLS != nil)
 	if err != nil {
-		log.Warnf("Failed to create a new target %s", err)
+		log.Warnf("Failed to load credentials for target %s: %s", target.ID, err)
 		return err
+	} else if creds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+
+	// Track the status of the connection from the first attempt to connect
+	m.addStatus(target.ID, connectivity.Idle, connOptions.DialOut)
+	onError := func(err error) {
+		m.recordError(target.ID, err)
+	}
+	if tlsCerts != nil {
+		tlsCerts.onError = onError
 	}
 
-	log.Infof("Connecting to gNMI target: %+v", destination)
-	opts := []grpc.DialOption{
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32)),
+	var gnmiClient *client
+	var clientConn *grpc.ClientConn
+	if connOptions.DialOut {
+		log.Infof("Waiting for dial-out gNMI target: %+v", destination)
+		m.tunnels.register(target.ID)
+		gnmiClient, clientConn, err = connectDialOut(ctx, *destination, m.tunnels.dialer(target.ID), opts...)
+		if err != nil {
+			m.tunnels.unregister(target.ID)
+		}
+	} else {
+		log.Infof("Connecting to gNMI target: %+v", destination)
+		gnmiClient, clientConn, err = connect(ctx, *destination, opts...)
 	}
-	gnmiClient, clientConn, err := connect(ctx, *destination, opts...)
 	if err != nil {
 		log.Warnf("Failed to connect to the gNMI target %s: %s", destination.Target, err)
+		m.removeStatus(target.ID)
 		return err
 	}
+	gnmiClient.getTimeout = time.Duration(connOptions.GetTimeout)
+	gnmiClient.setTimeout = time.Duration(connOptions.SetTimeout)
+	gnmiClient.tlsCerts = tlsCerts
+	gnmiClient.onError = onError
 
 	m.targets[target.ID] = gnmiClient
 
@@ -123,9 +202,10 @@ func (m *connManager) Connect(ctx context.Context, target *topoapi.Object) error
 		state := clientConn.GetState()
 		switch state {
 		case connectivity.Ready:
-			conn = newConn(target.ID, gnmiClient)
+			conn = newConn(target.ID, gnmiClient, tlsCerts)
 			m.addConn(conn)
 		}
+		m.updateState(target.ID, state, conn)
 		for clientConn.WaitForStateChange(context.Background(), state) {
 			state = clientConn.GetState()
 			log.Infof("Connection state changed for Target '%s': %s", target.ID, state)
@@ -137,7 +217,7 @@ func (m *connManager) Connect(ctx context.Context, target *topoapi.Object) error
 			switch state {
 			case connectivity.Ready:
 				if conn == nil {
-					conn = newConn(target.ID, gnmiClient)
+					conn = newConn(target.ID, gnmiClient, tlsCerts)
 					m.addConn(conn)
 				}
 			case connectivity.Idle:
@@ -154,6 +234,7 @@ func (m *connManager) Connect(ctx context.Context, target *topoapi.Object) error
 			case connectivity.Shutdown:
 				return
 			}
+			m.updateState(target.ID, state, conn)
 		}
 	}()
 	return nil
@@ -168,6 +249,8 @@ func (m *connManager) Disconnect(ctx context.Context, targetID topoapi.ID) error
 	}
 	delete(m.targets, targetID)
 	m.connsMu.Unlock()
+	m.tunnels.unregister(targetID)
+	m.removeStatus(targetID)
 	return clientConn.Close()
 }
 
@@ -262,7 +345,8 @@ func connect(ctx context.Context, d baseClient.Destination, opts ...grpc.DialOpt
 	}
 
 	gnmiClient := &client{
-		client: cl,
+		client:     cl,
+		gnmiClient: gpb.NewGNMIClient(conn),
 	}
 
 	return gnmiClient, conn, nil
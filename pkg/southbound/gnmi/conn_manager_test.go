@@ -201,7 +201,7 @@ func TestGNMIConn_CapabilitiesWithString(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -223,7 +223,7 @@ func TestGNMIConn_GetWithString(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -246,7 +246,7 @@ func TestGNMIConn_Get(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -271,7 +271,7 @@ func TestGNMIConn_SetWithString(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -294,7 +294,7 @@ func TestGNMIConn_Set(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -316,7 +316,7 @@ func TestGNMIConn_Capabilities(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -336,13 +336,13 @@ func TestConnManager_List(t *testing.T) {
 	target1 := createTestTarget(t, target1, true)
 	target2 := createTestTarget(t, target2, true)
 
-	destination1, err := newDestination(target1)
+	destination1, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn1, _, err := connect(ctx, *destination1)
 	assert.NoError(t, err)
 	assert.NotNil(t, conn1)
 
-	destination2, err := newDestination(target2)
+	destination2, _, err := newDestination(target2)
 	assert.NoError(t, err)
 	conn2, _, err := connect(ctx, *destination2)
 	assert.NoError(t, err)
@@ -357,7 +357,7 @@ func TestGNMICon_GetBadRequest(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -383,7 +383,7 @@ func TestGNMIConn_NonTLS(t *testing.T) {
 	defer cancel()
 	target1 := createTestTarget(t, target1, true)
 
-	destination, err := newDestination(target1)
+	destination, _, err := newDestination(target1)
 	assert.NoError(t, err)
 	conn, _, err := connect(ctx, *destination)
 	assert.NoError(t, err)
@@ -0,0 +1,254 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// secretsDirEnv is the environment variable overriding the directory in which credentials secrets are mounted
+	secretsDirEnv = "ONOS_CONFIG_SECRETS_DIR"
+	// credentialsFileEnv is the environment variable overriding the path of the credentials file
+	credentialsFileEnv = "ONOS_CONFIG_CREDENTIALS_FILE"
+
+	defaultSecretsDir      = "/etc/onos/secrets"
+	defaultCredentialsFile = "/etc/onos/credentials/credentials.yaml"
+
+	usernameKey = "username"
+	passwordKey = "password"
+
+	// credentialsRefreshInterval is the interval after which credentials are reloaded from their source
+	credentialsRefreshInterval = time.Minute
+)
+
+// userCredentials is a username/password pair
+type userCredentials struct {
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"password"`
+}
+
+// Credentials are the username and password with which a Dialer authenticates to a target
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// credentialsLoader loads the current credentials from their source
+type credentialsLoader func() (userCredentials, error)
+
+// rotatingPassCred is an username/password implementation of credentials.PerRPCCredentials which periodically
+// reloads the credentials from their source. Rotated credentials are picked up by subsequent RPCs without
+// reconnecting to the target. Credential values must never be logged.
+type rotatingPassCred struct {
+	targetID        topoapi.ID
+	load            credentialsLoader
+	secure          bool
+	refreshInterval time.Duration
+	mu              sync.Mutex
+	credentials     userCredentials
+	loaded          time.Time
+}
+
+// GetRequestMetadata returns the current request metadata, including username and password in this case.
+// If reloading the credentials fails, the last loaded credentials are used.
+func (pc *rotatingPassCred) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if time.Since(pc.loaded) >= pc.refreshInterval {
+		creds, err := pc.load()
+		if err != nil {
+			log.Warnf("Failed reloading credentials for target '%s', using previous credentials: %s", pc.targetID, err)
+		} else {
+			pc.credentials = creds
+		}
+		// Defer the next attempt regardless of the outcome to avoid reloading on every RPC
+		pc.loaded = time.Now()
+	}
+	return map[string]string{
+		usernameKey: pc.credentials.Username,
+		passwordKey: pc.credentials.Password,
+	}, nil
+}
+
+// RequireTransportSecurity indicates whether the credentials requires transport security.
+func (pc *rotatingPassCred) RequireTransportSecurity() bool {
+	return pc.secure
+}
+
+// newTargetCredentials returns the per-RPC credentials for the given target. The credentials are referenced
+// by the target's credentials aspect. If the aspect is not set, the credentials file entry for the target ID
+// is used if one exists. If the target has no credentials, nil is returned.
+func newTargetCredentials(target *topoapi.Object, secure bool) (credentials.PerRPCCredentials, error) {
+	load, err := newCredentialsLoader(target)
+	if err != nil || load == nil {
+		return nil, err
+	}
+
+	// Load the credentials up front so misconfigured credentials fail the connection rather than each RPC
+	creds, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &rotatingPassCred{
+		targetID:        target.ID,
+		load:            load,
+		secure:          secure,
+		refreshInterval: credentialsRefreshInterval,
+		credentials:     creds,
+		loaded:          time.Now(),
+	}, nil
+}
+
+// newCredentialsLoader returns a loader for the credentials source of the given target
+func newCredentialsLoader(target *topoapi.Object) (credentialsLoader, error) {
+	targetCredentials, err := topo.GetCredentials(target)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		credentialsFile := getCredentialsFile()
+		fileCredentials, err := loadCredentialsFile(credentialsFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if _, ok := fileCredentials[string(target.ID)]; !ok {
+			return nil, nil
+		}
+		return newFileCredentialsLoader(credentialsFile, target.ID), nil
+	}
+
+	switch targetCredentials.Source {
+	case topo.CredentialsSourceSecret:
+		secretDir, err := getSecretDir(getSecretsDir(), targetCredentials.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return newSecretCredentialsLoader(secretDir), nil
+	case topo.CredentialsSourceEnv:
+		return newEnvCredentialsLoader(targetCredentials.UsernameEnv, targetCredentials.PasswordEnv), nil
+	default:
+		return newFileCredentialsLoader(getCredentialsFile(), target.ID), nil
+	}
+}
+
+// getSecretDir returns the directory in which the secret with the given name is mounted in the given secrets
+// directory. Secret names must not contain a path separator so that targets cannot read files outside the
+// secrets directory.
+func getSecretDir(secretsDir string, secret string) (string, error) {
+	if secret == "" || secret == "." || secret == ".." || strings.ContainsAny(secret, `/\`) {
+		return "", errors.NewInvalid("invalid secret name '%s'", secret)
+	}
+	secretDir := filepath.Join(secretsDir, secret)
+	if filepath.Dir(secretDir) != filepath.Clean(secretsDir) {
+		return "", errors.NewInvalid("invalid secret name '%s'", secret)
+	}
+	return secretDir, nil
+}
+
+// newSecretCredentialsLoader returns a loader reading the credentials from a mounted secret directory
+func newSecretCredentialsLoader(secretDir string) credentialsLoader {
+	return func() (userCredentials, error) {
+		username, err := ioutil.ReadFile(filepath.Join(secretDir, usernameKey))
+		if err != nil {
+			return userCredentials{}, errors.NewInvalid("failed reading username from secret '%s': %v", secretDir, err)
+		}
+		password, err := ioutil.ReadFile(filepath.Join(secretDir, passwordKey))
+		if err != nil {
+			return userCredentials{}, errors.NewInvalid("failed reading password from secret '%s': %v", secretDir, err)
+		}
+		return userCredentials{
+			Username: strings.TrimRight(string(username), "\r\n"),
+			Password: strings.TrimRight(string(password), "\r\n"),
+		}, nil
+	}
+}
+
+// newFileCredentialsLoader returns a loader reading the credentials for the given target from the credentials file
+func newFileCredentialsLoader(credentialsFile string, targetID topoapi.ID) credentialsLoader {
+	return func() (userCredentials, error) {
+		fileCredentials, err := loadCredentialsFile(credentialsFile)
+		if err != nil {
+			return userCredentials{}, errors.NewInvalid("failed reading credentials file '%s': %v", credentialsFile, err)
+		}
+		creds, ok := fileCredentials[string(targetID)]
+		if !ok {
+			return userCredentials{}, errors.NewNotFound("no credentials for target '%s' in '%s'", targetID, credentialsFile)
+		}
+		return creds, nil
+	}
+}
+
+// newEnvCredentialsLoader returns a loader reading the credentials from the given environment variables, which
+// topo.GetCredentials restricts to the target's credentials environment prefix
+func newEnvCredentialsLoader(usernameEnv, passwordEnv string) credentialsLoader {
+	return func() (userCredentials, error) {
+		username, ok := os.LookupEnv(usernameEnv)
+		if !ok {
+			return userCredentials{}, errors.NewNotFound("environment variable '%s' is not set", usernameEnv)
+		}
+		password, ok := os.LookupEnv(passwordEnv)
+		if !ok {
+			return userCredentials{}, errors.NewNotFound("environment variable '%s' is not set", passwordEnv)
+		}
+		return userCredentials{
+			Username: username,
+			Password: password,
+		}, nil
+	}
+}
+
+// loadCredentialsFile reads a YAML or JSON file mapping target IDs to credentials
+func loadCredentialsFile(credentialsFile string) (map[string]userCredentials, error) {
+	bytes, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	fileCredentials := make(map[string]userCredentials)
+	// The decoding error is not returned since it may quote the file contents
+	if err := yaml.Unmarshal(bytes, &fileCredentials); err != nil {
+		return nil, errors.NewInvalid("malformed credentials file '%s'", credentialsFile)
+	}
+	return fileCredentials, nil
+}
+
+func getSecretsDir() string {
+	if secretsDir := os.Getenv(secretsDirEnv); secretsDir != "" {
+		return secretsDir
+	}
+	return defaultSecretsDir
+}
+
+func getCredentialsFile() string {
+	if credentialsFile := os.Getenv(credentialsFileEnv); credentialsFile != "" {
+		return credentialsFile
+	}
+	return defaultCredentialsFile
+}
@@ -0,0 +1,176 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCredentialsTarget(t *testing.T, credentials *topo.Credentials) *topoapi.Object {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+	if credentials != nil {
+		bytes, err := json.Marshal(credentials)
+		assert.NoError(t, err)
+		assert.NoError(t, target.SetAspectBytes(topo.CredentialsAspect, bytes))
+	}
+	return target
+}
+
+func getRequestMetadata(t *testing.T, creds interface {
+	GetRequestMetadata(context.Context, ...string) (map[string]string, error)
+}) (string, string) {
+	md, err := creds.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	return md[usernameKey], md[passwordKey]
+}
+
+func TestSecretCredentials(t *testing.T) {
+	secretsDir := t.TempDir()
+	t.Setenv(secretsDirEnv, secretsDir)
+	secretDir := filepath.Join(secretsDir, "target-1-secret")
+	assert.NoError(t, os.Mkdir(secretDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, usernameKey), []byte("admin\n"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, passwordKey), []byte("secret\n"), 0600))
+
+	target := newCredentialsTarget(t, &topo.Credentials{
+		Source: topo.CredentialsSourceSecret,
+		Secret: "target-1-secret",
+	})
+	creds, err := newTargetCredentials(target, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, creds)
+	assert.True(t, creds.RequireTransportSecurity())
+	username, password := getRequestMetadata(t, creds)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+
+	// Rotate the password and force a reload
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, passwordKey), []byte("rotated"), 0600))
+	creds.(*rotatingPassCred).refreshInterval = 0
+	_, password = getRequestMetadata(t, creds)
+	assert.Equal(t, "rotated", password)
+
+	// A failed reload keeps the previous credentials
+	assert.NoError(t, os.Remove(filepath.Join(secretDir, passwordKey)))
+	_, password = getRequestMetadata(t, creds)
+	assert.Equal(t, "rotated", password)
+
+	// A missing secret fails when connecting
+	target = newCredentialsTarget(t, &topo.Credentials{
+		Source: topo.CredentialsSourceSecret,
+		Secret: "unknown",
+	})
+	_, err = newTargetCredentials(target, true)
+	assert.Error(t, err)
+
+	// Secrets outside the secrets directory cannot be referenced
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretsDir, usernameKey), []byte("admin"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretsDir, passwordKey), []byte("secret"), 0600))
+	for _, secret := range []string{"..", ".", "../secrets", "target-1-secret/..", "/etc"} {
+		target = newCredentialsTarget(t, &topo.Credentials{
+			Source: topo.CredentialsSourceSecret,
+			Secret: secret,
+		})
+		_, err = newTargetCredentials(target, true)
+		assert.True(t, errors.IsInvalid(err), secret)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.yaml")
+	t.Setenv(credentialsFileEnv, credentialsFile)
+
+	// Without an aspect or a credentials file the target has no credentials
+	creds, err := newTargetCredentials(newCredentialsTarget(t, nil), false)
+	assert.NoError(t, err)
+	assert.Nil(t, creds)
+
+	assert.NoError(t, ioutil.WriteFile(credentialsFile, []byte(`
+target-1:
+  username: admin
+  password: secret
+`), 0600))
+	creds, err = newTargetCredentials(newCredentialsTarget(t, nil), false)
+	assert.NoError(t, err)
+	assert.NotNil(t, creds)
+	assert.False(t, creds.RequireTransportSecurity())
+	username, password := getRequestMetadata(t, creds)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+
+	// JSON credentials files are supported too
+	assert.NoError(t, ioutil.WriteFile(credentialsFile, []byte(`{"target-2": {"username": "admin", "password": "secret"}}`), 0600))
+	creds, err = newTargetCredentials(newCredentialsTarget(t, nil), false)
+	assert.NoError(t, err)
+	assert.Nil(t, creds)
+
+	creds, err = newTargetCredentials(newCredentialsTarget(t, &topo.Credentials{
+		Source: topo.CredentialsSourceFile,
+	}), false)
+	assert.Error(t, err)
+	assert.Nil(t, creds)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("ONOS_CONFIG_CREDENTIALS_TARGET_1_USERNAME", "admin")
+	t.Setenv("ONOS_CONFIG_CREDENTIALS_TARGET_1_PASSWORD", "secret")
+	t.Setenv("ONOS_CONFIG_CREDENTIALS_TARGET_1_ADMIN_PASSWORD", "admin-secret")
+	t.Setenv("OTHER_SECRET", "other")
+
+	creds, err := newTargetCredentials(newCredentialsTarget(t, &topo.Credentials{
+		Source: topo.CredentialsSourceEnv,
+	}), true)
+	assert.NoError(t, err)
+	username, password := getRequestMetadata(t, creds)
+	assert.Equal(t, "admin", username)
+	assert.Equal(t, "secret", password)
+
+	creds, err = newTargetCredentials(newCredentialsTarget(t, &topo.Credentials{
+		Source:      topo.CredentialsSourceEnv,
+		PasswordEnv: "ONOS_CONFIG_CREDENTIALS_TARGET_1_ADMIN_PASSWORD",
+	}), true)
+	assert.NoError(t, err)
+	_, password = getRequestMetadata(t, creds)
+	assert.Equal(t, "admin-secret", password)
+
+	// Other variables of the process cannot be referenced
+	_, err = newTargetCredentials(newCredentialsTarget(t, &topo.Credentials{
+		Source:      topo.CredentialsSourceEnv,
+		PasswordEnv: "OTHER_SECRET",
+	}), true)
+	assert.True(t, errors.IsInvalid(err))
+
+	_, err = newTargetCredentials(newCredentialsTarget(t, &topo.Credentials{
+		Source:      topo.CredentialsSourceEnv,
+		UsernameEnv: "ONOS_CONFIG_CREDENTIALS_TARGET_1_MISSING",
+	}), true)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,315 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	baseClient "github.com/openconfig/gnmi/client"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	// minRedialDelay is the delay before the first attempt to reopen a closed session
+	minRedialDelay = time.Second
+	// maxRedialDelay is the upper bound of the delay between attempts to reopen a closed session
+	maxRedialDelay = 30 * time.Second
+)
+
+// Dialer opens a session to a target managed with a southbound protocol other than gNMI.
+// The returned Client translates gNMI requests into the target's protocol. The credentials are nil
+// if the target has no credentials. The onClose function must be called once when the session is
+// closed by either end, with the error that caused it to close, if any.
+type Dialer func(ctx context.Context, target *topoapi.Object, creds *Credentials, onClose func(error)) (Client, error)
+
+// RegisterDialer registers the dialer used to connect to targets managed with the given protocol
+func (m *connManager) RegisterDialer(protocol topo.SouthboundProtocol, dialer Dialer) {
+	m.dialersMu.Lock()
+	defer m.dialersMu.Unlock()
+	m.dialers[protocol] = dialer
+}
+
+// connectDialer connects to a target with the dialer registered for its protocol.
+// Unlike gRPC channels, sessions opened by a dialer are not reestablished by themselves,
+// so closed sessions are redialed until the target is disconnected.
+// The target is reserved before dialing so the connection manager is not locked while the session is opened;
+// requests to the target fail as unavailable until the session is established.
+func (m *connManager) connectDialer(ctx context.Context, target *topoapi.Object, protocol topo.SouthboundProtocol) error {
+	m.dialersMu.RLock()
+	dialer, ok := m.dialers[protocol]
+	m.dialersMu.RUnlock()
+	if !ok {
+		return errors.NewNotSupported("southbound protocol '%s' of target '%s' is not supported", protocol, target.ID)
+	}
+
+	load, err := newCredentialsLoader(target)
+	if err != nil {
+		log.Warnf("Failed to load credentials for target %s: %s", target.ID, err)
+		return err
+	}
+
+	dialerClient := &dialerClient{
+		target:  target,
+		dialer:  dialer,
+		load:    load,
+		closeCh: make(chan struct{}),
+	}
+	m.connsMu.Lock()
+	if _, ok := m.targets[target.ID]; ok {
+		m.connsMu.Unlock()
+		return errors.NewAlreadyExists("target '%s' already exists", target.ID)
+	}
+	m.targets[target.ID] = dialerClient
+	m.addStatus(target.ID, connectivity.Connecting, false)
+	m.connsMu.Unlock()
+
+	log.Infof("Connecting to %s target: %s", protocol, target.ID)
+	closedCh, err := dialerClient.dial(ctx)
+	if err != nil {
+		log.Warnf("Failed to connect to the %s target %s: %s", protocol, target.ID, err)
+		// Release the reservation unless the target was disconnected while dialing
+		m.connsMu.Lock()
+		if m.targets[target.ID] == Client(dialerClient) {
+			delete(m.targets, target.ID)
+			m.removeStatus(target.ID)
+		}
+		m.connsMu.Unlock()
+		_ = dialerClient.Close()
+		return err
+	}
+	go m.maintainSession(dialerClient, closedCh)
+	return nil
+}
+
+// maintainSession tracks the session to a dialer target, redialing the target when the session is closed
+func (m *connManager) maintainSession(dialerClient *dialerClient, closedCh <-chan error) {
+	targetID := dialerClient.target.ID
+	for {
+		conn := newConn(targetID, dialerClient, nil)
+		m.addConn(conn)
+		m.updateState(targetID, connectivity.Ready, conn)
+
+		select {
+		case err := <-closedCh:
+			log.Warnf("Session to target '%s' closed", targetID, err)
+			dialerClient.setSession(nil)
+			m.removeConn(conn.ID())
+			if err != nil {
+				m.recordError(targetID, err)
+			}
+			m.updateState(targetID, connectivity.TransientFailure, nil)
+		case <-dialerClient.closeCh:
+			m.removeConn(conn.ID())
+			return
+		}
+
+		closedCh = m.redial(dialerClient)
+		if closedCh == nil {
+			return
+		}
+	}
+}
+
+// redial reopens the session to a dialer target with exponential backoff until it succeeds or the target is
+// disconnected, in which case nil is returned
+func (m *connManager) redial(dialerClient *dialerClient) <-chan error {
+	targetID := dialerClient.target.ID
+	delay := minRedialDelay
+	for {
+		select {
+		case <-time.After(delay):
+		case <-dialerClient.closeCh:
+			return nil
+		}
+
+		log.Infof("Reconnecting to target '%s'", targetID)
+		m.updateState(targetID, connectivity.Connecting, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		closedCh, err := dialerClient.dial(ctx)
+		cancel()
+		if err == nil {
+			return closedCh
+		}
+		log.Warnf("Failed to reconnect to target '%s'", targetID, err)
+		m.recordError(targetID, err)
+		m.updateState(targetID, connectivity.TransientFailure, nil)
+
+		delay *= 2
+		if delay > maxRedialDelay {
+			delay = maxRedialDelay
+		}
+	}
+}
+
+// dialerClient is a Client for a target connected with a Dialer. Requests are sent on the current
+// session to the target and fail as unavailable while the target is being redialed.
+type dialerClient struct {
+	target    *topoapi.Object
+	dialer    Dialer
+	load      credentialsLoader
+	session   Client
+	mu        sync.RWMutex
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// dial opens a new session to the target, returning a channel on which the error that closed the session is received
+func (c *dialerClient) dial(ctx context.Context) (<-chan error, error) {
+	var creds *Credentials
+	if c.load != nil {
+		userCreds, err := c.load()
+		if err != nil {
+			return nil, err
+		}
+		creds = &Credentials{
+			Username: userCreds.Username,
+			Password: userCreds.Password,
+		}
+	}
+
+	closedCh := make(chan error, 1)
+	session, err := c.dialer(ctx, c.target, creds, func(err error) {
+		select {
+		case closedCh <- err:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// If the client was closed while dialing, close the new session
+	c.mu.Lock()
+	select {
+	case <-c.closeCh:
+		c.mu.Unlock()
+		_ = session.Close()
+		return nil, errors.NewCanceled("connection to target '%s' was closed", c.target.ID)
+	default:
+	}
+	c.session = session
+	c.mu.Unlock()
+	return closedCh, nil
+}
+
+func (c *dialerClient) setSession(session Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = session
+}
+
+func (c *dialerClient) getSession() (Client, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.session == nil {
+		return nil, errors.NewUnavailable("target '%s' is not connected", c.target.ID)
+	}
+	return c.session, nil
+}
+
+// Capabilities returns the capabilities of the target
+func (c *dialerClient) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.Capabilities(ctx, req)
+}
+
+// CapabilitiesWithString allows a request for the capabilities by a string - can be empty
+func (c *dialerClient) CapabilitiesWithString(ctx context.Context, request string) (*gpb.CapabilityResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.CapabilitiesWithString(ctx, request)
+}
+
+// Get gets the state of the target
+func (c *dialerClient) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.Get(ctx, req)
+}
+
+// GetWithString can make a get request based on a given a string request - can be empty
+func (c *dialerClient) GetWithString(ctx context.Context, request string) (*gpb.GetResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.GetWithString(ctx, request)
+}
+
+// Set changes the configuration of the target
+func (c *dialerClient) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.Set(ctx, req)
+}
+
+// SetWithString can make a set request based on a given string request
+func (c *dialerClient) SetWithString(ctx context.Context, request string) (*gpb.SetResponse, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.SetWithString(ctx, request)
+}
+
+// Subscribe subscribes to the target based on a given query
+func (c *dialerClient) Subscribe(ctx context.Context, q baseClient.Query) error {
+	session, err := c.getSession()
+	if err != nil {
+		return err
+	}
+	return session.Subscribe(ctx, q)
+}
+
+// SubscribeStream opens a subscription stream to the target and sends the given request
+func (c *dialerClient) SubscribeStream(ctx context.Context, req *gpb.SubscribeRequest) (gpb.GNMI_SubscribeClient, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+	return session.SubscribeStream(ctx, req)
+}
+
+// Close closes the session to the target and stops redialing it
+func (c *dialerClient) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+	})
+	c.mu.Lock()
+	session := c.session
+	c.session = nil
+	c.mu.Unlock()
+	if session != nil {
+		return session.Close()
+	}
+	return nil
+}
+
+var _ Client = &dialerClient{}
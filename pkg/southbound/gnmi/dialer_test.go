@@ -0,0 +1,163 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/connectivity"
+)
+
+// testSession is a session opened by the test dialer
+type testSession struct {
+	Client
+	onClose func(error)
+	closed  bool
+}
+
+func (s *testSession) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	return &gpb.CapabilityResponse{}, nil
+}
+
+func (s *testSession) Close() error {
+	s.closed = true
+	return nil
+}
+
+// testDialer records the sessions it opens
+type testDialer struct {
+	sessions []*testSession
+	mu       sync.Mutex
+}
+
+func (d *testDialer) dial(ctx context.Context, target *topoapi.Object, creds *Credentials, onClose func(error)) (Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	session := &testSession{onClose: onClose}
+	d.sessions = append(d.sessions, session)
+	return session, nil
+}
+
+func (d *testDialer) getSessions() []*testSession {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sessions
+}
+
+func TestConnManager_Dialer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr := NewConnManager()
+
+	target := createTestTarget(t, target1, true)
+	assert.NoError(t, target.SetAspectBytes(topo.SouthboundAspect, []byte(`{"protocol": "netconf"}`)))
+
+	// Connecting fails if no dialer is registered for the protocol
+	err := mgr.Connect(ctx, target)
+	assert.True(t, errors.IsNotSupported(err))
+
+	dialer := &testDialer{}
+	mgr.RegisterDialer(topo.SouthboundProtocolNETCONF, dialer.dial)
+
+	ch := make(chan Conn)
+	assert.NoError(t, mgr.Watch(ctx, ch))
+	assert.NoError(t, mgr.Connect(ctx, target))
+
+	var conn Conn
+	select {
+	case conn = <-ch:
+	case <-time.After(timeout):
+		t.Fatal("target not connected")
+	}
+	assert.Equal(t, topoapi.ID(target1), conn.TargetID())
+	assert.Nil(t, conn.CertificateStatus())
+	_, err = conn.Capabilities(ctx, &gpb.CapabilityRequest{})
+	assert.NoError(t, err)
+
+	// Closing the session removes the connection and redials the target
+	dialer.getSessions()[0].onClose(errors.NewUnavailable("connection reset"))
+	select {
+	case removed := <-ch:
+		assert.Equal(t, conn.ID(), removed.ID())
+	case <-time.After(timeout):
+		t.Fatal("connection not removed")
+	}
+	select {
+	case conn = <-ch:
+		assert.Equal(t, topoapi.ID(target1), conn.TargetID())
+	case <-time.After(timeout):
+		t.Fatal("target not reconnected")
+	}
+	assert.Len(t, dialer.getSessions(), 2)
+
+	status, err := mgr.GetStatus(ctx, target1)
+	assert.NoError(t, err)
+	assert.Equal(t, connectivity.Ready.String(), status.State)
+	assert.Equal(t, 1, status.Reconnects)
+	assert.Equal(t, "connection reset", status.LastError)
+
+	assert.NoError(t, mgr.Disconnect(ctx, target1))
+	assert.True(t, dialer.getSessions()[1].closed)
+	_, err = mgr.GetByTarget(ctx, target1)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestConnManager_DialerDoesNotBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr := NewConnManager()
+
+	// A dialer that blocks until the test releases it
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+	mgr.RegisterDialer(topo.SouthboundProtocolNETCONF, func(ctx context.Context, target *topoapi.Object, creds *Credentials, onClose func(error)) (Client, error) {
+		close(dialing)
+		<-release
+		return nil, errors.NewUnavailable("connection refused")
+	})
+
+	target := createTestTarget(t, target1, true)
+	assert.NoError(t, target.SetAspectBytes(topo.SouthboundAspect, []byte(`{"protocol": "netconf"}`)))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- mgr.Connect(ctx, target)
+	}()
+	<-dialing
+
+	// The target is reserved while it is dialed without locking the connection manager
+	client, err := mgr.GetByTarget(ctx, target1)
+	assert.NoError(t, err)
+	_, err = client.Capabilities(ctx, &gpb.CapabilityRequest{})
+	assert.True(t, errors.IsUnavailable(err))
+	assert.True(t, errors.IsAlreadyExists(mgr.Connect(ctx, target)))
+	_, ok := mgr.Get(ctx, "unknown")
+	assert.False(t, ok)
+
+	// A failed dial releases the reservation
+	close(release)
+	assert.True(t, errors.IsUnavailable(<-errCh))
+	_, err = mgr.GetByTarget(ctx, target1)
+	assert.True(t, errors.IsNotFound(err))
+	_, err = mgr.GetStatus(ctx, target1)
+	assert.True(t, errors.IsNotFound(err))
+}
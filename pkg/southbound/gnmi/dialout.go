@@ -0,0 +1,253 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	baseClient "github.com/openconfig/gnmi/client"
+	gclient "github.com/openconfig/gnmi/client/gnmi"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+)
+
+const (
+	// dialOutHandshakeTimeout is the time allowed for a target to complete the TLS handshake and identify itself
+	dialOutHandshakeTimeout = 10 * time.Second
+	// maxTargetIDLength is the maximum length of the target ID sent by a dial-out target
+	maxTargetIDLength = 253
+)
+
+// NewDialOutListener returns a TLS listener on the given port for targets that initiate the southbound connection.
+// Targets must present a client certificate signed by the CA, and the certificate's common name or one of its DNS
+// names must match the target ID sent by the target. If no paths are given, the default certificates are used.
+func NewDialOutListener(port int, caPath, keyPath, certPath string) (net.Listener, error) {
+	var certificate tls.Certificate
+	var err error
+	if keyPath == "" && certPath == "" {
+		certificate, err = tls.X509KeyPair([]byte(certs.DefaultOnosConfigCrt), []byte(certs.DefaultOnosConfigKey))
+	} else {
+		certificate, err = tls.LoadX509KeyPair(certPath, keyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+	if caPath == "" {
+		clientCAs.AppendCertsFromPEM([]byte(certs.OnfCaCrt))
+	} else {
+		clientCAs, err = certs.GetCertPool(caPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tls.Listen("tcp", fmt.Sprintf(":%d", port), &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
+
+// ServeDialOut accepts connections from dial-out targets on the given TLS listener until the listener is closed.
+// After the TLS handshake, a target sends its ID terminated by a newline. onos-config then acts as the gRPC
+// client over the connection, so the target serves gNMI on the connection it opened.
+func (m *connManager) ServeDialOut(lis net.Listener) error {
+	log.Infof("Accepting dial-out gNMI targets on %s", lis.Addr())
+	for {
+		netConn, err := lis.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				log.Warnf("Failed accepting dial-out connection: %s", err)
+				continue
+			}
+			return err
+		}
+		go m.acceptDialOut(netConn)
+	}
+}
+
+// acceptDialOut identifies the target behind the given connection and hands the connection to the target's tunnel
+func (m *connManager) acceptDialOut(netConn net.Conn) {
+	targetID, err := identifyDialOutTarget(netConn)
+	if err != nil {
+		log.Warnf("Rejecting dial-out connection from %s: %s", netConn.RemoteAddr(), err)
+		_ = netConn.Close()
+		return
+	}
+	if !m.tunnels.accept(targetID, netConn) {
+		log.Warnf("Rejecting dial-out connection from %s: target '%s' is not a dial-out target", netConn.RemoteAddr(), targetID)
+		_ = netConn.Close()
+		return
+	}
+	log.Infof("Accepted dial-out connection from target '%s' at %s", targetID, netConn.RemoteAddr())
+}
+
+// identifyDialOutTarget completes the TLS handshake, reads the target ID and verifies it against the client certificate
+func identifyDialOutTarget(netConn net.Conn) (topoapi.ID, error) {
+	tlsConn, ok := netConn.(*tls.Conn)
+	if !ok {
+		return "", errors.NewInvalid("dial-out connection is not a TLS connection")
+	}
+	if err := tlsConn.SetDeadline(time.Now().Add(dialOutHandshakeTimeout)); err != nil {
+		return "", err
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		return "", err
+	}
+
+	// Read the target ID one byte at a time to avoid consuming any of the gRPC traffic that follows it
+	var builder strings.Builder
+	b := make([]byte, 1)
+	for {
+		if _, err := tlsConn.Read(b); err != nil {
+			return "", err
+		}
+		if b[0] == '\n' {
+			break
+		}
+		if builder.Len() == maxTargetIDLength {
+			return "", errors.NewInvalid("target ID exceeds %d bytes", maxTargetIDLength)
+		}
+		builder.WriteByte(b[0])
+	}
+	targetID := topoapi.ID(strings.TrimSpace(builder.String()))
+	if targetID == "" {
+		return "", errors.NewInvalid("no target ID sent")
+	}
+
+	peerCerts := tlsConn.ConnectionState().PeerCertificates
+	if len(peerCerts) == 0 || !certificateMatchesTarget(peerCerts[0], targetID) {
+		return "", errors.NewUnauthorized("client certificate does not match target '%s'", targetID)
+	}
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		return "", err
+	}
+	return targetID, nil
+}
+
+// certificateMatchesTarget returns whether the common name or one of the DNS names of the certificate is the target ID
+func certificateMatchesTarget(cert *x509.Certificate, targetID topoapi.ID) bool {
+	if cert.Subject.CommonName == string(targetID) {
+		return true
+	}
+	for _, name := range cert.DNSNames {
+		if name == string(targetID) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialOutTunnels holds the connections opened by dial-out targets until gRPC dials them
+type dialOutTunnels struct {
+	tunnels map[topoapi.ID]chan net.Conn
+	mu      sync.Mutex
+}
+
+// register registers a dial-out target, enabling it to connect
+func (t *dialOutTunnels) register(targetID topoapi.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tunnels == nil {
+		t.tunnels = make(map[topoapi.ID]chan net.Conn)
+	}
+	if _, ok := t.tunnels[targetID]; !ok {
+		t.tunnels[targetID] = make(chan net.Conn, 1)
+	}
+}
+
+// unregister unregisters a dial-out target, closing any connection that has not yet been dialed
+func (t *dialOutTunnels) unregister(targetID topoapi.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tunnel, ok := t.tunnels[targetID]; ok {
+		select {
+		case netConn := <-tunnel:
+			_ = netConn.Close()
+		default:
+		}
+		delete(t.tunnels, targetID)
+	}
+}
+
+// accept hands a connection opened by the given target to gRPC, replacing any connection not yet dialed
+func (t *dialOutTunnels) accept(targetID topoapi.ID, netConn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tunnel, ok := t.tunnels[targetID]
+	if !ok {
+		return false
+	}
+	select {
+	case stale := <-tunnel:
+		_ = stale.Close()
+	default:
+	}
+	tunnel <- netConn
+	return true
+}
+
+// dialer returns a gRPC dialer that waits for the given target to open a connection
+func (t *dialOutTunnels) dialer(targetID topoapi.ID) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		t.mu.Lock()
+		tunnel, ok := t.tunnels[targetID]
+		t.mu.Unlock()
+		if !ok {
+			return nil, errors.NewNotFound("target '%s' is not a dial-out target", targetID)
+		}
+		select {
+		case netConn := <-tunnel:
+			return netConn, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// connectDialOut creates a client for a dial-out target. The gRPC connection waits for the target to open a
+// connection, and the TLS connection opened by the target is used as the transport.
+func connectDialOut(ctx context.Context, d baseClient.Destination, dialer func(context.Context, string) (net.Conn, error), opts ...grpc.DialOption) (*client, *grpc.ClientConn, error) {
+	opts = append(opts, grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+	conn, err := grpc.DialContext(ctx, "passthrough:///"+d.Target, opts...)
+	if err != nil {
+		return nil, nil, errors.NewInternal("Dialer(%s): %v", d.Target, err)
+	}
+
+	// NewFromConn performs a reflection lookup that would block until the target connects; its result is ignored
+	lookupCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	cl, err := gclient.NewFromConn(lookupCtx, conn, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &client{
+		client:     cl,
+		gnmiClient: gpb.NewGNMIClient(conn),
+	}, conn, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// singleConnListener is a net.Listener returning a single connection, used to serve gRPC on a dial-out connection
+type singleConnListener struct {
+	conns chan net.Conn
+	addr  net.Addr
+	once  sync.Once
+}
+
+func newSingleConnListener(conn net.Conn) *singleConnListener {
+	conns := make(chan net.Conn, 1)
+	conns <- conn
+	return &singleConnListener{conns: conns, addr: conn.LocalAddr()}
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	conn, ok := <-l.conns
+	if !ok {
+		return nil, net.ErrClosed
+	}
+	return conn, nil
+}
+
+func (l *singleConnListener) Close() error {
+	l.once.Do(func() {
+		close(l.conns)
+	})
+	return nil
+}
+
+func (l *singleConnListener) Addr() net.Addr {
+	return l.addr
+}
+
+func dialOut(t *testing.T, addr string, ca *testCert, client *testCert, targetID string) (net.Conn, error) {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		ServerName: "onos-config",
+		RootCAs:    roots,
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{client.cert.Raw},
+			PrivateKey:  client.key,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.Write([]byte(targetID + "\n"))
+	return conn, err
+}
+
+func TestDialOut(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "onos-config.crt")
+	keyFile := filepath.Join(dir, "onos-config.key")
+	expiry := time.Now().Add(time.Hour)
+	ca := newTestCert(t, "ca", expiry, nil)
+	ca.write(t, caFile, "")
+	newTestCert(t, "onos-config", expiry, ca).write(t, certFile, keyFile)
+
+	lis, err := NewDialOutListener(0, caFile, keyFile, certFile)
+	assert.NoError(t, err)
+	defer lis.Close()
+
+	mgr := NewConnManager()
+	go func() {
+		_ = mgr.ServeDialOut(lis)
+	}()
+
+	target := createTestTarget(t, target1, false)
+	assert.NoError(t, target.SetAspectBytes(topo.ConnectionOptionsAspect, []byte(`{"dialOut": true}`)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, mgr.Connect(ctx, target))
+
+	ch := make(chan Conn)
+	assert.NoError(t, mgr.Watch(ctx, ch))
+
+	// A target presenting a certificate for another target is rejected
+	other := newTestCert(t, target2, expiry, ca)
+	netConn, err := dialOut(t, lis.Addr().String(), ca, other, target1)
+	if err == nil {
+		_, err = netConn.Read(make([]byte, 1))
+	}
+	assert.Error(t, err)
+
+	// The target connects and serves gNMI on its own connection
+	netConn, err = dialOut(t, lis.Addr().String(), ca, newTestCert(t, target1, expiry, ca), target1)
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	gpb.RegisterGNMIServer(server, &testServer{})
+	go func() {
+		_ = server.Serve(newSingleConnListener(netConn))
+	}()
+	defer server.Stop()
+
+	select {
+	case conn := <-ch:
+		assert.Equal(t, topoapi.ID(target1), conn.TargetID())
+		response, err := conn.Capabilities(ctx, &gpb.CapabilityRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "0.7.0", response.GNMIVersion)
+	case <-time.After(timeout):
+		t.Fatal("dial-out connection not established")
+	}
+	assert.NoError(t, mgr.Disconnect(ctx, target1))
+}
+
+func TestCertificateMatchesTarget(t *testing.T) {
+	cert := &x509.Certificate{DNSNames: []string{"target-1.example.com"}}
+	assert.True(t, certificateMatchesTarget(cert, "target-1.example.com"))
+	assert.False(t, certificateMatchesTarget(cert, "target-1"))
+	cert.Subject.CommonName = "target-1"
+	assert.True(t, certificateMatchesTarget(cert, "target-1"))
+}
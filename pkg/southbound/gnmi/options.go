@@ -0,0 +1,76 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"math"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor
+	"google.golang.org/grpc/keepalive"
+)
+
+// newDialOptions returns the gRPC dial options for the given target connection options
+func newDialOptions(options *topo.ConnectionOptions) ([]grpc.DialOption, error) {
+	maxRecvMsgSize := math.MaxInt32
+	if options.MaxRecvMsgSize > 0 {
+		maxRecvMsgSize = options.MaxRecvMsgSize
+	}
+	callOpts := []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
+	}
+	if options.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(options.MaxSendMsgSize))
+	}
+	if options.Compression != "" {
+		if encoding.GetCompressor(options.Compression) == nil {
+			return nil, errors.NewInvalid("unknown compressor '%s'", options.Compression)
+		}
+		callOpts = append(callOpts, grpc.UseCompressor(options.Compression))
+	}
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(callOpts...),
+	}
+
+	if options.KeepaliveInterval > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(options.KeepaliveInterval),
+			Timeout:             time.Duration(options.KeepaliveTimeout),
+			PermitWithoutStream: true,
+		}))
+	}
+
+	if options.BackoffBaseDelay > 0 || options.BackoffMaxDelay > 0 {
+		backoffConfig := backoff.DefaultConfig
+		if options.BackoffBaseDelay > 0 {
+			backoffConfig.BaseDelay = time.Duration(options.BackoffBaseDelay)
+		}
+		if options.BackoffMaxDelay > 0 {
+			backoffConfig.MaxDelay = time.Duration(options.BackoffMaxDelay)
+		}
+		if backoffConfig.MaxDelay < backoffConfig.BaseDelay {
+			return nil, errors.NewInvalid("backoff max delay %s is less than base delay %s", backoffConfig.MaxDelay, backoffConfig.BaseDelay)
+		}
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoffConfig,
+		}))
+	}
+	return opts, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDialOptions(t *testing.T) {
+	opts, err := newDialOptions(&topo.ConnectionOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	opts, err = newDialOptions(&topo.ConnectionOptions{
+		KeepaliveInterval: topo.Duration(10 * time.Second),
+		KeepaliveTimeout:  topo.Duration(5 * time.Second),
+		BackoffBaseDelay:  topo.Duration(time.Second),
+		BackoffMaxDelay:   topo.Duration(time.Minute),
+		MaxSendMsgSize:    1024 * 1024,
+		Compression:       "gzip",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, opts, 3)
+
+	_, err = newDialOptions(&topo.ConnectionOptions{
+		Compression: "lz4",
+	})
+	assert.Error(t, err)
+
+	_, err = newDialOptions(&topo.ConnectionOptions{
+		BackoffBaseDelay: topo.Duration(time.Minute),
+		BackoffMaxDelay:  topo.Duration(time.Second),
+	})
+	assert.Error(t, err)
+}
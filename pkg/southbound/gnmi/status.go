@@ -0,0 +1,222 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnStatus is the status of the southbound connection to a target
+type ConnStatus struct {
+	TargetID topoapi.ID `json:"targetId"`
+	// ConnID is the ID of the connection; empty if the target is not connected
+	ConnID ConnID `json:"connId,omitempty"`
+	// State is the state of the gRPC channel to the target
+	State string `json:"state"`
+	// DialOut indicates the target initiates the connection
+	DialOut bool `json:"dialOut,omitempty"`
+	// Connected is the time at which the current connection was established
+	Connected *time.Time `json:"connected,omitempty"`
+	// LastError is the last error caused by the connection to the target, if any
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime is the time at which the last error occurred
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+	// Reconnects is the number of times the target has been reconnected
+	Reconnects int `json:"reconnects"`
+	// established indicates the target has been connected at least once
+	established bool
+}
+
+// GetStatus returns the connection status of the given target
+func (m *connManager) GetStatus(ctx context.Context, targetID topoapi.ID) (ConnStatus, error) {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	status, ok := m.statuses[targetID]
+	if !ok {
+		return ConnStatus{}, errors.NewNotFound("target '%s' not found", targetID)
+	}
+	return *status, nil
+}
+
+// ListStatus returns the connection status of all targets
+func (m *connManager) ListStatus(ctx context.Context) []ConnStatus {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	statuses := make([]ConnStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, *status)
+	}
+	return statuses
+}
+
+// statusWatcherBufferSize is the number of status changes buffered for each watcher.
+// A watcher that falls further behind is removed.
+const statusWatcherBufferSize = 100
+
+// statusWatcher is a watcher of connection status changes
+type statusWatcher struct {
+	ch     chan ConnStatus
+	cancel context.CancelFunc
+}
+
+// WatchStatus streams changes to the connection status of targets until the context is canceled.
+// The current status of each target is sent first. The channel is closed once the watch is removed.
+// Changes are buffered for each watcher; a watcher that does not keep up with changes is removed.
+func (m *connManager) WatchStatus(ctx context.Context, ch chan<- ConnStatus) error {
+	ctx, cancel := context.WithCancel(ctx)
+	id := uuid.New()
+	watcher := &statusWatcher{
+		ch:     make(chan ConnStatus, statusWatcherBufferSize),
+		cancel: cancel,
+	}
+
+	// Register the watcher while holding the status lock so no change is missed between the
+	// snapshot of the current statuses and the registration
+	m.statusMu.RLock()
+	statuses := make([]ConnStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, *status)
+	}
+	m.statusWatchersMu.Lock()
+	m.statusWatchers[id] = watcher
+	m.statusWatchersMu.Unlock()
+	m.statusMu.RUnlock()
+
+	go func() {
+		defer func() {
+			m.statusWatchersMu.Lock()
+			delete(m.statusWatchers, id)
+			m.statusWatchersMu.Unlock()
+			cancel()
+			close(ch)
+		}()
+		for _, status := range statuses {
+			select {
+			case ch <- status:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case status := <-watcher.ch:
+				select {
+				case ch <- status:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// addStatus adds the status of a target being connected and notifies watchers
+func (m *connManager) addStatus(targetID topoapi.ID, state connectivity.State, dialOut bool) {
+	m.statusMu.Lock()
+	status := &ConnStatus{
+		TargetID: targetID,
+		State:    state.String(),
+		DialOut:  dialOut,
+	}
+	m.statuses[targetID] = status
+	event := *status
+	m.statusMu.Unlock()
+	m.statusCh <- event
+}
+
+// updateStatus applies the given update to the status of a connected target and notifies watchers
+func (m *connManager) updateStatus(targetID topoapi.ID, update func(*ConnStatus)) {
+	m.statusMu.Lock()
+	status, ok := m.statuses[targetID]
+	if !ok {
+		m.statusMu.Unlock()
+		return
+	}
+	update(status)
+	event := *status
+	m.statusMu.Unlock()
+	m.statusCh <- event
+}
+
+// updateState records a state change of the gRPC channel to a target
+func (m *connManager) updateState(targetID topoapi.ID, state connectivity.State, conn Conn) {
+	m.updateStatus(targetID, func(status *ConnStatus) {
+		status.State = state.String()
+		if conn != nil {
+			if status.ConnID != conn.ID() {
+				if status.established {
+					status.Reconnects++
+				}
+				status.established = true
+				now := time.Now()
+				status.ConnID = conn.ID()
+				status.Connected = &now
+			}
+		} else if state != connectivity.Idle {
+			status.ConnID = ""
+			status.Connected = nil
+		}
+	})
+}
+
+// recordError records an error caused by the connection to a target
+func (m *connManager) recordError(targetID topoapi.ID, err error) {
+	m.updateStatus(targetID, func(status *ConnStatus) {
+		now := time.Now()
+		status.LastError = err.Error()
+		status.LastErrorTime = &now
+	})
+}
+
+// removeStatus removes the status of a disconnected target and notifies watchers
+func (m *connManager) removeStatus(targetID topoapi.ID) {
+	m.statusMu.Lock()
+	status, ok := m.statuses[targetID]
+	if !ok {
+		m.statusMu.Unlock()
+		return
+	}
+	delete(m.statuses, targetID)
+	event := *status
+	event.ConnID = ""
+	event.Connected = nil
+	event.State = connectivity.Shutdown.String()
+	m.statusMu.Unlock()
+	m.statusCh <- event
+}
+
+func (m *connManager) processStatusEvents() {
+	for status := range m.statusCh {
+		m.statusWatchersMu.RLock()
+		for id, watcher := range m.statusWatchers {
+			select {
+			case watcher.ch <- status:
+			default:
+				log.Warnf("Removing connection status watcher %s: too many pending changes", id)
+				watcher.cancel()
+			}
+		}
+		m.statusWatchersMu.RUnlock()
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestConnManager_Status(t *testing.T) {
+	s := setup(t, getTLSServerConfig(t))
+	defer s.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr := NewConnManager()
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	ch := make(chan ConnStatus)
+	assert.NoError(t, mgr.WatchStatus(watchCtx, ch))
+
+	assert.NoError(t, mgr.Connect(ctx, createTestTarget(t, target1, true)))
+	for {
+		var status ConnStatus
+		select {
+		case status = <-ch:
+		case <-time.After(timeout):
+			t.Fatal("target not connected")
+		}
+		assert.Equal(t, topoapi.ID(target1), status.TargetID)
+		if status.State == connectivity.Ready.String() && status.ConnID != "" {
+			assert.NotNil(t, status.Connected)
+			assert.Equal(t, 0, status.Reconnects)
+			break
+		}
+	}
+
+	// The channel is closed once the watch is canceled
+	watchCancel()
+	for range ch {
+	}
+
+	status, err := mgr.GetStatus(ctx, target1)
+	assert.NoError(t, err)
+	assert.Equal(t, connectivity.Ready.String(), status.State)
+	assert.Len(t, mgr.ListStatus(ctx), 1)
+
+	// Errors caused by the connection are recorded
+	client, err := mgr.GetByTarget(ctx, target1)
+	assert.NoError(t, err)
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer timeoutCancel()
+	<-timeoutCtx.Done()
+	_, err = client.Capabilities(timeoutCtx, &gpb.CapabilityRequest{})
+	assert.Error(t, err)
+	status, err = mgr.GetStatus(ctx, target1)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, status.LastError)
+	assert.NotNil(t, status.LastErrorTime)
+
+	assert.NoError(t, mgr.Disconnect(ctx, target1))
+	_, err = mgr.GetStatus(ctx, target1)
+	assert.Error(t, err)
+	assert.Len(t, mgr.ListStatus(ctx), 0)
+}
+
+func TestConnManager_SlowStatusWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr := NewConnManager().(*connManager)
+
+	// A watcher that does not read its channel
+	slowCh := make(chan ConnStatus)
+	assert.NoError(t, mgr.WatchStatus(ctx, slowCh))
+
+	// Status changes are not blocked by the slow watcher
+	for i := 0; i < statusWatcherBufferSize+2; i++ {
+		mgr.addStatus(target1, connectivity.Connecting, true)
+	}
+
+	// The slow watcher is removed once it falls behind
+	assert.Eventually(t, func() bool {
+		mgr.statusWatchersMu.RLock()
+		defer mgr.statusWatchersMu.RUnlock()
+		return len(mgr.statusWatchers) == 0
+	}, timeout, 10*time.Millisecond)
+
+	// The slow watcher's channel is closed once it is removed
+	for range slowCh {
+	}
+
+	// New watchers still receive the current status
+	ch := make(chan ConnStatus)
+	assert.NoError(t, mgr.WatchStatus(ctx, ch))
+	select {
+	case status := <-ch:
+		assert.Equal(t, topoapi.ID(target1), status.TargetID)
+		assert.Equal(t, connectivity.Connecting.String(), status.State)
+	case <-time.After(timeout):
+		t.Fatal("status not received")
+	}
+}
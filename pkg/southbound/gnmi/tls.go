@@ -0,0 +1,285 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// tlsCertificates holds the client certificate and CA bundle used for the TLS connection to a target.
+// The certificate files are checked for changes at most once per reload interval when a handshake
+// takes place, so rotated certificates are used on reconnection without restarting onos-config.
+// If reloading fails, the previously loaded certificates remain in use.
+type tlsCertificates struct {
+	targetID       topoapi.ID
+	certFile       string
+	keyFile        string
+	caFile         string
+	insecure       bool
+	reloadInterval time.Duration
+	mu             sync.RWMutex
+	certificate    *tls.Certificate
+	roots          *x509.CertPool
+	certModTime    time.Time
+	caModTime      time.Time
+	checked        time.Time
+	status         topo.CertificateStatus
+	onError        func(error)
+}
+
+// newTLSCertificates loads the certificates for the given target. Unlike a partial configuration of the
+// client certificate, a missing or invalid certificate file is an error rather than a reason to skip verification.
+func newTLSCertificates(targetID topoapi.ID, tlsOptions *topoapi.TLSOptions, certOptions *topo.CertificateOptions) (*tlsCertificates, error) {
+	if (tlsOptions.Cert == "") != (tlsOptions.Key == "") {
+		return nil, errors.NewInvalid("target %s must configure both a certificate and a key, or neither", targetID)
+	}
+	c := &tlsCertificates{
+		targetID:       targetID,
+		certFile:       tlsOptions.Cert,
+		keyFile:        tlsOptions.Key,
+		caFile:         tlsOptions.CaCert,
+		insecure:       tlsOptions.Insecure,
+		reloadInterval: time.Duration(certOptions.ReloadInterval),
+	}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newTLSConfig returns a TLS configuration using the current certificates for each handshake.
+// Built-in verification is disabled because its CA pool cannot be changed once the connection is
+// created; the server certificate is instead verified against the current CA bundle in verifyConnection.
+func (c *tlsCertificates) newTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		ServerName:           serverName,
+		InsecureSkipVerify:   true,
+		GetClientCertificate: c.getClientCertificate,
+		VerifyConnection:     c.verifyConnection,
+	}
+}
+
+// getClientCertificate returns the current client certificate
+func (c *tlsCertificates) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	c.reloadIfDue()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.certificate, nil
+}
+
+// verifyConnection verifies the certificate chain presented by the target against the current CA bundle
+func (c *tlsCertificates) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.NewUnauthorized("target %s presented no certificate", c.targetID)
+	}
+	c.reloadIfDue()
+
+	c.mu.Lock()
+	c.status.Server = newCertificateInfo(state.PeerCertificates[0])
+	roots := c.roots
+	c.mu.Unlock()
+
+	if c.insecure {
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		log.Warnf("Failed verifying certificate of target %s: %s", c.targetID, err)
+		if c.onError != nil {
+			c.onError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// getStatus returns the status of the certificates, reloading them first if they are due to be checked
+func (c *tlsCertificates) getStatus() *topo.CertificateStatus {
+	c.reloadIfDue()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	status := c.status
+	return &status
+}
+
+// reloadIfDue reloads the certificates if the reload interval has elapsed since they were last checked
+func (c *tlsCertificates) reloadIfDue() {
+	c.mu.RLock()
+	due := time.Since(c.checked) >= c.reloadInterval
+	c.mu.RUnlock()
+	if !due {
+		return
+	}
+	if err := c.reload(); err != nil {
+		log.Warnf("Failed reloading certificates for target %s, using previous certificates: %s", c.targetID, err)
+	}
+}
+
+// reload loads the certificate files that changed since they were last loaded
+func (c *tlsCertificates) reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checked = time.Now()
+	if err := c.reloadCertificate(); err != nil {
+		c.status.Error = err.Error()
+		return err
+	}
+	if err := c.reloadCA(); err != nil {
+		c.status.Error = err.Error()
+		return err
+	}
+	c.status.Error = ""
+	return nil
+}
+
+func (c *tlsCertificates) reloadCertificate() error {
+	if c.certFile == "" {
+		// Targets without a client certificate of their own are presented the default client certificate.
+		// This includes insecure targets, for which only verification of the target's certificate is skipped.
+		if c.certificate != nil {
+			return nil
+		}
+		log.Infof("Loading default client certificate for target %s", c.targetID)
+		certificate, err := tls.X509KeyPair([]byte(certs.DefaultClientCrt), []byte(certs.DefaultClientKey))
+		if err != nil {
+			return err
+		}
+		return c.setCertificate(certificate)
+	}
+
+	modTime, err := getModTime(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	if c.certificate != nil && modTime.Equal(c.certModTime) {
+		return nil
+	}
+	log.Infof("Loading client certificate %s for target %s", c.certFile, c.targetID)
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return errors.NewInvalid("failed loading client certificate %s: %v", c.certFile, err)
+	}
+	if err := c.setCertificate(certificate); err != nil {
+		return err
+	}
+	c.certModTime = modTime
+	return nil
+}
+
+func (c *tlsCertificates) setCertificate(certificate tls.Certificate) error {
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return err
+	}
+	c.certificate = &certificate
+	c.status.Client = newCertificateInfo(leaf)
+	c.status.Loaded = time.Now()
+	return nil
+}
+
+func (c *tlsCertificates) reloadCA() error {
+	if c.caFile == "" {
+		if c.roots != nil {
+			return nil
+		}
+		log.Infof("Loading default CA onfca for target %s", c.targetID)
+		return c.setCA([]byte(certs.OnfCaCrt), c.caFile)
+	}
+
+	modTime, err := getModTime(c.caFile)
+	if err != nil {
+		return err
+	}
+	if c.roots != nil && modTime.Equal(c.caModTime) {
+		return nil
+	}
+	log.Infof("Loading CA bundle %s for target %s", c.caFile, c.targetID)
+	bundle, err := ioutil.ReadFile(c.caFile)
+	if err != nil {
+		return err
+	}
+	if err := c.setCA(bundle, c.caFile); err != nil {
+		return err
+	}
+	c.caModTime = modTime
+	return nil
+}
+
+func (c *tlsCertificates) setCA(bundle []byte, caFile string) error {
+	roots := x509.NewCertPool()
+	var earliest *x509.Certificate
+	for block, rest := pem.Decode(bundle); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return errors.NewInvalid("invalid certificate in CA bundle %s: %v", caFile, err)
+		}
+		roots.AddCert(cert)
+		if earliest == nil || cert.NotAfter.Before(earliest.NotAfter) {
+			earliest = cert
+		}
+	}
+	if earliest == nil {
+		return errors.NewInvalid("no certificates found in CA bundle %s", caFile)
+	}
+	c.roots = roots
+	c.status.CA = newCertificateInfo(earliest)
+	c.status.Loaded = time.Now()
+	return nil
+}
+
+// getModTime returns the latest modification time of the given files
+func getModTime(files ...string) (time.Time, error) {
+	var modTime time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func newCertificateInfo(cert *x509.Certificate) *topo.CertificateInfo {
+	return &topo.CertificateInfo{
+		Subject:  cert.Subject.String(),
+		NotAfter: cert.NotAfter,
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, name string, notAfter time.Time, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	assert.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	if keyFile != "" {
+		keyBytes, err := x509.MarshalECPrivateKey(c.key)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	}
+}
+
+func TestTLSCertificates(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+
+	expiry := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	ca := newTestCert(t, "ca", expiry.Add(time.Hour), nil)
+	ca.write(t, caFile, "")
+	newTestCert(t, "client", expiry, ca).write(t, certFile, keyFile)
+
+	// A partially configured client certificate fails rather than falling back to an insecure connection
+	_, err := newTLSCertificates("target-1", &topoapi.TLSOptions{CaCert: caFile, Cert: certFile}, &topo.CertificateOptions{})
+	assert.Error(t, err)
+	_, err = newTLSCertificates("target-1", &topoapi.TLSOptions{CaCert: filepath.Join(dir, "missing.crt")}, &topo.CertificateOptions{})
+	assert.Error(t, err)
+
+	certs, err := newTLSCertificates("target-1", &topoapi.TLSOptions{
+		CaCert: caFile,
+		Cert:   certFile,
+		Key:    keyFile,
+	}, &topo.CertificateOptions{
+		ReloadInterval: topo.Duration(time.Hour),
+	})
+	assert.NoError(t, err)
+	status := certs.getStatus()
+	assert.Equal(t, "CN=client", status.Client.Subject)
+	assert.True(t, expiry.Equal(status.Client.NotAfter))
+	assert.Equal(t, "CN=ca", status.CA.Subject)
+	assert.Empty(t, status.Error)
+
+	// Verify the server certificate against the CA bundle and the server name
+	server := newTestCert(t, "target-1.example.com", expiry, ca)
+	state := tls.ConnectionState{
+		ServerName:       "target-1.example.com",
+		PeerCertificates: []*x509.Certificate{server.cert},
+	}
+	assert.NoError(t, certs.verifyConnection(state))
+	assert.Equal(t, "CN=target-1.example.com", certs.getStatus().Server.Subject)
+	state.ServerName = "target-2.example.com"
+	assert.Error(t, certs.verifyConnection(state))
+	state = tls.ConnectionState{
+		ServerName:       "target-1.example.com",
+		PeerCertificates: []*x509.Certificate{newTestCert(t, "target-1.example.com", expiry, nil).cert},
+	}
+	assert.Error(t, certs.verifyConnection(state))
+
+	// Rotate the client certificate and force a reload
+	rotatedExpiry := expiry.Add(24 * time.Hour)
+	newTestCert(t, "rotated", rotatedExpiry, ca).write(t, certFile, keyFile)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, later, later))
+	certs.reloadInterval = 0
+	cert, err := certs.getClientCertificate(nil)
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", leaf.Subject.CommonName)
+	assert.True(t, rotatedExpiry.Equal(certs.getStatus().Client.NotAfter))
+
+	// A failed reload keeps the previous certificates and reports the error
+	assert.NoError(t, os.Remove(caFile))
+	status = certs.getStatus()
+	assert.NotEmpty(t, status.Error)
+	assert.Equal(t, "CN=ca", status.CA.Subject)
+	cert, err = certs.getClientCertificate(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+}
+
+func TestDefaultTLSCertificates(t *testing.T) {
+	certs, err := newTLSCertificates("target-1", &topoapi.TLSOptions{}, &topo.CertificateOptions{})
+	assert.NoError(t, err)
+	status := certs.getStatus()
+	assert.NotNil(t, status.Client)
+	assert.NotNil(t, status.CA)
+
+	// Insecure targets are not verified
+	certs, err = newTLSCertificates("target-1", &topoapi.TLSOptions{Insecure: true}, &topo.CertificateOptions{})
+	assert.NoError(t, err)
+	ca := newTestCert(t, "ca", time.Now().Add(time.Hour), nil)
+	assert.NoError(t, certs.verifyConnection(tls.ConnectionState{
+		ServerName:       "target-1",
+		PeerCertificates: []*x509.Certificate{newTestCert(t, "other", time.Now().Add(time.Hour), ca).cert},
+	}))
+
+	// Insecure targets are presented the default client certificate, as servers may still require one
+	cert, err := certs.getClientCertificate(nil)
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	assert.Equal(t, status.Client.Subject, leaf.Subject.String())
+}
+
+func TestInsecureTLSCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	ca := newTestCert(t, "ca", time.Now().Add(time.Hour), nil)
+	newTestCert(t, "client", time.Now().Add(time.Hour), ca).write(t, certFile, keyFile)
+
+	// A client certificate configured for an insecure target is presented to it
+	certs, err := newTLSCertificates("target-1", &topoapi.TLSOptions{
+		Insecure: true,
+		Cert:     certFile,
+		Key:      keyFile,
+	}, &topo.CertificateOptions{})
+	assert.NoError(t, err)
+	cert, err := certs.getClientCertificate(nil)
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "client", leaf.Subject.CommonName)
+}
@@ -16,19 +16,10 @@ package gnmi
 
 import (
 	"context"
-	"crypto/tls"
 
 	"google.golang.org/grpc/credentials"
 )
 
-func setCertificate(pathCert string, pathKey string) tls.Certificate {
-	certificate, err := tls.LoadX509KeyPair(pathCert, pathKey)
-	if err != nil {
-		log.Error("could not load client key pair ", err)
-	}
-	return certificate
-}
-
 // passCred is an username/password implementation of credentials.Credentials.
 type passCred struct {
 	username string
@@ -0,0 +1,264 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	baseClient "github.com/openconfig/gnmi/client"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var log = logging.GetLogger("southbound", "netconf")
+
+const (
+	// closeTimeout is the time to wait for the target to acknowledge the closing of a session
+	closeTimeout = 5 * time.Second
+	// unlockTimeout is the time to wait for the target to release the lock on the candidate datastore
+	unlockTimeout = 5 * time.Second
+)
+
+// newClient returns a gNMI client translating requests into NETCONF operations on the given session
+func newClient(targetID topoapi.ID, session *session, schema *schema, datastore topo.NETCONFDatastore) (*client, error) {
+	if datastore == topo.NETCONFDatastoreCandidate && !session.hasCapability(capabilityCandidate) {
+		return nil, errors.NewNotSupported("target '%s' does not support the candidate datastore", targetID)
+	}
+	return &client{
+		targetID:  targetID,
+		session:   session,
+		schema:    schema,
+		datastore: datastore,
+	}, nil
+}
+
+// client is a gNMI client for a NETCONF target
+type client struct {
+	targetID   topoapi.ID
+	session    *session
+	schema     *schema
+	datastore  topo.NETCONFDatastore
+	getTimeout time.Duration
+	setTimeout time.Duration
+}
+
+// Capabilities returns the YANG modules advertised by the target in its hello message
+func (c *client) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	models := make([]*gpb.ModelData, 0)
+	for _, capability := range c.session.capabilities {
+		i := strings.Index(capability, "?")
+		if i < 0 {
+			continue
+		}
+		params, err := url.ParseQuery(capability[i+1:])
+		if err != nil || params.Get("module") == "" {
+			continue
+		}
+		models = append(models, &gpb.ModelData{
+			Name:    params.Get("module"),
+			Version: params.Get("revision"),
+		})
+	}
+	return &gpb.CapabilityResponse{
+		SupportedModels:    models,
+		SupportedEncodings: []gpb.Encoding{gpb.Encoding_PROTO},
+	}, nil
+}
+
+// CapabilitiesWithString allows a request for the capabilities by a string - can be empty
+func (c *client) CapabilitiesWithString(ctx context.Context, request string) (*gpb.CapabilityResponse, error) {
+	r := &gpb.CapabilityRequest{}
+	if err := proto.UnmarshalText(request, r); err != nil {
+		return nil, errors.NewInvalid("unable to unmarshal gnmi.CapabilityRequest from %v : %v", request, err)
+	}
+	return c.Capabilities(ctx, r)
+}
+
+// Get reads the requested paths with get-config for CONFIG requests and with get for all other requests.
+// The leaves in the data returned by the target are converted to updates typed by the model.
+func (c *client) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	if c.getTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.getTimeout)
+		defer cancel()
+	}
+
+	filter, err := newSubtreeFilter(c.schema, req.Prefix, req.Path)
+	if err != nil {
+		return nil, err
+	}
+	var filterElement string
+	if filter != nil {
+		filterElement = fmt.Sprintf(`<filter type="subtree">%s</filter>`, filter)
+	}
+
+	var operation string
+	if req.Type == gpb.GetRequest_CONFIG {
+		operation = fmt.Sprintf("<get-config><source><running/></source>%s</get-config>", filterElement)
+	} else {
+		operation = fmt.Sprintf("<get>%s</get>", filterElement)
+	}
+	data, err := c.session.rpc(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := parseData(data)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := c.schema.newUpdates(elements, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	target := req.GetPrefix().GetTarget()
+	if target == "" {
+		target = string(c.targetID)
+	}
+	return &gpb.GetResponse{
+		Notification: []*gpb.Notification{
+			{
+				Timestamp: time.Now().UnixNano(),
+				Prefix:    &gpb.Path{Target: target},
+				Update:    updates,
+			},
+		},
+	}, nil
+}
+
+// GetWithString can make a get request based on a given a string request - can be empty
+func (c *client) GetWithString(ctx context.Context, request string) (*gpb.GetResponse, error) {
+	if request == "" {
+		return nil, errors.NewInvalid("cannot get an empty request")
+	}
+	r := &gpb.GetRequest{}
+	if err := proto.UnmarshalText(request, r); err != nil {
+		return nil, errors.NewInvalid("unable to unmarshal gnmi getRequest from %v : %v", request, err)
+	}
+	return c.Get(ctx, r)
+}
+
+// Set applies the changes in the request to the configured datastore in a single edit-config operation.
+// Changes written to the candidate datastore are committed to the running datastore. The candidate
+// datastore is locked while it is edited and committed so that changes made by other sessions are
+// neither committed nor discarded along with ours.
+// Extensions such as master arbitration have no NETCONF equivalent and are ignored.
+func (c *client) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if c.setTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.setTimeout)
+		defer cancel()
+	}
+
+	config, err := newEditConfig(c.schema, req)
+	if err != nil {
+		return nil, err
+	}
+	var errorOption string
+	if c.session.hasCapability(capabilityRollbackOnError) {
+		errorOption = "<error-option>rollback-on-error</error-option>"
+	}
+	operation := fmt.Sprintf(`<edit-config><target><%s/></target>%s<config xmlns:nc="%s">%s</config></edit-config>`,
+		c.datastore, errorOption, baseNamespace, config)
+	if c.datastore == topo.NETCONFDatastoreCandidate {
+		if _, err := c.session.rpc(ctx, "<lock><target><candidate/></target></lock>"); err != nil {
+			return nil, err
+		}
+		defer c.unlockCandidate()
+	}
+	if _, err := c.session.rpc(ctx, operation); err != nil {
+		if c.datastore == topo.NETCONFDatastoreCandidate {
+			c.discardChanges(ctx)
+		}
+		return nil, err
+	}
+	if c.datastore == topo.NETCONFDatastoreCandidate {
+		if _, err := c.session.rpc(ctx, "<commit/>"); err != nil {
+			c.discardChanges(ctx)
+			return nil, err
+		}
+	}
+
+	results := make([]*gpb.UpdateResult, 0, len(req.Delete)+len(req.Replace)+len(req.Update))
+	for _, path := range req.Delete {
+		results = append(results, &gpb.UpdateResult{Path: path, Op: gpb.UpdateResult_DELETE})
+	}
+	for _, update := range req.Replace {
+		results = append(results, &gpb.UpdateResult{Path: update.Path, Op: gpb.UpdateResult_REPLACE})
+	}
+	for _, update := range req.Update {
+		results = append(results, &gpb.UpdateResult{Path: update.Path, Op: gpb.UpdateResult_UPDATE})
+	}
+	return &gpb.SetResponse{
+		Prefix:    req.Prefix,
+		Response:  results,
+		Timestamp: time.Now().UnixNano(),
+	}, nil
+}
+
+// unlockCandidate releases the lock on the candidate datastore. The lock is released even if the
+// context of the change has expired, since the target would otherwise hold it until the session is closed.
+func (c *client) unlockCandidate() {
+	ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+	defer cancel()
+	if _, err := c.session.rpc(ctx, "<unlock><target><candidate/></target></unlock>"); err != nil {
+		log.Warnf("Failed releasing the candidate datastore lock on target '%s'", c.targetID, err)
+	}
+}
+
+// discardChanges reverts the candidate datastore to the running datastore after a failed change
+func (c *client) discardChanges(ctx context.Context) {
+	if _, err := c.session.rpc(ctx, "<discard-changes/>"); err != nil {
+		log.Warnf("Failed discarding candidate changes on target '%s'", c.targetID, err)
+	}
+}
+
+// SetWithString can make a set request based on a given string request
+func (c *client) SetWithString(ctx context.Context, request string) (*gpb.SetResponse, error) {
+	if request == "" {
+		return nil, errors.NewInvalid("cannot set an empty request")
+	}
+	r := &gpb.SetRequest{}
+	if err := proto.UnmarshalText(request, r); err != nil {
+		return nil, errors.NewInvalid("unable to unmarshal gnmi set request from %v: %v", request, err)
+	}
+	return c.Set(ctx, r)
+}
+
+// Subscribe is not supported for NETCONF targets
+func (c *client) Subscribe(ctx context.Context, q baseClient.Query) error {
+	return errors.NewNotSupported("subscriptions are not supported for NETCONF target '%s'", c.targetID)
+}
+
+// SubscribeStream is not supported for NETCONF targets
+func (c *client) SubscribeStream(ctx context.Context, req *gpb.SubscribeRequest) (gpb.GNMI_SubscribeClient, error) {
+	return nil, errors.NewNotSupported("subscriptions are not supported for NETCONF target '%s'", c.targetID)
+}
+
+// Close closes the NETCONF session
+func (c *client) Close() error {
+	return c.session.Close()
+}
+
+var _ gnmi.Client = &client{}
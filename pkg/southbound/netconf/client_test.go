@@ -0,0 +1,180 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+const testData = `<interfaces xmlns="urn:test:interfaces">` +
+	`<interface><name>eth1</name><config><enabled>true</enabled><mtu>1500</mtu></config>` +
+	`<state><oper-status>UP</oper-status></state></interface>` +
+	`</interfaces>` +
+	`<system xmlns="urn:test:system"><config>` +
+	`<hostname>switch-1</hostname><dns-servers>1.1.1.1</dns-servers><dns-servers>8.8.8.8</dns-servers>` +
+	`</config></system>`
+
+func newTestClient(t *testing.T, server *testServer, datastore topo.NETCONFDatastore) (*client, chan error, error) {
+	clientConn, serverConn := net.Pipe()
+	go func() {
+		_ = server.serve(serverConn)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	closeCh := make(chan error, 1)
+	session, err := newSession(ctx, clientConn, func(err error) {
+		closeCh <- err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), session.id)
+	client, err := newClient("target-1", session, newSchema(newTestModelInfo(), testNamespaces), datastore)
+	if err != nil {
+		session.close(err)
+	}
+	return client, closeCh, err
+}
+
+func TestClientGet(t *testing.T) {
+	server := &testServer{
+		capabilities: []string{"urn:test:interfaces?module=test-interfaces&revision=2022-01-01"},
+		data:         testData,
+	}
+	client, closeCh, err := newTestClient(t, server, topo.NETCONFDatastoreRunning)
+	assert.NoError(t, err)
+
+	capabilities, err := client.Capabilities(context.Background(), &gpb.CapabilityRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, capabilities.SupportedModels, 1)
+	assert.Equal(t, "test-interfaces", capabilities.SupportedModels[0].Name)
+	assert.Equal(t, "2022-01-01", capabilities.SupportedModels[0].Version)
+
+	resp, err := client.Get(context.Background(), &gpb.GetRequest{
+		Path: []*gpb.Path{{}},
+		Type: gpb.GetRequest_CONFIG,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Notification, 1)
+	assert.Equal(t, "target-1", resp.Notification[0].Prefix.Target)
+
+	values := make(map[string]*gpb.TypedValue)
+	for _, update := range resp.Notification[0].Update {
+		values[utils.StrPath(update.Path)] = update.Val
+	}
+	assert.Len(t, values, 6)
+	assert.Equal(t, "eth1", values["/interfaces/interface[name=eth1]/name"].GetStringVal())
+	assert.Equal(t, true, values["/interfaces/interface[name=eth1]/config/enabled"].GetBoolVal())
+	assert.Equal(t, uint64(1500), values["/interfaces/interface[name=eth1]/config/mtu"].GetUintVal())
+	assert.Equal(t, "UP", values["/interfaces/interface[name=eth1]/state/oper-status"].GetStringVal())
+	assert.Equal(t, "switch-1", values["/system/config/hostname"].GetStringVal())
+	dnsServers := values["/system/config/dns-servers"].GetLeaflistVal()
+	assert.NotNil(t, dnsServers)
+	assert.Len(t, dnsServers.Element, 2)
+	assert.Equal(t, "8.8.8.8", dnsServers.Element[1].GetStringVal())
+	assert.Equal(t, []string{"get-config"}, server.getOperations())
+
+	_, err = client.Get(context.Background(), &gpb.GetRequest{
+		Path: []*gpb.Path{newTestPath(t, "/interfaces/interface[name=eth1]/state")},
+		Type: gpb.GetRequest_STATE,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"get-config", "get"}, server.getOperations())
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, <-closeCh)
+	assert.Equal(t, []string{"get-config", "get", "close-session"}, server.getOperations())
+}
+
+func TestClientSet(t *testing.T) {
+	server := &testServer{}
+	client, _, err := newTestClient(t, server, topo.NETCONFDatastoreRunning)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	req := &gpb.SetRequest{
+		Update: []*gpb.Update{
+			{
+				Path: newTestPath(t, "/system/config/hostname"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "switch-2"}},
+			},
+		},
+	}
+	resp, err := client.Set(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Response, 1)
+	assert.Equal(t, gpb.UpdateResult_UPDATE, resp.Response[0].Op)
+	edits := server.getEdits()
+	assert.Len(t, edits, 1)
+	assert.Contains(t, edits[0], "<target><running/></target>")
+	assert.Contains(t, edits[0], `<system xmlns="urn:test:system"><config><hostname>switch-2</hostname></config></system>`)
+
+	server.mu.Lock()
+	server.editError = `<rpc-error><error-type>application</error-type><error-tag>data-exists</error-tag>` +
+		`<error-severity>error</error-severity><error-message>hostname exists</error-message></rpc-error>`
+	server.mu.Unlock()
+	_, err = client.Set(context.Background(), req)
+	assert.True(t, errors.IsAlreadyExists(err))
+	assert.Equal(t, codes.AlreadyExists, errors.Status(err).Code())
+}
+
+func TestClientSetCandidate(t *testing.T) {
+	_, _, err := newTestClient(t, &testServer{}, topo.NETCONFDatastoreCandidate)
+	assert.True(t, errors.IsNotSupported(err))
+
+	server := &testServer{
+		capabilities: []string{capabilityCandidate},
+	}
+	client, _, err := newTestClient(t, server, topo.NETCONFDatastoreCandidate)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	req := &gpb.SetRequest{
+		Delete: []*gpb.Path{newTestPath(t, "/interfaces/interface[name=eth1]")},
+	}
+	_, err = client.Set(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"lock", "edit-config", "commit", "unlock"}, server.getOperations())
+	assert.Contains(t, server.getEdits()[0], "<target><candidate/></target>")
+
+	server.mu.Lock()
+	server.editError = `<rpc-error><error-type>protocol</error-type><error-tag>lock-denied</error-tag>` +
+		`<error-severity>error</error-severity></rpc-error>`
+	server.mu.Unlock()
+	_, err = client.Set(context.Background(), req)
+	assert.True(t, errors.IsConflict(err))
+	assert.Equal(t, []string{"lock", "edit-config", "commit", "unlock", "lock", "edit-config", "discard-changes", "unlock"},
+		server.getOperations())
+
+	// Nothing is written to the candidate datastore if it is locked by another session
+	server.mu.Lock()
+	server.editError = ""
+	server.lockError = `<rpc-error><error-type>protocol</error-type><error-tag>lock-denied</error-tag>` +
+		`<error-severity>error</error-severity><error-info><session-id>2</session-id></error-info></rpc-error>`
+	server.operations = nil
+	server.mu.Unlock()
+	_, err = client.Set(context.Background(), req)
+	assert.True(t, errors.IsConflict(err))
+	assert.Equal(t, []string{"lock"}, server.getOperations())
+}
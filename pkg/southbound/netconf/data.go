@@ -0,0 +1,208 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// xmlElement is an element of XML data received from a target
+type xmlElement struct {
+	name     string
+	text     string
+	children []*xmlElement
+}
+
+// parseData parses the content of the data element of an rpc-reply
+func parseData(data []byte) ([]*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlElement{}
+	stack := []*xmlElement{root}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.NewInvalid("invalid data: %v", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, element)
+			stack = append(stack, element)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, errors.NewInvalid("invalid data: unexpected end element %s", t.Name.Local)
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			element := stack[len(stack)-1]
+			element.text += string(t)
+		}
+	}
+	return root.children, nil
+}
+
+// newUpdates converts XML data to gNMI updates of the leaves in the data.
+// List keys and value types are determined by the schema.
+func (s *schema) newUpdates(elements []*xmlElement, parent []*gpb.PathElem, parentSchemaPath string) ([]*gpb.Update, error) {
+	var updates []*gpb.Update
+
+	// Leaves with the same name are the values of a leaf-list
+	var leafNames []string
+	leafValues := make(map[string][]string)
+	for _, element := range elements {
+		schemaPath := parentSchemaPath + "/" + element.name
+		if len(element.children) == 0 {
+			// Skip empty containers and lists
+			if s.modeled && s.hasNode(schemaPath) && !s.isLeaf(schemaPath) {
+				continue
+			}
+			if _, ok := leafValues[element.name]; !ok {
+				leafNames = append(leafNames, element.name)
+			}
+			leafValues[element.name] = append(leafValues[element.name], strings.TrimSpace(element.text))
+			continue
+		}
+
+		elem := &gpb.PathElem{Name: element.name}
+		if keyNames := s.getKeyNames(schemaPath); len(keyNames) > 0 {
+			elem.Key = make(map[string]string)
+			for _, child := range element.children {
+				for _, keyName := range keyNames {
+					if child.name == keyName {
+						elem.Key[keyName] = strings.TrimSpace(child.text)
+					}
+				}
+			}
+		}
+		childUpdates, err := s.newUpdates(element.children, appendElem(parent, elem), schemaPath)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, childUpdates...)
+	}
+
+	for _, name := range leafNames {
+		value := newTypedValue(leafValues[name], s.getValueType(parentSchemaPath+"/"+name))
+		updates = append(updates, &gpb.Update{
+			Path: &gpb.Path{Elem: appendElem(parent, &gpb.PathElem{Name: name})},
+			Val:  value,
+		})
+	}
+	return updates, nil
+}
+
+// newTypedValue converts the text of one or more XML elements to a gNMI value of the given type.
+// Text that cannot be converted to the given type is returned as a string.
+func newTypedValue(values []string, valueType configapi.ValueType) *gpb.TypedValue {
+	var scalarType configapi.ValueType
+	switch valueType {
+	case configapi.ValueType_LEAFLIST_STRING:
+		scalarType = configapi.ValueType_STRING
+	case configapi.ValueType_LEAFLIST_INT:
+		scalarType = configapi.ValueType_INT
+	case configapi.ValueType_LEAFLIST_UINT:
+		scalarType = configapi.ValueType_UINT
+	case configapi.ValueType_LEAFLIST_BOOL:
+		scalarType = configapi.ValueType_BOOL
+	case configapi.ValueType_LEAFLIST_DECIMAL:
+		scalarType = configapi.ValueType_DECIMAL
+	case configapi.ValueType_LEAFLIST_FLOAT:
+		scalarType = configapi.ValueType_FLOAT
+	case configapi.ValueType_LEAFLIST_BYTES:
+		scalarType = configapi.ValueType_BYTES
+	default:
+		if len(values) == 1 {
+			return newScalarValue(values[0], valueType)
+		}
+		scalarType = valueType
+	}
+
+	elements := make([]*gpb.TypedValue, len(values))
+	for i, value := range values {
+		elements[i] = newScalarValue(value, scalarType)
+	}
+	return &gpb.TypedValue{
+		Value: &gpb.TypedValue_LeaflistVal{
+			LeaflistVal: &gpb.ScalarArray{
+				Element: elements,
+			},
+		},
+	}
+}
+
+// newScalarValue converts the text of an XML element to a gNMI scalar value of the given type
+func newScalarValue(value string, valueType configapi.ValueType) *gpb.TypedValue {
+	switch valueType {
+	case configapi.ValueType_INT:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}
+		}
+	case configapi.ValueType_UINT:
+		if u, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: u}}
+		}
+	case configapi.ValueType_BOOL:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: b}}
+		}
+	case configapi.ValueType_DECIMAL:
+		if d, ok := parseDecimal(value); ok {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_DecimalVal{DecimalVal: d}}
+		}
+	case configapi.ValueType_FLOAT:
+		if f, err := strconv.ParseFloat(value, 32); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_FloatVal{FloatVal: float32(f)}}
+		}
+	case configapi.ValueType_BYTES:
+		if b, err := base64.StdEncoding.DecodeString(value); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: b}}
+		}
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: value}}
+}
+
+// parseDecimal parses a decimal64 value
+func parseDecimal(value string) (*gpb.Decimal64, bool) {
+	whole, fraction := value, ""
+	if i := strings.Index(value, "."); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+	digits, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &gpb.Decimal64{
+		Digits:    digits,
+		Precision: uint32(len(fraction)),
+	}, true
+}
+
+func appendElem(elems []*gpb.PathElem, elem *gpb.PathElem) []*gpb.PathElem {
+	appended := make([]*gpb.PathElem, 0, len(elems)+1)
+	appended = append(appended, elems...)
+	return append(appended, elem)
+}
@@ -0,0 +1,199 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// netconfSubsystem is the SSH subsystem over which NETCONF is served
+	netconfSubsystem = "netconf"
+	// defaultTimeout is the default timeout for establishing a session
+	defaultTimeout = 60 * time.Second
+)
+
+// NewDialer returns a dialer opening NETCONF sessions over SSH to targets managed with NETCONF.
+// Changes are translated to edit-config operations using the schema of the target's model plugin.
+func NewDialer(pluginRegistry pluginregistry.PluginRegistry) gnmi.Dialer {
+	return func(ctx context.Context, target *topoapi.Object, creds *gnmi.Credentials, onClose func(error)) (gnmi.Client, error) {
+		configurable := &topoapi.Configurable{}
+		if err := target.GetAspect(configurable); err != nil {
+			return nil, errors.NewInvalid("target entity %s must have 'onos.topo.Configurable' aspect to work with onos-config", target.ID)
+		}
+		southboundOptions, err := topo.GetSouthboundOptions(target)
+		if err != nil {
+			return nil, err
+		}
+		connOptions, err := topo.GetConnectionOptions(target)
+		if err != nil {
+			return nil, err
+		}
+		hostKeyCallback, err := newHostKeyCallback(target, southboundOptions.NETCONF.HostKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		var modelInfo *pluginregistry.ModelPluginInfo
+		if plugin, ok := pluginRegistry.GetPlugin(configapi.TargetType(configurable.Type), configapi.TargetVersion(configurable.Version)); ok {
+			modelInfo = plugin.GetInfo()
+		} else {
+			log.Warnf("No model plugin found for target '%s'; paths will not be validated", target.ID)
+		}
+
+		timeout := defaultTimeout
+		if configurable.Timeout != nil {
+			timeout = *configurable.Timeout
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		config := &ssh.ClientConfig{
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         timeout,
+		}
+		if creds != nil {
+			config.User = creds.Username
+			config.Auth = []ssh.AuthMethod{ssh.Password(creds.Password)}
+		}
+
+		log.Infof("Connecting to NETCONF target '%s' at %s", target.ID, configurable.Address)
+		stream, err := dialSSH(ctx, configurable.Address, config)
+		if err != nil {
+			return nil, err
+		}
+		session, err := newSession(ctx, stream, onClose)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			// Report the closing of the SSH connection by either end
+			err := stream.client.Wait()
+			session.close(errors.NewUnavailable("connection closed: %v", err))
+		}()
+
+		client, err := newClient(target.ID, session, newSchema(modelInfo, southboundOptions.NETCONF.Namespaces), southboundOptions.NETCONF.Datastore)
+		if err != nil {
+			session.close(err)
+			return nil, err
+		}
+		client.getTimeout = time.Duration(connOptions.GetTimeout)
+		client.setTimeout = time.Duration(connOptions.SetTimeout)
+		log.Infof("Opened NETCONF session %d to target '%s'", session.id, target.ID)
+		return client, nil
+	}
+}
+
+// sshStream is the stream of the NETCONF subsystem of an SSH session
+type sshStream struct {
+	io.Reader
+	io.WriteCloser
+	client  *ssh.Client
+	session *ssh.Session
+}
+
+// Close closes the SSH session and connection
+func (s *sshStream) Close() error {
+	_ = s.WriteCloser.Close()
+	_ = s.session.Close()
+	return s.client.Close()
+}
+
+// dialSSH opens an SSH connection to the given address and starts the NETCONF subsystem
+func dialSSH(ctx context.Context, address string, config *ssh.ClientConfig) (*sshStream, error) {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.NewUnavailable("failed connecting to %s: %v", address, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.NewUnauthorized("failed establishing SSH connection to %s: %v", address, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	client := ssh.NewClient(sshConn, chans, reqs)
+
+	session, err := client.NewSession()
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.NewUnavailable("failed opening SSH session to %s: %v", address, err)
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.NewInternal(err.Error())
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.NewInternal(err.Error())
+	}
+	if err := session.RequestSubsystem(netconfSubsystem); err != nil {
+		_ = client.Close()
+		return nil, errors.NewNotSupported("failed starting NETCONF subsystem on %s: %v", address, err)
+	}
+	return &sshStream{
+		Reader:      stdout,
+		WriteCloser: stdin,
+		client:      client,
+		session:     session,
+	}, nil
+}
+
+// newHostKeyCallback returns a callback accepting the given host keys. If no host keys are configured,
+// any host key is accepted only if the target's TLS options are insecure.
+func newHostKeyCallback(target *topoapi.Object, hostKeys []string) (ssh.HostKeyCallback, error) {
+	if len(hostKeys) == 0 {
+		tlsOptions := &topoapi.TLSOptions{}
+		if err := target.GetAspect(tlsOptions); err == nil && tlsOptions.Insecure {
+			log.Warnf("Accepting any SSH host key for insecure target '%s'", target.ID)
+			return ssh.InsecureIgnoreHostKey(), nil
+		}
+		return nil, errors.NewInvalid("no SSH host keys configured for target '%s'", target.ID)
+	}
+
+	keys := make([]ssh.PublicKey, 0, len(hostKeys))
+	for _, hostKey := range hostKeys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, errors.NewInvalid("invalid SSH host key for target '%s': %v", target.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		for _, hostKey := range keys {
+			if hostKey.Type() == key.Type() && bytes.Equal(hostKey.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return errors.NewForbidden("SSH host key of target '%s' is not trusted", target.ID)
+	}, nil
+}
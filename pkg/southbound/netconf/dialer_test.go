@@ -0,0 +1,128 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	gnmitest "github.com/onosproject/onos-config/pkg/northbound/gnmi/test"
+	"github.com/onosproject/onos-config/pkg/southbound/gnmi"
+	"github.com/onosproject/onos-config/pkg/store/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+	return signer
+}
+
+func newTestTarget(t *testing.T, address string, options topo.SouthboundOptions) *topoapi.Object {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+	assert.NoError(t, target.SetAspect(&topoapi.Configurable{
+		Type:    "test",
+		Version: "1.0.0",
+		Address: address,
+	}))
+	bytes, err := json.Marshal(options)
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspectBytes(topo.SouthboundAspect, bytes))
+	return target
+}
+
+func TestDialer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	plugin := gnmitest.NewMockModelPlugin(ctrl)
+	plugin.EXPECT().GetInfo().AnyTimes().Return(newTestModelInfo())
+	registry := gnmitest.NewMockPluginRegistry(ctrl)
+	registry.EXPECT().GetPlugin(configapi.TargetType("test"), configapi.TargetVersion("1.0.0")).AnyTimes().Return(plugin, true)
+
+	hostKey := newHostKey(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer lis.Close()
+	server := &testServer{
+		data: testData,
+	}
+	go server.serveSSH(t, lis, hostKey, "secret")
+
+	dialer := NewDialer(registry)
+	creds := &gnmi.Credentials{Username: "admin", Password: "secret"}
+	options := topo.SouthboundOptions{
+		Protocol: topo.SouthboundProtocolNETCONF,
+		NETCONF: topo.NETCONFOptions{
+			Namespaces: testNamespaces,
+			HostKeys:   []string{string(ssh.MarshalAuthorizedKey(hostKey.PublicKey()))},
+		},
+	}
+	target := newTestTarget(t, lis.Addr().String(), options)
+
+	closeCh := make(chan error, 1)
+	client, err := dialer(context.Background(), target, creds, func(err error) {
+		closeCh <- err
+	})
+	assert.NoError(t, err)
+	resp, err := client.Get(context.Background(), &gpb.GetRequest{
+		Path: []*gpb.Path{newTestPath(t, "/system/config/hostname")},
+		Type: gpb.GetRequest_CONFIG,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Notification, 1)
+	assert.NotEmpty(t, resp.Notification[0].Update)
+	assert.NoError(t, client.Close())
+	assert.NoError(t, <-closeCh)
+	assert.Equal(t, []string{"get-config", "close-session"}, server.getOperations())
+
+	// Wrong credentials are rejected
+	_, err = dialer(context.Background(), target, &gnmi.Credentials{Username: "admin", Password: "wrong"}, nil)
+	assert.True(t, errors.IsUnauthorized(err))
+
+	// Host keys other than the configured keys are rejected
+	untrustedOptions := options
+	untrustedOptions.NETCONF.HostKeys = []string{string(ssh.MarshalAuthorizedKey(newHostKey(t).PublicKey()))}
+	_, err = dialer(context.Background(), newTestTarget(t, lis.Addr().String(), untrustedOptions), creds, nil)
+	assert.Error(t, err)
+
+	// Host keys must be configured unless the target is insecure
+	insecureOptions := options
+	insecureOptions.NETCONF.HostKeys = nil
+	insecureTarget := newTestTarget(t, lis.Addr().String(), insecureOptions)
+	_, err = dialer(context.Background(), insecureTarget, creds, nil)
+	assert.True(t, errors.IsInvalid(err))
+
+	assert.NoError(t, insecureTarget.SetAspect(&topoapi.TLSOptions{Insecure: true}))
+	client, err = dialer(context.Background(), insecureTarget, creds, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Close())
+}
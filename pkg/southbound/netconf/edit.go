@@ -0,0 +1,421 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	operationReplace = "replace"
+	operationRemove  = "remove"
+)
+
+// xmlNode is a node of an XML tree built from gNMI paths
+type xmlNode struct {
+	name      string
+	namespace string
+	keys      map[string]string
+	operation string
+	values    []string
+	leaf      bool
+	children  []*xmlNode
+}
+
+// getChild returns the child with the given name and keys, adding it if it does not exist.
+// The key leaves of a new list entry are added before any other children, as required by RFC 7950.
+func (n *xmlNode) getChild(name string, keys map[string]string, keyNames []string) *xmlNode {
+	for _, child := range n.children {
+		if child.name == name && !child.leaf && equalKeys(child.keys, keys) {
+			return child
+		}
+	}
+	child := &xmlNode{
+		name: name,
+		keys: keys,
+	}
+	if len(keyNames) != len(keys) {
+		keyNames = make([]string, 0, len(keys))
+		for keyName := range keys {
+			keyNames = append(keyNames, keyName)
+		}
+		sort.Strings(keyNames)
+	}
+	for _, keyName := range keyNames {
+		if value, ok := keys[keyName]; ok && value != "*" {
+			child.children = append(child.children, &xmlNode{
+				name:   keyName,
+				values: []string{value},
+				leaf:   true,
+			})
+		}
+	}
+	n.children = append(n.children, child)
+	return child
+}
+
+// setLeaf sets the values of the leaf with the given name, replacing any previous values
+func (n *xmlNode) setLeaf(name string, values []string) *xmlNode {
+	for _, child := range n.children {
+		if child.name == name && child.leaf {
+			child.values = values
+			return child
+		}
+	}
+	child := &xmlNode{
+		name:   name,
+		values: values,
+		leaf:   true,
+	}
+	n.children = append(n.children, child)
+	return child
+}
+
+// write writes the node and its children
+func (n *xmlNode) write(b *bytes.Buffer) {
+	if n.leaf && len(n.values) > 0 {
+		for _, value := range n.values {
+			n.writeStart(b)
+			_ = xml.EscapeText(b, []byte(value))
+			fmt.Fprintf(b, "</%s>", n.name)
+		}
+		return
+	}
+	n.writeStart(b)
+	for _, child := range n.children {
+		child.write(b)
+	}
+	fmt.Fprintf(b, "</%s>", n.name)
+}
+
+func (n *xmlNode) writeStart(b *bytes.Buffer) {
+	b.WriteString("<")
+	b.WriteString(n.name)
+	if n.namespace != "" {
+		b.WriteString(` xmlns="`)
+		_ = xml.EscapeText(b, []byte(n.namespace))
+		b.WriteString(`"`)
+	}
+	if n.operation != "" {
+		fmt.Fprintf(b, ` nc:operation="%s"`, n.operation)
+	}
+	b.WriteString(">")
+}
+
+// xmlTree is a tree of XML elements built from gNMI paths
+type xmlTree struct {
+	schema *schema
+	root   *xmlNode
+}
+
+func newXMLTree(schema *schema) *xmlTree {
+	return &xmlTree{
+		schema: schema,
+		root:   &xmlNode{},
+	}
+}
+
+// addPath adds the elements of the given path to the tree and returns the node of its last element.
+// If leaf is true, the last element is added as a leaf.
+func (t *xmlTree) addPath(elems []*gpb.PathElem, leaf bool) (*xmlNode, error) {
+	schemaPath := getSchemaPath(elems)
+	if !t.schema.hasNode(schemaPath) {
+		return nil, errors.NewInvalid("path %s is not in the model", utils.StrPathElem(elems))
+	}
+	if len(elems) == 0 {
+		return t.root, nil
+	}
+
+	node := t.root
+	schemaPath = ""
+	for i, elem := range elems {
+		schemaPath += "/" + elem.Name
+		if leaf && i == len(elems)-1 {
+			node = node.setLeaf(elem.Name, nil)
+		} else {
+			node = node.getChild(elem.Name, elem.Key, t.schema.getKeyNames(schemaPath))
+		}
+		if i == 0 {
+			node.namespace = t.schema.getNamespace(elem.Name)
+		}
+	}
+	return node, nil
+}
+
+// addUpdate adds an update of the value at the given path to the tree
+func (t *xmlTree) addUpdate(elems []*gpb.PathElem, value *gpb.TypedValue, operation string) error {
+	var jsonValue []byte
+	switch v := value.GetValue().(type) {
+	case *gpb.TypedValue_JsonVal:
+		jsonValue = v.JsonVal
+	case *gpb.TypedValue_JsonIetfVal:
+		jsonValue = v.JsonIetfVal
+	}
+
+	if jsonValue != nil {
+		decoder := json.NewDecoder(bytes.NewReader(jsonValue))
+		decoder.UseNumber()
+		var tree interface{}
+		if err := decoder.Decode(&tree); err != nil {
+			return errors.NewInvalid("invalid JSON value for path %s: %v", utils.StrPathElem(elems), err)
+		}
+		node, err := t.addPath(elems, !isJSONObject(tree))
+		if err != nil {
+			return err
+		}
+		node.operation = operation
+		return t.addJSON(node, tree)
+	}
+
+	if !t.schema.isLeaf(getSchemaPath(elems)) && t.schema.modeled {
+		return errors.NewInvalid("path %s is not a leaf in the model", utils.StrPathElem(elems))
+	}
+	values, err := formatValue(value)
+	if err != nil {
+		return errors.NewInvalid("invalid value for path %s: %v", utils.StrPathElem(elems), err)
+	}
+	node, err := t.addPath(elems, true)
+	if err != nil {
+		return err
+	}
+	node.values = values
+	node.operation = operation
+	return nil
+}
+
+// addDelete adds the removal of the given path to the tree
+func (t *xmlTree) addDelete(elems []*gpb.PathElem) error {
+	if len(elems) == 0 {
+		return errors.NewInvalid("cannot delete the root path")
+	}
+	node, err := t.addPath(elems, t.schema.isLeaf(getSchemaPath(elems)))
+	if err != nil {
+		return err
+	}
+	node.operation = operationRemove
+	node.values = nil
+	return nil
+}
+
+// addJSON adds the given JSON tree beneath the given node
+func (t *xmlTree) addJSON(node *xmlNode, tree interface{}) error {
+	switch value := tree.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			// Strip the module name qualifying member names in JSON_IETF encoded values
+			localName := name
+			if i := strings.Index(name, ":"); i >= 0 {
+				localName = name[i+1:]
+			}
+			if err := t.addJSONMember(node, localName, value[name]); err != nil {
+				return err
+			}
+		}
+	default:
+		values, err := formatJSONValues(value)
+		if err != nil {
+			return err
+		}
+		node.values = values
+	}
+	return nil
+}
+
+func (t *xmlTree) addJSONMember(node *xmlNode, name string, member interface{}) error {
+	switch value := member.(type) {
+	case map[string]interface{}:
+		child := &xmlNode{name: name}
+		node.children = append(node.children, child)
+		return t.addJSON(child, value)
+	case []interface{}:
+		if len(value) > 0 && isJSONObject(value[0]) {
+			// Each entry of a list is a separate element
+			for _, entry := range value {
+				child := &xmlNode{name: name}
+				node.children = append(node.children, child)
+				if err := t.addJSON(child, entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	values, err := formatJSONValues(member)
+	if err != nil {
+		return err
+	}
+	node.setLeaf(name, values)
+	return nil
+}
+
+// write writes the XML tree
+func (t *xmlTree) write(b *bytes.Buffer) {
+	for _, child := range t.root.children {
+		child.write(b)
+	}
+}
+
+// newEditConfig returns the content of an edit-config config element applying the changes in the given SetRequest
+func newEditConfig(schema *schema, request *gpb.SetRequest) ([]byte, error) {
+	tree := newXMLTree(schema)
+	prefix := request.GetPrefix().GetElem()
+	for _, path := range request.Delete {
+		if err := tree.addDelete(joinElems(prefix, path.GetElem())); err != nil {
+			return nil, err
+		}
+	}
+	for _, update := range request.Replace {
+		if err := tree.addUpdate(joinElems(prefix, update.GetPath().GetElem()), update.Val, operationReplace); err != nil {
+			return nil, err
+		}
+	}
+	for _, update := range request.Update {
+		if err := tree.addUpdate(joinElems(prefix, update.GetPath().GetElem()), update.Val, ""); err != nil {
+			return nil, err
+		}
+	}
+	var b bytes.Buffer
+	tree.write(&b)
+	return b.Bytes(), nil
+}
+
+// newSubtreeFilter returns the content of a subtree filter selecting the given paths, or nil if the paths select
+// the whole datastore
+func newSubtreeFilter(schema *schema, prefix *gpb.Path, paths []*gpb.Path) ([]byte, error) {
+	tree := newXMLTree(schema)
+	if len(paths) == 0 {
+		paths = []*gpb.Path{{}}
+	}
+	for _, path := range paths {
+		elems := joinElems(prefix.GetElem(), path.GetElem())
+		if len(elems) == 0 {
+			return nil, nil
+		}
+		if _, err := tree.addPath(elems, false); err != nil {
+			return nil, err
+		}
+	}
+	var b bytes.Buffer
+	tree.write(&b)
+	return b.Bytes(), nil
+}
+
+// formatValue formats a gNMI scalar or leaf-list value as the text of one or more XML elements
+func formatValue(value *gpb.TypedValue) ([]string, error) {
+	if leaflist := value.GetLeaflistVal(); leaflist != nil {
+		values := make([]string, 0, len(leaflist.Element))
+		for _, element := range leaflist.Element {
+			elementValues, err := formatValue(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, elementValues...)
+		}
+		return values, nil
+	}
+
+	switch v := value.GetValue().(type) {
+	case *gpb.TypedValue_StringVal:
+		return []string{v.StringVal}, nil
+	case *gpb.TypedValue_AsciiVal:
+		return []string{v.AsciiVal}, nil
+	case *gpb.TypedValue_IntVal:
+		return []string{strconv.FormatInt(v.IntVal, 10)}, nil
+	case *gpb.TypedValue_UintVal:
+		return []string{strconv.FormatUint(v.UintVal, 10)}, nil
+	case *gpb.TypedValue_BoolVal:
+		return []string{strconv.FormatBool(v.BoolVal)}, nil
+	case *gpb.TypedValue_FloatVal:
+		return []string{strconv.FormatFloat(float64(v.FloatVal), 'g', -1, 32)}, nil
+	case *gpb.TypedValue_DecimalVal:
+		return []string{formatDecimal(v.DecimalVal.Digits, v.DecimalVal.Precision)}, nil
+	case *gpb.TypedValue_BytesVal:
+		return []string{base64.StdEncoding.EncodeToString(v.BytesVal)}, nil
+	default:
+		return nil, errors.NewNotSupported("unsupported value type %T", value.GetValue())
+	}
+}
+
+// formatJSONValues formats a JSON scalar or array of scalars as the text of one or more XML elements
+func formatJSONValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			elementValues, err := formatJSONValues(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, elementValues...)
+		}
+		return values, nil
+	case string:
+		return []string{v}, nil
+	case json.Number:
+		return []string{v.String()}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case nil:
+		// Empty leaves are encoded as [null] in JSON_IETF
+		return []string{""}, nil
+	default:
+		return nil, errors.NewInvalid("unsupported JSON value %v", value)
+	}
+}
+
+// formatDecimal formats a decimal64 value
+func formatDecimal(digits int64, precision uint32) string {
+	return new(big.Rat).SetFrac(big.NewInt(digits), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)).FloatString(int(precision))
+}
+
+func isJSONObject(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+	return ok
+}
+
+func equalKeys(keys1, keys2 map[string]string) bool {
+	if len(keys1) != len(keys2) {
+		return false
+	}
+	for key, value := range keys1 {
+		if keys2[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func joinElems(prefix []*gpb.PathElem, elems []*gpb.PathElem) []*gpb.PathElem {
+	joined := make([]*gpb.PathElem, 0, len(prefix)+len(elems))
+	joined = append(joined, prefix...)
+	return append(joined, elems...)
+}
@@ -0,0 +1,151 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-config/pkg/utils/path"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNamespaces = map[string]string{
+	"interfaces": "urn:test:interfaces",
+	"system":     "urn:test:system",
+}
+
+func newTestModelInfo() *pluginregistry.ModelPluginInfo {
+	newElem := func(valueType configapi.ValueType) path.ReadWritePathElem {
+		return path.ReadWritePathElem{ReadOnlyAttrib: path.ReadOnlyAttrib{ValueType: valueType}}
+	}
+	return &pluginregistry.ModelPluginInfo{
+		ReadWritePaths: path.ReadWritePathMap{
+			"/interfaces/interface[name=*]/name":               newElem(configapi.ValueType_STRING),
+			"/interfaces/interface[name=*]/config/enabled":     newElem(configapi.ValueType_BOOL),
+			"/interfaces/interface[name=*]/config/mtu":         newElem(configapi.ValueType_UINT),
+			"/interfaces/interface[name=*]/config/description": newElem(configapi.ValueType_STRING),
+			"/system/config/hostname":                          newElem(configapi.ValueType_STRING),
+			"/system/config/dns-servers":                       newElem(configapi.ValueType_LEAFLIST_STRING),
+		},
+		ReadOnlyPaths: path.ReadOnlyPathMap{
+			"/interfaces/interface[name=*]/state": path.ReadOnlySubPathMap{
+				"/oper-status":        path.ReadOnlyAttrib{ValueType: configapi.ValueType_STRING},
+				"/counters/in-octets": path.ReadOnlyAttrib{ValueType: configapi.ValueType_UINT},
+			},
+		},
+	}
+}
+
+func newTestPath(t *testing.T, p string) *gpb.Path {
+	elems, err := utils.ParseGNMIElements(utils.SplitPath(p))
+	assert.NoError(t, err)
+	return elems
+}
+
+func TestEditConfig(t *testing.T) {
+	schema := newSchema(newTestModelInfo(), testNamespaces)
+
+	config, err := newEditConfig(schema, &gpb.SetRequest{
+		Delete: []*gpb.Path{
+			newTestPath(t, "/interfaces/interface[name=eth2]"),
+		},
+		Replace: []*gpb.Update{
+			{
+				Path: newTestPath(t, "/system/config/hostname"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "switch-1"}},
+			},
+		},
+		Update: []*gpb.Update{
+			{
+				Path: newTestPath(t, "/interfaces/interface[name=eth1]/config/enabled"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}},
+			},
+			{
+				Path: newTestPath(t, "/interfaces/interface[name=eth1]/config/mtu"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1500}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<interfaces xmlns="urn:test:interfaces">`+
+		`<interface nc:operation="remove"><name>eth2</name></interface>`+
+		`<interface><name>eth1</name><config><enabled>true</enabled><mtu>1500</mtu></config></interface>`+
+		`</interfaces>`+
+		`<system xmlns="urn:test:system"><config><hostname nc:operation="replace">switch-1</hostname></config></system>`,
+		string(config))
+
+	config, err = newEditConfig(schema, &gpb.SetRequest{
+		Prefix: newTestPath(t, "/system"),
+		Update: []*gpb.Update{
+			{
+				Path: newTestPath(t, "/config"),
+				Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{
+					JsonVal: []byte(`{"hostname": "switch-<1>", "dns-servers": ["1.1.1.1", "8.8.8.8"]}`),
+				}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<system xmlns="urn:test:system"><config>`+
+		`<dns-servers>1.1.1.1</dns-servers><dns-servers>8.8.8.8</dns-servers><hostname>switch-&lt;1&gt;</hostname>`+
+		`</config></system>`, string(config))
+
+	_, err = newEditConfig(schema, &gpb.SetRequest{
+		Update: []*gpb.Update{
+			{
+				Path: newTestPath(t, "/system/config/domain-name"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "example.com"}},
+			},
+		},
+	})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestSubtreeFilter(t *testing.T) {
+	schema := newSchema(newTestModelInfo(), testNamespaces)
+
+	filter, err := newSubtreeFilter(schema, nil, []*gpb.Path{{}})
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = newSubtreeFilter(schema, newTestPath(t, "/interfaces"), []*gpb.Path{
+		newTestPath(t, "/interface[name=eth1]/state"),
+		newTestPath(t, "/interface[name=*]/config/mtu"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `<interfaces xmlns="urn:test:interfaces">`+
+		`<interface><name>eth1</name><state></state></interface>`+
+		`<interface><config><mtu></mtu></config></interface>`+
+		`</interfaces>`, string(filter))
+
+	_, err = newSubtreeFilter(schema, nil, []*gpb.Path{newTestPath(t, "/unknown")})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestRPCErrors(t *testing.T) {
+	err := newRPCErrors([]RPCError{{Type: "protocol", Tag: "lock-denied", Severity: "error", Message: "locked"}})
+	assert.True(t, errors.IsConflict(err))
+	err = newRPCErrors([]RPCError{{Type: "application", Tag: "invalid-value", Severity: "error"}})
+	assert.True(t, errors.IsInvalid(err))
+	err = newRPCErrors([]RPCError{{Type: "application", Tag: "data-missing", Severity: "error"}})
+	assert.True(t, errors.IsNotFound(err))
+	err = newRPCErrors([]RPCError{{Type: "application", Tag: "vendor-specific", Severity: "error"}})
+	assert.True(t, errors.IsUnknown(err))
+}
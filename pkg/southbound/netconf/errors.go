@@ -0,0 +1,83 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// RPCError is an error reported by a target in an rpc-reply, as defined in RFC 6241 section 4.3
+type RPCError struct {
+	Type     string `xml:"error-type"`
+	Tag      string `xml:"error-tag"`
+	Severity string `xml:"error-severity"`
+	AppTag   string `xml:"error-app-tag"`
+	Path     string `xml:"error-path"`
+	Message  string `xml:"error-message"`
+}
+
+// Error returns a description of the rpc-error
+func (e RPCError) Error() string {
+	description := fmt.Sprintf("%s %s", strings.TrimSpace(e.Type), strings.TrimSpace(e.Tag))
+	if message := strings.TrimSpace(e.Message); message != "" {
+		description = fmt.Sprintf("%s: %s", description, message)
+	}
+	if path := strings.TrimSpace(e.Path); path != "" {
+		description = fmt.Sprintf("%s (%s)", description, path)
+	}
+	return description
+}
+
+// errorTypes maps the error tags defined in RFC 6241 appendix A to error types.
+// The error types determine the type of the failure recorded when changes cannot be applied to a target.
+var errorTypes = map[string]errors.Type{
+	"in-use":                  errors.Conflict,
+	"invalid-value":           errors.Invalid,
+	"too-big":                 errors.Invalid,
+	"missing-attribute":       errors.Invalid,
+	"bad-attribute":           errors.Invalid,
+	"unknown-attribute":       errors.Invalid,
+	"missing-element":         errors.Invalid,
+	"bad-element":             errors.Invalid,
+	"unknown-element":         errors.Invalid,
+	"unknown-namespace":       errors.Invalid,
+	"malformed-message":       errors.Invalid,
+	"access-denied":           errors.Forbidden,
+	"lock-denied":             errors.Conflict,
+	"resource-denied":         errors.Unavailable,
+	"rollback-failed":         errors.Internal,
+	"data-exists":             errors.AlreadyExists,
+	"data-missing":            errors.NotFound,
+	"operation-not-supported": errors.NotSupported,
+	"operation-failed":        errors.Internal,
+	"partial-operation":       errors.Internal,
+}
+
+// newRPCErrors returns an error describing the given rpc-errors. The type of the error is determined by the tag
+// of the first rpc-error.
+func newRPCErrors(rpcErrors []RPCError) error {
+	descriptions := make([]string, len(rpcErrors))
+	for i, rpcError := range rpcErrors {
+		descriptions[i] = rpcError.Error()
+	}
+	errorType, ok := errorTypes[strings.TrimSpace(rpcErrors[0].Tag)]
+	if !ok {
+		errorType = errors.Unknown
+	}
+	return errors.New(errorType, strings.Join(descriptions, "; "))
+}
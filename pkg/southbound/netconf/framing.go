@@ -0,0 +1,141 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	// endOfMessage is the delimiter terminating messages framed with the NETCONF 1.0 end-of-message framing
+	endOfMessage = "]]>]]>"
+	// maxMessageSize is the maximum size of a message received from a target
+	maxMessageSize = 64 << 20
+)
+
+// newTransport returns a new transport framing messages on the given stream.
+// Messages use the end-of-message framing until the transport is switched to chunked framing.
+func newTransport(rw io.ReadWriter) *transport {
+	return &transport{
+		reader: bufio.NewReader(rw),
+		writer: rw,
+	}
+}
+
+// transport frames NETCONF messages as defined in RFC 6242
+type transport struct {
+	reader  *bufio.Reader
+	writer  io.Writer
+	chunked bool
+}
+
+// readMessage reads the next message
+func (t *transport) readMessage() ([]byte, error) {
+	if t.chunked {
+		return t.readChunkedMessage()
+	}
+	return t.readDelimitedMessage()
+}
+
+// writeMessage writes a message
+func (t *transport) writeMessage(msg []byte) error {
+	var buf bytes.Buffer
+	if t.chunked {
+		fmt.Fprintf(&buf, "\n#%d\n", len(msg))
+		buf.Write(msg)
+		buf.WriteString("\n##\n")
+	} else {
+		buf.Write(msg)
+		buf.WriteString(endOfMessage)
+	}
+	_, err := t.writer.Write(buf.Bytes())
+	return err
+}
+
+// readDelimitedMessage reads a message terminated by the end-of-message delimiter
+func (t *transport) readDelimitedMessage() ([]byte, error) {
+	var buf bytes.Buffer
+	for {
+		b, err := t.reader.ReadBytes('>')
+		buf.Write(b)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasSuffix(buf.Bytes(), []byte(endOfMessage)) {
+			return bytes.TrimSpace(buf.Bytes()[:buf.Len()-len(endOfMessage)]), nil
+		}
+		if buf.Len() > maxMessageSize {
+			return nil, errors.NewInvalid("message exceeds the maximum size of %d bytes", maxMessageSize)
+		}
+	}
+}
+
+// readChunkedMessage reads a message framed with the chunked framing
+func (t *transport) readChunkedMessage() ([]byte, error) {
+	var buf bytes.Buffer
+	for {
+		if err := t.expect("\n#"); err != nil {
+			return nil, err
+		}
+		b, err := t.reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == '#' {
+			if err := t.expect("\n"); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+		if err := t.reader.UnreadByte(); err != nil {
+			return nil, err
+		}
+
+		line, err := t.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.ParseUint(line[:len(line)-1], 10, 32)
+		if err != nil || size == 0 {
+			return nil, errors.NewInvalid("invalid chunk size '%s'", line[:len(line)-1])
+		}
+		if buf.Len()+int(size) > maxMessageSize {
+			return nil, errors.NewInvalid("message exceeds the maximum size of %d bytes", maxMessageSize)
+		}
+		if _, err := io.CopyN(&buf, t.reader, int64(size)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// expect reads the given literal from the stream
+func (t *transport) expect(literal string) error {
+	for i := 0; i < len(literal); i++ {
+		b, err := t.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != literal[i] {
+			return errors.NewInvalid("malformed chunked message")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	var buf bytes.Buffer
+	transport := newTransport(&buf)
+
+	assert.NoError(t, transport.writeMessage([]byte("<hello/>")))
+	assert.Equal(t, "<hello/>]]>]]>", buf.String())
+	msg, err := transport.readMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "<hello/>", string(msg))
+
+	transport.chunked = true
+	assert.NoError(t, transport.writeMessage([]byte("<rpc/>")))
+	assert.Equal(t, "\n#6\n<rpc/>\n##\n", buf.String())
+	msg, err = transport.readMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "<rpc/>", string(msg))
+
+	// A message may be split into multiple chunks
+	buf.WriteString("\n#4\n<rpc\n#7\n><get/>\n#6\n</rpc>\n##\n")
+	msg, err = transport.readMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "<rpc><get/></rpc>", string(msg))
+
+	buf.WriteString("\n#x\n<rpc/>\n##\n")
+	_, err = transport.readMessage()
+	assert.True(t, errors.IsInvalid(err))
+}
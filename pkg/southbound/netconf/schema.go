@@ -0,0 +1,135 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"strings"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/pluginregistry"
+	"github.com/onosproject/onos-config/pkg/utils"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newSchema returns the schema of the model plugin with the given info. If no plugin info is provided,
+// paths are not validated and values read from the target are returned as strings.
+func newSchema(info *pluginregistry.ModelPluginInfo, namespaces map[string]string) *schema {
+	s := &schema{
+		nodes:      make(map[string]bool),
+		leaves:     make(map[string]configapi.ValueType),
+		keys:       make(map[string][]string),
+		namespaces: namespaces,
+	}
+	if info == nil {
+		return s
+	}
+	s.modeled = true
+	for path, elem := range info.ReadWritePaths {
+		s.addPath(path, elem.ValueType)
+	}
+	for parentPath, subPaths := range info.ReadOnlyPaths {
+		for subPath, attr := range subPaths {
+			if subPath == "/" {
+				s.addPath(parentPath, attr.ValueType)
+			} else {
+				s.addPath(parentPath+subPath, attr.ValueType)
+			}
+		}
+	}
+	return s
+}
+
+// schema describes the elements of a target's model needed to translate between gNMI paths and XML.
+// Elements are identified by their schema path: the names of the path elements without keys.
+type schema struct {
+	modeled bool
+	// nodes is the set of schema paths of all the containers, lists and leaves in the model
+	nodes map[string]bool
+	// leaves maps the schema paths of leaves to their value types
+	leaves map[string]configapi.ValueType
+	// keys maps the schema paths of lists to the names of their keys in the order defined by the model
+	keys map[string][]string
+	// namespaces maps the names of top-level elements to their XML namespaces
+	namespaces map[string]string
+}
+
+// addPath adds a leaf path from the model plugin to the schema
+func (s *schema) addPath(path string, valueType configapi.ValueType) {
+	schemaPath := ""
+	for _, elem := range utils.SplitPath(path) {
+		name, keyNames := splitElem(elem)
+		schemaPath += "/" + name
+		s.nodes[schemaPath] = true
+		if len(keyNames) > 0 {
+			s.keys[schemaPath] = keyNames
+		}
+	}
+	s.leaves[schemaPath] = valueType
+}
+
+// hasNode returns whether the given schema path is in the model. If the schema is not modeled, all paths are accepted.
+func (s *schema) hasNode(schemaPath string) bool {
+	return !s.modeled || schemaPath == "" || s.nodes[schemaPath]
+}
+
+// isLeaf returns whether the given schema path is a leaf in the model
+func (s *schema) isLeaf(schemaPath string) bool {
+	_, ok := s.leaves[schemaPath]
+	return ok
+}
+
+// getValueType returns the type of the leaf at the given schema path, or STRING if the leaf is not in the model
+func (s *schema) getValueType(schemaPath string) configapi.ValueType {
+	if valueType, ok := s.leaves[schemaPath]; ok {
+		return valueType
+	}
+	return configapi.ValueType_STRING
+}
+
+// getKeyNames returns the names of the keys of the list at the given schema path
+func (s *schema) getKeyNames(schemaPath string) []string {
+	return s.keys[schemaPath]
+}
+
+// getNamespace returns the XML namespace of the given top-level element, if known
+func (s *schema) getNamespace(name string) string {
+	return s.namespaces[name]
+}
+
+// getSchemaPath returns the schema path of the given path elements
+func getSchemaPath(elems []*gpb.PathElem) string {
+	var b strings.Builder
+	for _, elem := range elems {
+		b.WriteString("/")
+		b.WriteString(elem.Name)
+	}
+	return b.String()
+}
+
+// splitElem splits a path element into its name and the names of its keys in the order they appear
+func splitElem(elem string) (string, []string) {
+	i := strings.Index(elem, "[")
+	if i < 0 {
+		return elem, nil
+	}
+	name := elem[:i]
+	var keyNames []string
+	for _, key := range strings.Split(elem[i+1:], "[") {
+		if j := strings.Index(key, "="); j > 0 {
+			keyNames = append(keyNames, key[:j])
+		}
+	}
+	return name, keyNames
+}
@@ -0,0 +1,197 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is a simulated NETCONF server
+type testServer struct {
+	// capabilities are the capabilities advertised in addition to the base capabilities
+	capabilities []string
+	// data is the content of the data returned by get and get-config
+	data string
+	// editError is the rpc-error returned by edit-config, if any
+	editError string
+	// lockError is the rpc-error returned by lock, if any
+	lockError  string
+	operations []string
+	edits      []string
+	mu         sync.Mutex
+}
+
+// testRPC is an rpc received by the test server
+type testRPC struct {
+	MessageID string `xml:"message-id,attr"`
+	Content   []byte `xml:",innerxml"`
+}
+
+func (s *testServer) getOperations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.operations
+}
+
+func (s *testServer) getEdits() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.edits
+}
+
+// serve serves a NETCONF session on the given stream
+func (s *testServer) serve(stream io.ReadWriteCloser) error {
+	defer stream.Close()
+	transport := newTransport(stream)
+	serverHello, err := xml.Marshal(&hello{
+		Capabilities: append([]string{capabilityBase10, capabilityBase11}, s.capabilities...),
+		SessionID:    1,
+	})
+	if err != nil {
+		return err
+	}
+	// The peers send their hello messages simultaneously
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- transport.writeMessage(serverHello)
+	}()
+	msg, err := transport.readMessage()
+	if err != nil {
+		return err
+	}
+	if err := <-errCh; err != nil {
+		return err
+	}
+	clientHello := &hello{}
+	if err := xml.Unmarshal(msg, clientHello); err != nil {
+		return err
+	}
+	for _, capability := range clientHello.Capabilities {
+		if capability == capabilityBase11 {
+			transport.chunked = true
+		}
+	}
+
+	for {
+		msg, err := transport.readMessage()
+		if err != nil {
+			return err
+		}
+		rpc := &testRPC{}
+		if err := xml.Unmarshal(msg, rpc); err != nil {
+			return err
+		}
+		operation, err := getOperation(rpc.Content)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.operations = append(s.operations, operation)
+		reply := "<ok/>"
+		switch operation {
+		case "get", "get-config":
+			reply = fmt.Sprintf("<data>%s</data>", s.data)
+		case "edit-config":
+			s.edits = append(s.edits, string(rpc.Content))
+			if s.editError != "" {
+				reply = s.editError
+			}
+		case "lock":
+			if s.lockError != "" {
+				reply = s.lockError
+			}
+		}
+		s.mu.Unlock()
+
+		msg = []byte(fmt.Sprintf(`<rpc-reply message-id="%s" xmlns="%s">%s</rpc-reply>`, rpc.MessageID, baseNamespace, reply))
+		if err := transport.writeMessage(msg); err != nil {
+			return err
+		}
+		if operation == "close-session" {
+			return nil
+		}
+	}
+}
+
+// serveSSH serves NETCONF over SSH on the given listener, authenticating clients with the given password
+func (s *testServer) serveSSH(t *testing.T, lis net.Listener, hostKey ssh.Signer, password string) {
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, fmt.Errorf("invalid password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, config)
+			if err != nil {
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for newChannel := range chans {
+				if newChannel.ChannelType() != "session" {
+					_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+					continue
+				}
+				channel, requests, err := newChannel.Accept()
+				assert.NoError(t, err)
+				go func() {
+					for req := range requests {
+						// The subsystem name is encoded as an SSH string
+						ok := req.Type == "subsystem" && bytes.HasSuffix(req.Payload, []byte(netconfSubsystem))
+						_ = req.Reply(ok, nil)
+						if ok {
+							go func() {
+								_ = s.serve(channel)
+							}()
+						}
+					}
+				}()
+			}
+		}()
+	}
+}
+
+// getOperation returns the name of the operation in the content of an rpc
+func getOperation(content []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconf
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	baseNamespace = "urn:ietf:params:xml:ns:netconf:base:1.0"
+
+	capabilityBase10          = "urn:ietf:params:netconf:base:1.0"
+	capabilityBase11          = "urn:ietf:params:netconf:base:1.1"
+	capabilityCandidate       = "urn:ietf:params:netconf:capability:candidate:1.0"
+	capabilityRollbackOnError = "urn:ietf:params:netconf:capability:rollback-on-error:1.0"
+)
+
+// hello is the message with which the peers of a session exchange their capabilities
+type hello struct {
+	XMLName      xml.Name `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 hello"`
+	Capabilities []string `xml:"capabilities>capability"`
+	SessionID    uint64   `xml:"session-id,omitempty"`
+}
+
+// rpcReply is the reply to an rpc
+type rpcReply struct {
+	XMLName   xml.Name   `xml:"rpc-reply"`
+	MessageID string     `xml:"message-id,attr"`
+	Errors    []RPCError `xml:"rpc-error"`
+	Data      *struct {
+		Content []byte `xml:",innerxml"`
+	} `xml:"data"`
+}
+
+// newSession establishes a NETCONF session on the given stream by exchanging hello messages with the target.
+// The onClose function is called once when the session is closed, with the error that caused it to close, if any.
+func newSession(ctx context.Context, stream io.ReadWriteCloser, onClose func(error)) (*session, error) {
+	s := &session{
+		stream:    stream,
+		transport: newTransport(stream),
+		onClose:   onClose,
+	}
+
+	err := s.withContext(ctx, func() error {
+		msg, err := xml.Marshal(&hello{
+			Capabilities: []string{capabilityBase10, capabilityBase11},
+		})
+		if err != nil {
+			return errors.NewInternal(err.Error())
+		}
+		if err := s.transport.writeMessage(msg); err != nil {
+			return errors.NewUnavailable("failed sending hello: %v", err)
+		}
+		msg, err = s.transport.readMessage()
+		if err != nil {
+			return errors.NewUnavailable("failed receiving hello: %v", err)
+		}
+		serverHello := &hello{}
+		if err := xml.Unmarshal(msg, serverHello); err != nil {
+			return errors.NewInvalid("invalid hello: %v", err)
+		}
+		s.id = serverHello.SessionID
+		for _, capability := range serverHello.Capabilities {
+			s.capabilities = append(s.capabilities, strings.TrimSpace(capability))
+		}
+		return nil
+	})
+	if err != nil {
+		s.close(err)
+		return nil, err
+	}
+
+	// Switch to the chunked framing if both peers support NETCONF 1.1
+	s.transport.chunked = s.hasCapability(capabilityBase11)
+	return s, nil
+}
+
+// session is a NETCONF session. RPCs on the session are sent one at a time.
+type session struct {
+	id           uint64
+	capabilities []string
+	stream       io.ReadWriteCloser
+	transport    *transport
+	messageID    uint64
+	mu           sync.Mutex
+	onClose      func(error)
+	closeOnce    sync.Once
+}
+
+// hasCapability returns whether the target advertised the given capability
+func (s *session) hasCapability(capability string) bool {
+	for _, c := range s.capabilities {
+		if c == capability || strings.HasPrefix(c, capability+"?") {
+			return true
+		}
+	}
+	return false
+}
+
+// rpc sends the given operation to the target and returns the content of the data element of the reply, if any.
+// If the reply contains errors, they are returned as a typed error.
+func (s *session) rpc(ctx context.Context, operation string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messageID++
+	messageID := strconv.FormatUint(s.messageID, 10)
+	reply := &rpcReply{}
+	err := s.withContext(ctx, func() error {
+		msg := fmt.Sprintf(`<rpc message-id="%s" xmlns="%s">%s</rpc>`, messageID, baseNamespace, operation)
+		if err := s.transport.writeMessage([]byte(msg)); err != nil {
+			return errors.NewUnavailable("failed sending rpc: %v", err)
+		}
+		replyMsg, err := s.transport.readMessage()
+		if err != nil {
+			return errors.NewUnavailable("failed receiving rpc-reply: %v", err)
+		}
+		if err := xml.Unmarshal(replyMsg, reply); err != nil {
+			return errors.NewInvalid("invalid rpc-reply: %v", err)
+		}
+		if reply.MessageID != messageID {
+			return errors.NewInvalid("received rpc-reply for message %s, expected %s", reply.MessageID, messageID)
+		}
+		return nil
+	})
+	if err != nil {
+		// The session cannot be used once the request and reply streams are out of step
+		s.close(err)
+		return nil, err
+	}
+
+	var rpcErrors []RPCError
+	for _, rpcError := range reply.Errors {
+		if strings.TrimSpace(rpcError.Severity) == "warning" {
+			log.Warnf("Received rpc-error warning: %s", rpcError.Error())
+			continue
+		}
+		rpcErrors = append(rpcErrors, rpcError)
+	}
+	if len(rpcErrors) > 0 {
+		return nil, newRPCErrors(rpcErrors)
+	}
+	if reply.Data != nil {
+		return reply.Data.Content, nil
+	}
+	return nil, nil
+}
+
+// withContext runs the given function, closing the stream to interrupt it if the context is done first
+func (s *session) withContext(ctx context.Context, f func() error) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f()
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		_ = s.stream.Close()
+		<-errCh
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.NewTimeout("request timed out")
+		}
+		return errors.NewCanceled("request canceled")
+	}
+}
+
+// close closes the session, reporting the given error to the close handler
+func (s *session) close(err error) {
+	s.closeOnce.Do(func() {
+		_ = s.stream.Close()
+		if s.onClose != nil {
+			s.onClose(err)
+		}
+	})
+}
+
+// Close gracefully closes the session
+func (s *session) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if _, err := s.rpc(ctx, "<close-session/>"); err != nil {
+		log.Debugf("Failed closing session %d gracefully", s.id, err)
+	}
+	s.close(nil)
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Operation is a gNMI operation into which faults can be injected
+type Operation string
+
+const (
+	// OperationCapabilities is the gNMI Capabilities operation
+	OperationCapabilities Operation = "capabilities"
+	// OperationGet is the gNMI Get operation
+	OperationGet Operation = "get"
+	// OperationSet is the gNMI Set operation
+	OperationSet Operation = "set"
+	// OperationSubscribe is the gNMI Subscribe operation
+	OperationSubscribe Operation = "subscribe"
+)
+
+// Fault is a failure injected into the requests of an operation
+type Fault struct {
+	// Delay delays the handling of each request
+	Delay time.Duration
+	// Code fails each request with the given gRPC status code unless it is codes.OK
+	Code codes.Code
+	// Message is the message of the status with which requests fail
+	Message string
+	// Count is the number of requests into which the fault is injected; if zero, the fault is
+	// injected into all requests until the faults are cleared
+	Count int
+}
+
+func newFaults() *faults {
+	return &faults{
+		faults: make(map[Operation]*Fault),
+	}
+}
+
+// faults tracks the faults injected into a target's operations
+type faults struct {
+	faults map[Operation]*Fault
+	mu     sync.Mutex
+}
+
+func (f *faults) inject(operation Operation, fault Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[operation] = &fault
+}
+
+func (f *faults) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = make(map[Operation]*Fault)
+}
+
+// next returns the fault to inject into the next request of the given operation, if any
+func (f *faults) next(operation Operation) (Fault, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fault, ok := f.faults[operation]
+	if !ok {
+		return Fault{}, false
+	}
+	if fault.Count > 0 {
+		fault.Count--
+		if fault.Count == 0 {
+			delete(f.faults, operation)
+		}
+	}
+	return *fault, true
+}
+
+// apply applies the fault injected into the next request of the given operation, if any,
+// returning the status error with which the request fails
+func (f *faults) apply(ctx context.Context, operation Operation) error {
+	fault, ok := f.next(operation)
+	if !ok {
+		return nil
+	}
+	if fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if fault.Code != codes.OK {
+		message := fault.Message
+		if message == "" {
+			message = "injected fault"
+		}
+		return status.Error(fault.Code, message)
+	}
+	return nil
+}
@@ -0,0 +1,370 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulator
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	gnmi_ext "github.com/openconfig/gnmi/proto/gnmi_ext"
+)
+
+// server serves the gNMI requests of a simulated target
+type server struct {
+	target *Target
+}
+
+// Capabilities returns the models configured for the target
+func (s *server) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	if err := s.target.faults.apply(ctx, OperationCapabilities); err != nil {
+		return nil, err
+	}
+	return &gpb.CapabilityResponse{
+		SupportedModels:    s.target.options.Models,
+		SupportedEncodings: []gpb.Encoding{gpb.Encoding_PROTO},
+		GNMIVersion:        gnmiVersion,
+	}, nil
+}
+
+// Get returns the leaves at and beneath the requested paths. Values are always returned as scalar values.
+// CONFIG requests read the configuration tree, STATE and OPERATIONAL requests read the state tree,
+// and ALL requests read both.
+func (s *server) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	if err := s.target.faults.apply(ctx, OperationGet); err != nil {
+		return nil, err
+	}
+	if err := s.target.checkPrefix(req.Prefix); err != nil {
+		return nil, errors.Status(err).Err()
+	}
+
+	paths := req.Path
+	if len(paths) == 0 {
+		paths = []*gpb.Path{{}}
+	}
+
+	s.target.mu.RLock()
+	config, state := s.target.config, s.target.state
+	s.target.mu.RUnlock()
+
+	notifications := make([]*gpb.Notification, 0, len(paths))
+	for _, path := range paths {
+		elems := joinElems(req.Prefix.GetElem(), path.GetElem())
+		var leaves []*leaf
+		switch req.Type {
+		case gpb.GetRequest_ALL:
+			leaves = append(config.match(elems), state.match(elems)...)
+		case gpb.GetRequest_CONFIG:
+			leaves = config.match(elems)
+		case gpb.GetRequest_STATE, gpb.GetRequest_OPERATIONAL:
+			leaves = state.match(elems)
+		default:
+			return nil, errors.Status(errors.NewInvalid("unknown data type %s", req.Type)).Err()
+		}
+		if len(leaves) == 0 && len(elems) > 0 {
+			return nil, errors.Status(errors.NewNotFound("path %s not found", utils.StrPathElem(elems))).Err()
+		}
+		notifications = append(notifications, s.target.newNotification(req.Prefix, leaves, nil))
+	}
+	return &gpb.GetResponse{
+		Notification: notifications,
+	}, nil
+}
+
+// Set applies the deletes, replacements and updates in the request atomically, in that order.
+// Requests are rejected unless the client is the master for its role.
+func (s *server) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if err := s.target.faults.apply(ctx, OperationSet); err != nil {
+		return nil, err
+	}
+	if err := s.target.checkPrefix(req.Prefix); err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	prefix := req.Prefix.GetElem()
+
+	s.target.mu.Lock()
+	defer s.target.mu.Unlock()
+	if err := s.target.arbitrate(req.Extension); err != nil {
+		log.Warn(err)
+		return nil, errors.Status(err).Err()
+	}
+
+	config := s.target.config.clone()
+	var updates []*leaf
+	var deletes []*gpb.Path
+	results := make([]*gpb.UpdateResult, 0, len(req.Delete)+len(req.Replace)+len(req.Update))
+	for _, path := range req.Delete {
+		deletes = append(deletes, config.delete(joinElems(prefix, path.GetElem()))...)
+		results = append(results, &gpb.UpdateResult{Path: path, Op: gpb.UpdateResult_DELETE})
+	}
+	for _, update := range req.Replace {
+		elems := joinElems(prefix, update.GetPath().GetElem())
+		leaves, err := newLeaves(elems, update.Val)
+		if err != nil {
+			return nil, errors.Status(err).Err()
+		}
+		deletes = append(deletes, config.delete(elems)...)
+		config.set(leaves)
+		updates = append(updates, leaves...)
+		results = append(results, &gpb.UpdateResult{Path: update.Path, Op: gpb.UpdateResult_REPLACE})
+	}
+	for _, update := range req.Update {
+		leaves, err := newLeaves(joinElems(prefix, update.GetPath().GetElem()), update.Val)
+		if err != nil {
+			return nil, errors.Status(err).Err()
+		}
+		config.set(leaves)
+		updates = append(updates, leaves...)
+		results = append(results, &gpb.UpdateResult{Path: update.Path, Op: gpb.UpdateResult_UPDATE})
+	}
+
+	// Only report the deletion of leaves that were not set again by the same request
+	var removed []*gpb.Path
+	for _, path := range deletes {
+		if _, ok := config[utils.StrPathElem(path.Elem)]; !ok {
+			removed = append(removed, path)
+		}
+	}
+
+	s.target.config = config
+	s.target.sets = append(s.target.sets, req)
+	s.target.notify(updates, removed)
+	return &gpb.SetResponse{
+		Prefix:    req.Prefix,
+		Response:  results,
+		Timestamp: time.Now().UnixNano(),
+	}, nil
+}
+
+// Subscribe serves ONCE, POLL and STREAM subscriptions to the target's configuration and state.
+// STREAM subscriptions in SAMPLE mode are sampled at the requested interval; all other STREAM
+// subscriptions are notified of changes.
+func (s *server) Subscribe(stream gpb.GNMI_SubscribeServer) error {
+	if err := s.target.faults.apply(stream.Context(), OperationSubscribe); err != nil {
+		return err
+	}
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	subscriptionList := req.GetSubscribe()
+	if subscriptionList == nil {
+		return errors.Status(errors.NewInvalid("first message must be a subscription list")).Err()
+	}
+	if err := s.target.checkPrefix(subscriptionList.Prefix); err != nil {
+		return errors.Status(err).Err()
+	}
+
+	switch subscriptionList.Mode {
+	case gpb.SubscriptionList_ONCE:
+		err = s.sendSnapshot(stream, subscriptionList)
+	case gpb.SubscriptionList_POLL:
+		err = s.poll(stream, subscriptionList)
+	case gpb.SubscriptionList_STREAM:
+		err = s.stream(stream, subscriptionList)
+	default:
+		err = errors.NewInvalid("unknown subscription mode %s", subscriptionList.Mode)
+	}
+	if err != nil {
+		return errors.Status(err).Err()
+	}
+	return nil
+}
+
+// sendSnapshot sends the current values of the subscribed paths followed by a sync response
+func (s *server) sendSnapshot(stream gpb.GNMI_SubscribeServer, subscriptionList *gpb.SubscriptionList) error {
+	for _, subscription := range subscriptionList.Subscription {
+		if err := s.sendValues(stream, subscriptionList.Prefix, subscription.Path); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&gpb.SubscribeResponse{
+		Response: &gpb.SubscribeResponse_SyncResponse{
+			SyncResponse: true,
+		},
+	})
+}
+
+// sendValues sends the current values of the given subscribed path, if any
+func (s *server) sendValues(stream gpb.GNMI_SubscribeServer, prefix *gpb.Path, path *gpb.Path) error {
+	elems := joinElems(prefix.GetElem(), path.GetElem())
+	s.target.mu.RLock()
+	leaves := append(s.target.config.match(elems), s.target.state.match(elems)...)
+	s.target.mu.RUnlock()
+	if len(leaves) == 0 {
+		return nil
+	}
+	return stream.Send(&gpb.SubscribeResponse{
+		Response: &gpb.SubscribeResponse_Update{
+			Update: s.target.newNotification(prefix, leaves, nil),
+		},
+	})
+}
+
+// poll sends a snapshot of the subscribed paths each time the client polls the subscription
+func (s *server) poll(stream gpb.GNMI_SubscribeServer, subscriptionList *gpb.SubscriptionList) error {
+	if err := s.sendSnapshot(stream, subscriptionList); err != nil {
+		return err
+	}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.FromGRPC(err)
+		}
+		if req.GetPoll() == nil {
+			return errors.NewInvalid("expected a poll request")
+		}
+		if err := s.sendSnapshot(stream, subscriptionList); err != nil {
+			return err
+		}
+	}
+}
+
+// stream sends the current values of the subscribed paths and then streams changes and samples until the
+// client cancels the subscription or the target is disconnected
+func (s *server) stream(stream gpb.GNMI_SubscribeServer, subscriptionList *gpb.SubscriptionList) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	sub := &subscriber{
+		prefix: subscriptionList.Prefix,
+		ch:     make(chan *gpb.Notification, subscriberBufferSize),
+	}
+	var samples []*gpb.Subscription
+	for _, subscription := range subscriptionList.Subscription {
+		if subscription.Mode == gpb.SubscriptionMode_SAMPLE && subscription.SampleInterval > 0 {
+			samples = append(samples, subscription)
+		} else {
+			sub.paths = append(sub.paths, joinElems(subscriptionList.Prefix.GetElem(), subscription.GetPath().GetElem()))
+		}
+	}
+
+	// Register the subscriber before sending the snapshot to avoid missing changes
+	if !s.target.subscribe(sub) {
+		return errors.NewUnavailable("target '%s' is stopped", s.target.id)
+	}
+	defer s.target.unsubscribe(sub)
+
+	if subscriptionList.UpdatesOnly {
+		if err := stream.Send(&gpb.SubscribeResponse{
+			Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true},
+		}); err != nil {
+			return err
+		}
+	} else if err := s.sendSnapshot(stream, subscriptionList); err != nil {
+		return err
+	}
+
+	sampleCh := make(chan *gpb.Subscription)
+	for _, subscription := range samples {
+		go func(subscription *gpb.Subscription) {
+			ticker := time.NewTicker(time.Duration(subscription.SampleInterval))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					select {
+					case sampleCh <- subscription:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(subscription)
+	}
+
+	for {
+		select {
+		case notification, ok := <-sub.ch:
+			if !ok {
+				return errors.NewUnavailable("subscription to target '%s' closed", s.target.id)
+			}
+			if err := stream.Send(&gpb.SubscribeResponse{
+				Response: &gpb.SubscribeResponse_Update{Update: notification},
+			}); err != nil {
+				return err
+			}
+		case subscription := <-sampleCh:
+			if err := s.sendValues(stream, subscriptionList.Prefix, subscription.Path); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// checkPrefix checks that the target of the given prefix, if any, is this target
+func (t *Target) checkPrefix(prefix *gpb.Path) error {
+	if target := prefix.GetTarget(); target != "" && target != t.id {
+		return errors.NewNotFound("unknown target '%s'", target)
+	}
+	return nil
+}
+
+// arbitrate checks the master arbitration extension of a Set request. Requests are accepted from the client
+// with the highest election ID seen for the request's role. Once any client has used master arbitration,
+// requests without the extension are rejected.
+func (t *Target) arbitrate(extensions []*gnmi_ext.Extension) error {
+	var arbitration *gnmi_ext.MasterArbitration
+	for _, extension := range extensions {
+		if ma := extension.GetMasterArbitration(); ma != nil {
+			arbitration = ma
+		}
+	}
+	if arbitration == nil {
+		if len(t.elections) > 0 {
+			return errors.NewForbidden("master arbitration is required by target '%s'", t.id)
+		}
+		return nil
+	}
+
+	electionID := arbitration.ElectionId
+	if electionID == nil {
+		return errors.NewInvalid("no election ID in master arbitration")
+	}
+	role := arbitration.GetRole().GetId()
+	if current, ok := t.elections[role]; ok && compareElectionIDs(electionID, current) < 0 {
+		return errors.NewForbidden("election ID %d:%d for role '%s' is lower than the master's %d:%d",
+			electionID.High, electionID.Low, role, current.High, current.Low)
+	}
+	t.elections[role] = electionID
+	return nil
+}
+
+// compareElectionIDs compares two election IDs, returning -1, 0 or 1
+func compareElectionIDs(id1, id2 *gnmi_ext.Uint128) int {
+	switch {
+	case id1.High < id2.High:
+		return -1
+	case id1.High > id2.High:
+		return 1
+	case id1.Low < id2.Low:
+		return -1
+	case id1.Low > id2.Low:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulator
+
+import (
+	"time"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// subscriberBufferSize is the number of notifications buffered for a streaming subscription before it is closed
+const subscriberBufferSize = 1000
+
+// subscriber is a streaming subscription notified of changes to the subscribed paths
+type subscriber struct {
+	prefix *gpb.Path
+	paths  [][]*gpb.PathElem
+	ch     chan *gpb.Notification
+}
+
+// subscribe registers a subscriber, returning false if the target is stopped
+func (t *Target) subscribe(sub *subscriber) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.server == nil {
+		return false
+	}
+	t.subscribers[sub] = struct{}{}
+	return true
+}
+
+// unsubscribe removes a subscriber
+func (t *Target) unsubscribe(sub *subscriber) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.subscribers, sub)
+}
+
+// closeSubscribers closes all subscribers. The caller must hold the target's lock.
+func (t *Target) closeSubscribers() {
+	for sub := range t.subscribers {
+		close(sub.ch)
+	}
+	t.subscribers = make(map[*subscriber]struct{})
+}
+
+// notify notifies subscribers of the given changes. The caller must hold the target's lock.
+// Subscribers that do not keep up with changes are closed.
+func (t *Target) notify(updates []*leaf, deletes []*gpb.Path) {
+	for sub := range t.subscribers {
+		var subUpdates []*leaf
+		for _, update := range updates {
+			if sub.matches(update.elems) {
+				subUpdates = append(subUpdates, update)
+			}
+		}
+		var subDeletes []*gpb.Path
+		for _, path := range deletes {
+			if sub.matches(path.Elem) {
+				subDeletes = append(subDeletes, path)
+			}
+		}
+		if len(subUpdates) == 0 && len(subDeletes) == 0 {
+			continue
+		}
+		select {
+		case sub.ch <- t.newNotification(sub.prefix, subUpdates, subDeletes):
+		default:
+			log.Warnf("Closing slow subscriber to simulated target '%s'", t.id)
+			close(sub.ch)
+			delete(t.subscribers, sub)
+		}
+	}
+}
+
+// matches returns whether the given leaf path is subscribed
+func (s *subscriber) matches(elems []*gpb.PathElem) bool {
+	for _, path := range s.paths {
+		if matchPath(path, elems) {
+			return true
+		}
+	}
+	return false
+}
+
+// newNotification returns a notification of the given updates and deletes.
+// Paths are not relative to the prefix; only the prefix target is returned.
+func (t *Target) newNotification(prefix *gpb.Path, updates []*leaf, deletes []*gpb.Path) *gpb.Notification {
+	notification := &gpb.Notification{
+		Timestamp: time.Now().UnixNano(),
+		Delete:    deletes,
+	}
+	if target := prefix.GetTarget(); target != "" {
+		notification.Prefix = &gpb.Path{Target: target}
+	}
+	for _, leaf := range updates {
+		notification.Update = append(notification.Update, &gpb.Update{
+			Path: &gpb.Path{Elem: leaf.elems},
+			Val:  leaf.value,
+		})
+	}
+	return notification
+}
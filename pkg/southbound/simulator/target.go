@@ -0,0 +1,286 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulator implements an in-process simulated gNMI target for tests and demos.
+// A simulated target serves Capabilities, Get, Set and Subscribe requests from an in-memory configuration tree,
+// enforces master arbitration for Set requests, and supports injecting delays, errors and disconnects.
+// To manage a simulated target with onos-config, point a target entity's Configurable address at the
+// target's Address with plain TLS options.
+package simulator
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	gnmi_ext "github.com/openconfig/gnmi/proto/gnmi_ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = logging.GetLogger("southbound", "simulator")
+
+const (
+	// defaultAddress is the address on which targets listen by default; the port is chosen by the system
+	defaultAddress = "localhost:0"
+	// gnmiVersion is the gNMI version reported in the target's capabilities
+	gnmiVersion = "0.7.0"
+)
+
+// Options are the options of a simulated target
+type Options struct {
+	// Address is the address on which the target listens
+	Address string
+	// Models are the models reported in the target's capabilities
+	Models []*gpb.ModelData
+	// TLSConfig is the TLS configuration of the target's server; if nil, the target serves plaintext connections
+	TLSConfig *tls.Config
+}
+
+// Option is a simulated target option
+type Option interface {
+	apply(*Options)
+}
+
+type addressOption struct {
+	address string
+}
+
+func (o addressOption) apply(options *Options) {
+	options.Address = o.address
+}
+
+// WithAddress sets the address on which the target listens
+func WithAddress(address string) Option {
+	return addressOption{address: address}
+}
+
+type modelsOption struct {
+	models []*gpb.ModelData
+}
+
+func (o modelsOption) apply(options *Options) {
+	options.Models = append(options.Models, o.models...)
+}
+
+// WithModels adds models to the target's capabilities
+func WithModels(models ...*gpb.ModelData) Option {
+	return modelsOption{models: models}
+}
+
+type tlsOption struct {
+	config *tls.Config
+}
+
+func (o tlsOption) apply(options *Options) {
+	options.TLSConfig = o.config
+}
+
+// WithTLS serves TLS connections with the given configuration
+func WithTLS(config *tls.Config) Option {
+	return tlsOption{config: config}
+}
+
+// NewTarget returns a new simulated target with an empty configuration
+func NewTarget(id string, opts ...Option) *Target {
+	options := Options{
+		Address: defaultAddress,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &Target{
+		id:          id,
+		options:     options,
+		address:     options.Address,
+		config:      make(tree),
+		state:       make(tree),
+		elections:   make(map[string]*gnmi_ext.Uint128),
+		faults:      newFaults(),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Target is a simulated gNMI target
+type Target struct {
+	id          string
+	options     Options
+	address     string
+	server      *grpc.Server
+	config      tree
+	state       tree
+	elections   map[string]*gnmi_ext.Uint128
+	sets        []*gpb.SetRequest
+	faults      *faults
+	subscribers map[*subscriber]struct{}
+	mu          sync.RWMutex
+}
+
+// ID returns the target ID
+func (t *Target) ID() string {
+	return t.id
+}
+
+// Address returns the address on which the target listens. Once the target has been started,
+// the address includes the port chosen by the system, and the target listens on the same address when restarted.
+func (t *Target) Address() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.address
+}
+
+// Start starts serving gNMI requests
+func (t *Target) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.server != nil {
+		return nil
+	}
+
+	lis, err := net.Listen("tcp", t.address)
+	if err != nil {
+		return errors.NewUnavailable("failed listening on %s: %v", t.address, err)
+	}
+	t.address = lis.Addr().String()
+
+	var opts []grpc.ServerOption
+	if t.options.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(t.options.TLSConfig)))
+	}
+	t.server = grpc.NewServer(opts...)
+	gpb.RegisterGNMIServer(t.server, &server{target: t})
+	go func(server *grpc.Server) {
+		if err := server.Serve(lis); err != nil {
+			log.Warnf("Simulated target '%s' stopped serving", t.id, err)
+		}
+	}(t.server)
+	log.Infof("Started simulated target '%s' on %s", t.id, t.address)
+	return nil
+}
+
+// Stop stops serving gNMI requests, closing all client connections and subscriptions.
+// The configuration of the target is retained until it is restarted.
+func (t *Target) Stop() {
+	t.mu.Lock()
+	server := t.server
+	t.server = nil
+	t.closeSubscribers()
+	t.mu.Unlock()
+	if server != nil {
+		server.Stop()
+		log.Infof("Stopped simulated target '%s'", t.id)
+	}
+}
+
+// Disconnect closes all client connections and subscriptions. The target continues accepting new connections.
+func (t *Target) Disconnect() error {
+	t.Stop()
+	return t.Start()
+}
+
+// InjectFault injects a fault into requests of the given operation, replacing any fault injected earlier
+func (t *Target) InjectFault(operation Operation, fault Fault) {
+	t.faults.inject(operation, fault)
+}
+
+// ClearFaults clears all injected faults
+func (t *Target) ClearFaults() {
+	t.faults.clear()
+}
+
+// GetConfig returns the configuration value at the given path
+func (t *Target) GetConfig(path string) (*gpb.TypedValue, bool) {
+	elems, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	leaf, ok := t.config[utils.StrPathElem(elems)]
+	if !ok {
+		return nil, false
+	}
+	return leaf.value, true
+}
+
+// SetConfig sets the configuration value at the given path, as if it was changed by another client.
+// The value may be a JSON value, in which case the leaves of the JSON tree are set beneath the path.
+func (t *Target) SetConfig(path string, value *gpb.TypedValue) error {
+	return t.setValue(path, value, false)
+}
+
+// DeleteConfig deletes the configuration values at and beneath the given path, as if it was changed by another client
+func (t *Target) DeleteConfig(path string) error {
+	elems, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	config := t.config.clone()
+	deletes := config.delete(elems)
+	t.config = config
+	t.notify(nil, deletes)
+	return nil
+}
+
+// SetState sets the state value at the given path. State values are returned by STATE and OPERATIONAL
+// Get requests and cannot be changed with Set requests.
+func (t *Target) SetState(path string, value *gpb.TypedValue) error {
+	return t.setValue(path, value, true)
+}
+
+func (t *Target) setValue(path string, value *gpb.TypedValue, state bool) error {
+	elems, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	leaves, err := newLeaves(elems, value)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state {
+		t.state = t.state.clone()
+		t.state.set(leaves)
+	} else {
+		t.config = t.config.clone()
+		t.config.set(leaves)
+	}
+	t.notify(leaves, nil)
+	return nil
+}
+
+// SetRequests returns the Set requests applied by the target, in the order in which they were applied
+func (t *Target) SetRequests() []*gpb.SetRequest {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	sets := make([]*gpb.SetRequest, len(t.sets))
+	copy(sets, t.sets)
+	return sets
+}
+
+// parsePath parses the elements of the given path
+func parsePath(path string) ([]*gpb.PathElem, error) {
+	gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+	if err != nil {
+		return nil, errors.NewInvalid("invalid path %s: %v", path, err)
+	}
+	return gnmiPath.Elem, nil
+}
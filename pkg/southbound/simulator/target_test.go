@@ -0,0 +1,264 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-config/pkg/utils"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	gnmi_ext "github.com/openconfig/gnmi/proto/gnmi_ext"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func startTarget(t *testing.T, opts ...Option) (*Target, gpb.GNMIClient) {
+	target := NewTarget("target-1", opts...)
+	assert.NoError(t, target.Start())
+	conn, err := grpc.Dial(target.Address(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		target.Stop()
+	})
+	return target, gpb.NewGNMIClient(conn)
+}
+
+func newPath(t *testing.T, path string) *gpb.Path {
+	elems, err := parsePath(path)
+	assert.NoError(t, err)
+	return &gpb.Path{Elem: elems}
+}
+
+func newStringVal(value string) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: value}}
+}
+
+func newArbitration(role string, electionID uint64) []*gnmi_ext.Extension {
+	return []*gnmi_ext.Extension{
+		{
+			Ext: &gnmi_ext.Extension_MasterArbitration{
+				MasterArbitration: &gnmi_ext.MasterArbitration{
+					Role:       &gnmi_ext.Role{Id: role},
+					ElectionId: &gnmi_ext.Uint128{Low: electionID},
+				},
+			},
+		},
+	}
+}
+
+func getValues(t *testing.T, client gpb.GNMIClient, dataType gpb.GetRequest_DataType, paths ...string) map[string]*gpb.TypedValue {
+	req := &gpb.GetRequest{Type: dataType}
+	for _, path := range paths {
+		req.Path = append(req.Path, newPath(t, path))
+	}
+	resp, err := client.Get(context.Background(), req)
+	assert.NoError(t, err)
+	values := make(map[string]*gpb.TypedValue)
+	for _, notification := range resp.Notification {
+		for _, update := range notification.Update {
+			values[utils.StrPath(update.Path)] = update.Val
+		}
+	}
+	return values
+}
+
+func TestGetSet(t *testing.T) {
+	target, client := startTarget(t, WithModels(&gpb.ModelData{Name: "test", Version: "1.0.0"}))
+
+	capabilities, err := client.Capabilities(context.Background(), &gpb.CapabilityRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, capabilities.SupportedModels, 1)
+
+	_, err = client.Set(context.Background(), &gpb.SetRequest{
+		Update: []*gpb.Update{
+			{Path: newPath(t, "/interfaces/interface[name=eth1]/config/description"), Val: newStringVal("uplink")},
+			{Path: newPath(t, "/interfaces/interface[name=eth2]/config/description"), Val: newStringVal("downlink")},
+			{
+				Path: newPath(t, "/system/config"),
+				Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{
+					JsonVal: []byte(`{"hostname": "switch-1", "mtu": 1500, "dns-servers": ["1.1.1.1", "8.8.8.8"]}`),
+				}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	values := getValues(t, client, gpb.GetRequest_CONFIG, "/")
+	assert.Len(t, values, 5)
+	assert.Equal(t, "uplink", values["/interfaces/interface[name=eth1]/config/description"].GetStringVal())
+	assert.Equal(t, "switch-1", values["/system/config/hostname"].GetStringVal())
+	assert.Equal(t, uint64(1500), values["/system/config/mtu"].GetUintVal())
+	assert.Len(t, values["/system/config/dns-servers"].GetLeaflistVal().Element, 2)
+
+	values = getValues(t, client, gpb.GetRequest_CONFIG, "/interfaces/interface[name=*]/config/description")
+	assert.Len(t, values, 2)
+
+	_, err = client.Set(context.Background(), &gpb.SetRequest{
+		Prefix: &gpb.Path{Target: "target-1"},
+		Delete: []*gpb.Path{newPath(t, "/interfaces/interface[name=eth2]")},
+		Replace: []*gpb.Update{
+			{Path: newPath(t, "/system/config"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{
+				JsonVal: []byte(`{"hostname": "switch-2"}`),
+			}}},
+		},
+	})
+	assert.NoError(t, err)
+	values = getValues(t, client, gpb.GetRequest_CONFIG, "/")
+	assert.Len(t, values, 2)
+	assert.Equal(t, "switch-2", values["/system/config/hostname"].GetStringVal())
+	assert.Len(t, target.SetRequests(), 2)
+
+	_, err = client.Get(context.Background(), &gpb.GetRequest{Path: []*gpb.Path{newPath(t, "/interfaces/interface[name=eth2]")}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.Get(context.Background(), &gpb.GetRequest{Prefix: &gpb.Path{Target: "target-2"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Changes made by other clients and state values
+	assert.NoError(t, target.SetConfig("/system/config/hostname", newStringVal("switch-3")))
+	value, ok := target.GetConfig("/system/config/hostname")
+	assert.True(t, ok)
+	assert.Equal(t, "switch-3", value.GetStringVal())
+	assert.NoError(t, target.SetState("/interfaces/interface[name=eth1]/state/oper-status", newStringVal("UP")))
+	assert.Len(t, getValues(t, client, gpb.GetRequest_STATE, "/"), 1)
+	assert.Len(t, getValues(t, client, gpb.GetRequest_ALL, "/interfaces"), 2)
+	assert.NoError(t, target.DeleteConfig("/system"))
+	_, ok = target.GetConfig("/system/config/hostname")
+	assert.False(t, ok)
+}
+
+func TestMasterArbitration(t *testing.T) {
+	_, client := startTarget(t)
+
+	newRequest := func(extensions []*gnmi_ext.Extension) *gpb.SetRequest {
+		return &gpb.SetRequest{
+			Update: []*gpb.Update{
+				{Path: newPath(t, "/system/config/hostname"), Val: newStringVal("switch-1")},
+			},
+			Extension: extensions,
+		}
+	}
+
+	_, err := client.Set(context.Background(), newRequest(nil))
+	assert.NoError(t, err)
+	_, err = client.Set(context.Background(), newRequest(newArbitration("onos-config", 2)))
+	assert.NoError(t, err)
+	_, err = client.Set(context.Background(), newRequest(newArbitration("onos-config", 2)))
+	assert.NoError(t, err)
+	_, err = client.Set(context.Background(), newRequest(newArbitration("onos-config", 1)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.Set(context.Background(), newRequest(nil))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.Set(context.Background(), newRequest(newArbitration("other", 1)))
+	assert.NoError(t, err)
+	_, err = client.Set(context.Background(), newRequest(newArbitration("onos-config", 3)))
+	assert.NoError(t, err)
+	_, err = client.Set(context.Background(), newRequest(newArbitration("onos-config", 2)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestFaults(t *testing.T) {
+	target, client := startTarget(t)
+
+	target.InjectFault(OperationGet, Fault{Code: codes.Unavailable, Count: 1})
+	_, err := client.Get(context.Background(), &gpb.GetRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = client.Get(context.Background(), &gpb.GetRequest{})
+	assert.NoError(t, err)
+
+	target.InjectFault(OperationSet, Fault{Delay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.Set(ctx, &gpb.SetRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	target.ClearFaults()
+	_, err = client.Set(context.Background(), &gpb.SetRequest{})
+	assert.NoError(t, err)
+}
+
+func TestSubscribe(t *testing.T) {
+	target, client := startTarget(t)
+	assert.NoError(t, target.SetConfig("/system/config/hostname", newStringVal("switch-1")))
+
+	stream, err := client.Subscribe(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode: gpb.SubscriptionList_STREAM,
+				Subscription: []*gpb.Subscription{
+					{Path: newPath(t, "/system"), Mode: gpb.SubscriptionMode_ON_CHANGE},
+				},
+			},
+		},
+	}))
+
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "switch-1", resp.GetUpdate().Update[0].Val.GetStringVal())
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.True(t, resp.GetSyncResponse())
+
+	// Changes to other paths are not streamed
+	assert.NoError(t, target.SetConfig("/interfaces/interface[name=eth1]/config/mtu", &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1500}}))
+	_, err = client.Set(context.Background(), &gpb.SetRequest{
+		Update: []*gpb.Update{
+			{Path: newPath(t, "/system/config/hostname"), Val: newStringVal("switch-2")},
+		},
+	})
+	assert.NoError(t, err)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetUpdate().Update, 1)
+	assert.Equal(t, "switch-2", resp.GetUpdate().Update[0].Val.GetStringVal())
+
+	assert.NoError(t, target.DeleteConfig("/system/config"))
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetUpdate().Delete, 1)
+	assert.Equal(t, "/system/config/hostname", utils.StrPath(resp.GetUpdate().Delete[0]))
+
+	// Disconnecting the target closes the subscription, and clients can reconnect
+	assert.NoError(t, target.Disconnect())
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = client.Get(context.Background(), &gpb.GetRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+
+	stream, err = client.Subscribe(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode: gpb.SubscriptionList_ONCE,
+				Subscription: []*gpb.Subscription{
+					{Path: newPath(t, "/interfaces")},
+				},
+			},
+		},
+	}))
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1500), resp.GetUpdate().Update[0].Val.GetUintVal())
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.True(t, resp.GetSyncResponse())
+}
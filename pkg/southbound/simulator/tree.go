@@ -0,0 +1,204 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// leaf is a value in a target's tree
+type leaf struct {
+	elems []*gpb.PathElem
+	value *gpb.TypedValue
+}
+
+// tree is a set of leaves indexed by their paths. Trees are copied on write so that readers
+// holding a tree are not affected by later changes.
+type tree map[string]*leaf
+
+// clone returns a copy of the tree
+func (t tree) clone() tree {
+	clone := make(tree, len(t))
+	for path, leaf := range t {
+		clone[path] = leaf
+	}
+	return clone
+}
+
+// set sets the given leaves in the tree
+func (t tree) set(leaves []*leaf) {
+	for _, leaf := range leaves {
+		t[utils.StrPathElem(leaf.elems)] = leaf
+	}
+}
+
+// delete removes the leaves at and beneath the given path and returns the paths of the removed leaves
+func (t tree) delete(elems []*gpb.PathElem) []*gpb.Path {
+	var deletes []*gpb.Path
+	for path, leaf := range t {
+		if matchPath(elems, leaf.elems) {
+			delete(t, path)
+			deletes = append(deletes, &gpb.Path{Elem: leaf.elems})
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool {
+		return utils.StrPath(deletes[i]) < utils.StrPath(deletes[j])
+	})
+	return deletes
+}
+
+// match returns the leaves at and beneath the given path, ordered by path
+func (t tree) match(elems []*gpb.PathElem) []*leaf {
+	var leaves []*leaf
+	for _, leaf := range t {
+		if matchPath(elems, leaf.elems) {
+			leaves = append(leaves, leaf)
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return utils.StrPathElem(leaves[i].elems) < utils.StrPathElem(leaves[j].elems)
+	})
+	return leaves
+}
+
+// matchPath returns whether the given leaf path is at or beneath the given path.
+// Element names and key values in the path may be wildcards, and keys missing from the path match any value.
+func matchPath(elems []*gpb.PathElem, leafElems []*gpb.PathElem) bool {
+	if len(elems) > len(leafElems) {
+		return false
+	}
+	for i, elem := range elems {
+		leafElem := leafElems[i]
+		if elem.Name != "*" && elem.Name != leafElem.Name {
+			return false
+		}
+		for key, value := range elem.Key {
+			if value != "*" && leafElem.Key[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// newLeaves returns the leaves set by setting the given value at the given path.
+// JSON values are flattened into the leaves of the JSON tree.
+func newLeaves(elems []*gpb.PathElem, value *gpb.TypedValue) ([]*leaf, error) {
+	var jsonValue []byte
+	switch v := value.GetValue().(type) {
+	case nil:
+		return nil, errors.NewInvalid("no value for path %s", utils.StrPathElem(elems))
+	case *gpb.TypedValue_JsonVal:
+		jsonValue = v.JsonVal
+	case *gpb.TypedValue_JsonIetfVal:
+		jsonValue = v.JsonIetfVal
+	default:
+		return []*leaf{{elems: elems, value: value}}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonValue))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, errors.NewInvalid("invalid JSON value for path %s: %v", utils.StrPathElem(elems), err)
+	}
+	var leaves []*leaf
+	if err := addJSONLeaves(elems, tree, &leaves); err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+// addJSONLeaves adds the leaves of the given JSON tree beneath the given path.
+// The simulator has no schema from which to determine the keys of lists, so lists of objects are not supported.
+func addJSONLeaves(elems []*gpb.PathElem, tree interface{}, leaves *[]*leaf) error {
+	switch value := tree.(type) {
+	case map[string]interface{}:
+		for name, member := range value {
+			// Strip the module name qualifying member names in JSON_IETF encoded values
+			if i := strings.Index(name, ":"); i >= 0 {
+				name = name[i+1:]
+			}
+			childElems := make([]*gpb.PathElem, len(elems), len(elems)+1)
+			copy(childElems, elems)
+			childElems = append(childElems, &gpb.PathElem{Name: name})
+			if err := addJSONLeaves(childElems, member, leaves); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		elements := make([]*gpb.TypedValue, 0, len(value))
+		for _, element := range value {
+			typedValue, err := newJSONScalar(elems, element)
+			if err != nil {
+				return err
+			}
+			elements = append(elements, typedValue)
+		}
+		*leaves = append(*leaves, &leaf{
+			elems: elems,
+			value: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{Element: elements}}},
+		})
+		return nil
+	default:
+		typedValue, err := newJSONScalar(elems, value)
+		if err != nil {
+			return err
+		}
+		*leaves = append(*leaves, &leaf{elems: elems, value: typedValue})
+		return nil
+	}
+}
+
+// newJSONScalar returns the typed value of a JSON scalar
+func newJSONScalar(elems []*gpb.PathElem, value interface{}) (*gpb.TypedValue, error) {
+	switch v := value.(type) {
+	case string:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: v}}, nil
+	case bool:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: v}}, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			if i < 0 {
+				return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}, nil
+			}
+			return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: uint64(i)}}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, errors.NewInvalid("invalid number %s for path %s", v, utils.StrPathElem(elems))
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_FloatVal{FloatVal: float32(f)}}, nil
+	case map[string]interface{}, []interface{}:
+		return nil, errors.NewNotSupported("JSON lists of objects are not supported for path %s", utils.StrPathElem(elems))
+	default:
+		return nil, errors.NewInvalid("unsupported JSON value for path %s", utils.StrPathElem(elems))
+	}
+}
+
+// joinElems returns the elements of the given prefix followed by the given elements
+func joinElems(prefix []*gpb.PathElem, elems []*gpb.PathElem) []*gpb.PathElem {
+	joined := make([]*gpb.PathElem, 0, len(prefix)+len(elems))
+	joined = append(joined, prefix...)
+	return append(joined, elems...)
+}
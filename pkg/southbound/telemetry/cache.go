@@ -0,0 +1,355 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry implements a cache of target state maintained by southbound gNMI subscriptions.
+package telemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var log = logging.GetLogger("southbound", "telemetry")
+
+// CacheInfo describes the freshness of the cached state of a target
+type CacheInfo struct {
+	// Synced indicates the subscription has delivered the complete state of the cached paths
+	Synced bool `json:"synced"`
+	// LastUpdate is the time at which the last update was received from the target
+	LastUpdate time.Time `json:"lastUpdate"`
+	// Age is the time elapsed since the last update was received from the target
+	Age string `json:"age"`
+}
+
+// Generation identifies the subscription maintaining the cache of a target. Each call to Open starts
+// a new generation; writes made for an earlier generation are ignored.
+type Generation uint64
+
+// Cache is a per-target cache of state values
+type Cache interface {
+	// Open enables caching of the given paths for a target, discarding any previously cached state.
+	// The returned generation must be passed to the writes made by the new subscription.
+	Open(targetID topoapi.ID, paths []*gpb.Path, maxAge time.Duration) Generation
+	// Close disables caching for a target and closes the channels of its watchers
+	Close(targetID topoapi.ID)
+	// Update applies a notification received from a target
+	Update(targetID topoapi.ID, generation Generation, notification *gpb.Notification)
+	// Sync marks the state of a target as complete
+	Sync(targetID topoapi.ID, generation Generation)
+	// Unsync marks the state of a target as incomplete, e.g. when the subscription fails
+	Unsync(targetID topoapi.ID, generation Generation)
+	// Covers returns whether all the given paths of a target are cached
+	Covers(targetID topoapi.ID, paths []*gpb.Path) bool
+	// Get returns the cached state for the given paths of a target. If the paths are not cached,
+	// a NotFound error is returned. If the cached state is incomplete or stale, an Unavailable error is returned.
+	Get(targetID topoapi.ID, paths []*gpb.Path) ([]*gpb.Notification, *CacheInfo, error)
+	// Watch streams the updates to the given paths of a target until the context is canceled.
+	// The channel is closed when the watch ends. If the channel is full when an update is received,
+	// the watch ends early rather than blocking the cache.
+	Watch(ctx context.Context, targetID topoapi.ID, paths []*gpb.Path, ch chan<- *gpb.Notification) error
+}
+
+// NewCache returns a new telemetry cache
+func NewCache() Cache {
+	return &cache{
+		targets:  make(map[topoapi.ID]*targetCache),
+		watchers: make(map[topoapi.ID]map[uuid.UUID]*watcher),
+	}
+}
+
+type cache struct {
+	targets    map[topoapi.ID]*targetCache
+	watchers   map[topoapi.ID]map[uuid.UUID]*watcher
+	generation Generation
+	mu         sync.RWMutex
+}
+
+type targetCache struct {
+	generation Generation
+	paths      []*gpb.Path
+	maxAge     time.Duration
+	entries    map[string]*gpb.Update
+	timestamp  int64
+	synced     bool
+	lastUpdate time.Time
+}
+
+type watcher struct {
+	paths []*gpb.Path
+	ch    chan<- *gpb.Notification
+}
+
+func (c *cache) Open(targetID topoapi.ID, paths []*gpb.Path, maxAge time.Duration) Generation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	log.Infof("Caching %d paths for target '%s'", len(paths), targetID)
+	c.generation++
+	c.targets[targetID] = &targetCache{
+		generation: c.generation,
+		paths:      paths,
+		maxAge:     maxAge,
+		entries:    make(map[string]*gpb.Update),
+	}
+	return c.generation
+}
+
+func (c *cache) Close(targetID topoapi.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.targets[targetID]; ok {
+		log.Infof("Closing cache for target '%s'", targetID)
+		delete(c.targets, targetID)
+	}
+	for id, w := range c.watchers[targetID] {
+		delete(c.watchers[targetID], id)
+		close(w.ch)
+	}
+	delete(c.watchers, targetID)
+}
+
+func (c *cache) Update(targetID topoapi.ID, generation Generation, notification *gpb.Notification) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target, ok := c.targets[targetID]
+	if !ok || target.generation != generation {
+		return
+	}
+	target.lastUpdate = time.Now()
+	if notification.Timestamp > target.timestamp {
+		target.timestamp = notification.Timestamp
+	}
+
+	prefix := notification.GetPrefix().GetElem()
+	for _, path := range notification.Delete {
+		deletePath := &gpb.Path{Elem: joinElems(prefix, path.GetElem())}
+		for key, update := range target.entries {
+			if matchPath(deletePath.Elem, update.Path.Elem) {
+				delete(target.entries, key)
+			}
+		}
+	}
+	for _, update := range notification.Update {
+		path := &gpb.Path{Elem: joinElems(prefix, update.GetPath().GetElem())}
+		target.entries[utils.StrPath(path)] = &gpb.Update{
+			Path:       path,
+			Val:        update.Val,
+			Duplicates: update.Duplicates,
+		}
+	}
+
+	// Forward the notification to watchers of matching paths
+	for id, w := range c.watchers[targetID] {
+		if filtered := filterNotification(targetID, notification, w.paths); filtered != nil {
+			select {
+			case w.ch <- filtered:
+			default:
+				log.Warnf("Closing slow telemetry watcher for target '%s'", targetID)
+				delete(c.watchers[targetID], id)
+				close(w.ch)
+			}
+		}
+	}
+}
+
+func (c *cache) Sync(targetID topoapi.ID, generation Generation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if target, ok := c.targets[targetID]; ok && target.generation == generation {
+		target.synced = true
+		target.lastUpdate = time.Now()
+	}
+}
+
+func (c *cache) Unsync(targetID topoapi.ID, generation Generation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if target, ok := c.targets[targetID]; ok && target.generation == generation {
+		target.synced = false
+	}
+}
+
+func (c *cache) Covers(targetID topoapi.ID, paths []*gpb.Path) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	target, ok := c.targets[targetID]
+	return ok && target.covers(paths)
+}
+
+func (c *cache) Get(targetID topoapi.ID, paths []*gpb.Path) ([]*gpb.Notification, *CacheInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	target, ok := c.targets[targetID]
+	if !ok || !target.covers(paths) {
+		return nil, nil, errors.NewNotFound("paths are not cached for target '%s'", targetID)
+	}
+	info := &CacheInfo{
+		Synced:     target.synced,
+		LastUpdate: target.lastUpdate,
+		Age:        time.Since(target.lastUpdate).String(),
+	}
+	if !target.synced {
+		return nil, info, errors.NewUnavailable("cache for target '%s' is not synchronized", targetID)
+	}
+	if target.maxAge > 0 && time.Since(target.lastUpdate) > target.maxAge {
+		return nil, info, errors.NewUnavailable("cache for target '%s' is stale", targetID)
+	}
+
+	notifications := make([]*gpb.Notification, 0, len(paths))
+	for _, path := range paths {
+		updates := make([]*gpb.Update, 0)
+		for _, update := range target.entries {
+			if matchPath(path.Elem, update.Path.Elem) {
+				updates = append(updates, proto.Clone(update).(*gpb.Update))
+			}
+		}
+		sort.Slice(updates, func(i, j int) bool {
+			return utils.StrPath(updates[i].Path) < utils.StrPath(updates[j].Path)
+		})
+		notifications = append(notifications, &gpb.Notification{
+			Timestamp: target.timestamp,
+			Prefix:    &gpb.Path{Target: string(targetID)},
+			Update:    updates,
+		})
+	}
+	return notifications, info, nil
+}
+
+func (c *cache) Watch(ctx context.Context, targetID topoapi.ID, paths []*gpb.Path, ch chan<- *gpb.Notification) error {
+	id := uuid.New()
+	c.mu.Lock()
+	watchers, ok := c.watchers[targetID]
+	if !ok {
+		watchers = make(map[uuid.UUID]*watcher)
+		c.watchers[targetID] = watchers
+	}
+	watchers[id] = &watcher{paths: paths, ch: ch}
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		if _, ok := c.watchers[targetID][id]; ok {
+			delete(c.watchers[targetID], id)
+			close(ch)
+		}
+		if len(c.watchers[targetID]) == 0 {
+			delete(c.watchers, targetID)
+		}
+		c.mu.Unlock()
+	}()
+	return nil
+}
+
+// covers returns whether each of the given paths is at or beneath one of the cached paths
+func (t *targetCache) covers(paths []*gpb.Path) bool {
+	for _, path := range paths {
+		covered := false
+		for _, cachedPath := range t.paths {
+			if matchPath(cachedPath.Elem, path.Elem) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// filterNotification returns a copy of the notification with the updates and deletes matching the given paths,
+// or nil if none match
+func filterNotification(targetID topoapi.ID, notification *gpb.Notification, paths []*gpb.Path) *gpb.Notification {
+	prefix := notification.GetPrefix().GetElem()
+	matches := func(elems []*gpb.PathElem) bool {
+		for _, path := range paths {
+			// Deletes of parent paths affect the watched paths too
+			if matchPath(path.Elem, elems) || matchPath(elems, path.Elem) {
+				return true
+			}
+		}
+		return false
+	}
+
+	filtered := &gpb.Notification{
+		Timestamp: notification.Timestamp,
+		Prefix:    &gpb.Path{Target: string(targetID)},
+	}
+	for _, path := range notification.Delete {
+		elems := joinElems(prefix, path.GetElem())
+		if matches(elems) {
+			filtered.Delete = append(filtered.Delete, &gpb.Path{Elem: elems})
+		}
+	}
+	for _, update := range notification.Update {
+		elems := joinElems(prefix, update.GetPath().GetElem())
+		if matches(elems) && !isParent(elems, paths) {
+			filtered.Update = append(filtered.Update, &gpb.Update{
+				Path:       &gpb.Path{Elem: elems},
+				Val:        update.Val,
+				Duplicates: update.Duplicates,
+			})
+		}
+	}
+	if len(filtered.Update) == 0 && len(filtered.Delete) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+// isParent returns whether the given path is strictly above all the given paths
+func isParent(elems []*gpb.PathElem, paths []*gpb.Path) bool {
+	for _, path := range paths {
+		if matchPath(path.Elem, elems) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPath returns whether the given path is at or beneath the given pattern.
+// A '*' element name or key value in the pattern matches any name or value.
+func matchPath(pattern []*gpb.PathElem, elems []*gpb.PathElem) bool {
+	if len(elems) < len(pattern) {
+		return false
+	}
+	for i, patternElem := range pattern {
+		elem := elems[i]
+		if patternElem.Name != "*" && patternElem.Name != elem.Name {
+			return false
+		}
+		for key, value := range patternElem.Key {
+			if value != "*" && elem.Key[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func joinElems(prefix []*gpb.PathElem, elems []*gpb.PathElem) []*gpb.PathElem {
+	joined := make([]*gpb.PathElem, 0, len(prefix)+len(elems))
+	joined = append(joined, prefix...)
+	return append(joined, elems...)
+}
@@ -0,0 +1,200 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTarget = topoapi.ID("target-1")
+
+func testPath(t *testing.T, path string) *gpb.Path {
+	gnmiPath, err := utils.ParseGNMIElements(utils.SplitPath(path))
+	assert.NoError(t, err)
+	return gnmiPath
+}
+
+func testNotification(t *testing.T, path string, value string) *gpb.Notification {
+	return &gpb.Notification{
+		Timestamp: time.Now().UnixNano(),
+		Prefix:    &gpb.Path{Target: string(testTarget)},
+		Update: []*gpb.Update{
+			{
+				Path: testPath(t, path),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: value}},
+			},
+		},
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	cache := NewCache()
+	interfaces := testPath(t, "/interfaces/interface[name=*]/state")
+	eth1 := testPath(t, "/interfaces/interface[name=eth1]/state")
+	system := testPath(t, "/system/state")
+
+	_, _, err := cache.Get(testTarget, []*gpb.Path{eth1})
+	assert.True(t, errors.IsNotFound(err))
+
+	generation := cache.Open(testTarget, []*gpb.Path{interfaces}, 0)
+	assert.True(t, cache.Covers(testTarget, []*gpb.Path{eth1}))
+	assert.False(t, cache.Covers(testTarget, []*gpb.Path{system}))
+	assert.False(t, cache.Covers(topoapi.ID("target-2"), []*gpb.Path{eth1}))
+
+	cache.Update(testTarget, generation, testNotification(t, "/interfaces/interface[name=eth1]/state/oper-status", "UP"))
+	cache.Update(testTarget, generation, testNotification(t, "/interfaces/interface[name=eth2]/state/oper-status", "DOWN"))
+
+	// The cache is not available until the subscription is synchronized
+	_, info, err := cache.Get(testTarget, []*gpb.Path{eth1})
+	assert.True(t, errors.IsUnavailable(err))
+	assert.False(t, info.Synced)
+
+	cache.Sync(testTarget, generation)
+	notifications, info, err := cache.Get(testTarget, []*gpb.Path{eth1})
+	assert.NoError(t, err)
+	assert.True(t, info.Synced)
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, string(testTarget), notifications[0].Prefix.Target)
+	assert.Len(t, notifications[0].Update, 1)
+	assert.Equal(t, "/interfaces/interface[name=eth1]/state/oper-status", utils.StrPath(notifications[0].Update[0].Path))
+	assert.Equal(t, "UP", notifications[0].Update[0].Val.GetStringVal())
+
+	notifications, _, err = cache.Get(testTarget, []*gpb.Path{interfaces})
+	assert.NoError(t, err)
+	assert.Len(t, notifications[0].Update, 2)
+
+	// Deleting a parent path removes the cached values beneath it
+	cache.Update(testTarget, generation, &gpb.Notification{
+		Prefix: &gpb.Path{Target: string(testTarget)},
+		Delete: []*gpb.Path{testPath(t, "/interfaces/interface[name=eth2]")},
+	})
+	notifications, _, err = cache.Get(testTarget, []*gpb.Path{interfaces})
+	assert.NoError(t, err)
+	assert.Len(t, notifications[0].Update, 1)
+
+	cache.Unsync(testTarget, generation)
+	_, _, err = cache.Get(testTarget, []*gpb.Path{eth1})
+	assert.True(t, errors.IsUnavailable(err))
+
+	cache.Close(testTarget)
+	_, _, err = cache.Get(testTarget, []*gpb.Path{eth1})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestCacheGeneration(t *testing.T) {
+	cache := NewCache()
+	state := testPath(t, "/system/state")
+	oldGeneration := cache.Open(testTarget, []*gpb.Path{state}, 0)
+	generation := cache.Open(testTarget, []*gpb.Path{state}, 0)
+	assert.NotEqual(t, oldGeneration, generation)
+
+	cache.Update(testTarget, generation, testNotification(t, "/system/state/hostname", "switch-1"))
+	cache.Sync(testTarget, generation)
+
+	// Writes made by the subscription of an earlier generation are ignored
+	cache.Update(testTarget, oldGeneration, testNotification(t, "/system/state/hostname", "switch-0"))
+	cache.Unsync(testTarget, oldGeneration)
+	notifications, info, err := cache.Get(testTarget, []*gpb.Path{state})
+	assert.NoError(t, err)
+	assert.True(t, info.Synced)
+	assert.Equal(t, "switch-1", notifications[0].Update[0].Val.GetStringVal())
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	cache := NewCache()
+	state := testPath(t, "/system/state")
+	generation := cache.Open(testTarget, []*gpb.Path{state}, 50*time.Millisecond)
+	cache.Update(testTarget, generation, testNotification(t, "/system/state/hostname", "switch-1"))
+	cache.Sync(testTarget, generation)
+
+	_, _, err := cache.Get(testTarget, []*gpb.Path{state})
+	assert.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	_, info, err := cache.Get(testTarget, []*gpb.Path{state})
+	assert.True(t, errors.IsUnavailable(err))
+	assert.True(t, info.Synced)
+
+	cache.Update(testTarget, generation, testNotification(t, "/system/state/hostname", "switch-2"))
+	notifications, _, err := cache.Get(testTarget, []*gpb.Path{state})
+	assert.NoError(t, err)
+	assert.Equal(t, "switch-2", notifications[0].Update[0].Val.GetStringVal())
+}
+
+func TestCacheWatch(t *testing.T) {
+	cache := NewCache()
+	generation := cache.Open(testTarget, []*gpb.Path{testPath(t, "/interfaces")}, 0)
+	cache.Sync(testTarget, generation)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *gpb.Notification, 10)
+	err := cache.Watch(ctx, testTarget, []*gpb.Path{testPath(t, "/interfaces/interface[name=eth1]")}, ch)
+	assert.NoError(t, err)
+
+	cache.Update(testTarget, generation, testNotification(t, "/interfaces/interface[name=eth2]/state/oper-status", "UP"))
+	cache.Update(testTarget, generation, testNotification(t, "/interfaces/interface[name=eth1]/state/oper-status", "UP"))
+
+	notification := <-ch
+	assert.Len(t, notification.Update, 1)
+	assert.Equal(t, "/interfaces/interface[name=eth1]/state/oper-status", utils.StrPath(notification.Update[0].Path))
+
+	// Deletes of parent paths are forwarded to the watcher
+	cache.Update(testTarget, generation, &gpb.Notification{
+		Prefix: &gpb.Path{Target: string(testTarget)},
+		Delete: []*gpb.Path{testPath(t, "/interfaces")},
+	})
+	notification = <-ch
+	assert.Len(t, notification.Delete, 1)
+	assert.Equal(t, "/interfaces", utils.StrPath(notification.Delete[0]))
+
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Closing the cache of a target closes the channels of its watchers
+	ch = make(chan *gpb.Notification, 10)
+	err = cache.Watch(context.Background(), testTarget, []*gpb.Path{testPath(t, "/interfaces")}, ch)
+	assert.NoError(t, err)
+	cache.Close(testTarget)
+	_, ok = <-ch
+	assert.False(t, ok)
+}
+
+func TestCacheSlowWatcher(t *testing.T) {
+	cache := NewCache()
+	generation := cache.Open(testTarget, []*gpb.Path{testPath(t, "/system")}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan *gpb.Notification, 1)
+	err := cache.Watch(ctx, testTarget, []*gpb.Path{testPath(t, "/system")}, ch)
+	assert.NoError(t, err)
+
+	cache.Update(testTarget, generation, testNotification(t, "/system/state/hostname", "switch-1"))
+	cache.Update(testTarget, generation, testNotification(t, "/system/state/hostname", "switch-2"))
+
+	_, ok := <-ch
+	assert.True(t, ok)
+	_, ok = <-ch
+	assert.False(t, ok)
+}
@@ -0,0 +1,281 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var boltBucket = []byte("configurations")
+
+// NewBoltStore returns a new Store that persists configurations in the given bbolt database.
+// Each change is committed to disk before it is acknowledged or published to watchers, so the
+// store survives process crashes. The database is owned by the caller and is not closed by the store.
+func NewBoltStore(db *bbolt.DB) (Store, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.NewInternal("failed to initialize configuration store: %v", err)
+	}
+	store := &boltStore{
+		db:       db,
+		watchers: watchers.New(),
+	}
+	return store, nil
+}
+
+type boltStore struct {
+	db       *bbolt.DB
+	mu       sync.RWMutex
+	watchers *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are committed.
+func (s *boltStore) publishEvent(eventType configapi.ConfigurationEvent_EventType, entry *memoryEntry) {
+	var configuration configapi.Configuration
+	if err := decodeMemoryEntry(entry, &configuration); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.ConfigurationEvent{
+		Type:          eventType,
+		Configuration: configuration,
+	})
+}
+
+// Get gets a configuration
+func (s *boltStore) Get(ctx context.Context, id configapi.ConfigurationID) (*configapi.Configuration, error) {
+	var entry *memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(boltBucket).Get([]byte(id))
+		if value == nil {
+			return errors.NewNotFound("configuration '%s' not found", id)
+		}
+		entry = decodeBoltEntry(id, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	configuration := &configapi.Configuration{}
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return nil, errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	return configuration, nil
+}
+
+// Create creates a new configuration
+func (s *boltStore) Create(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeCreate(configuration)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = createEntry(&boltEntries{bucket: tx.Bucket(boltBucket)}, configuration.ID, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the configuration from the stored entry.
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ConfigurationEvent_CREATED, entry)
+	return nil
+}
+
+// Update updates an existing configuration
+func (s *boltStore) Update(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeUpdate(configuration, true)
+	if err != nil {
+		return err
+	}
+	return s.update(configuration, bytes)
+}
+
+// UpdateStatus updates an existing configuration status
+func (s *boltStore) UpdateStatus(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeUpdate(configuration, false)
+	if err != nil {
+		return err
+	}
+	return s.update(configuration, bytes)
+}
+
+// update replaces the stored configuration, using the configuration version as an optimistic lock
+func (s *boltStore) update(configuration *configapi.Configuration, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = updateEntry(&boltEntries{bucket: tx.Bucket(boltBucket)}, configuration.ID, configuration.Version, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the configuration from the stored entry.
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ConfigurationEvent_UPDATED, entry)
+	return nil
+}
+
+// List lists configurations
+func (s *boltStore) List(ctx context.Context) ([]*configapi.Configuration, error) {
+	entries, err := s.list("")
+	if err != nil {
+		return nil, err
+	}
+	configurations := make([]*configapi.Configuration, 0, len(entries))
+	for _, entry := range entries {
+		configuration := &configapi.Configuration{}
+		if err := decodeMemoryEntry(entry, configuration); err != nil {
+			log.Error(err)
+		} else {
+			configurations = append(configurations, configuration)
+		}
+	}
+	return configurations, nil
+}
+
+// list reads the stored entries, optionally filtered by configuration ID
+func (s *boltStore) list(id configapi.ConfigurationID) ([]*memoryEntry, error) {
+	var entries []*memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).ForEach(func(key, value []byte) error {
+			if id == "" || configapi.ConfigurationID(key) == id {
+				entries = append(entries, decodeBoltEntry(configapi.ConfigurationID(key), value))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fromBolt(err)
+	}
+	return entries, nil
+}
+
+// Watch watches the configuration store for changes
+func (s *boltStore) Watch(ctx context.Context, ch chan<- configapi.ConfigurationEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current configurations and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		entries, err := s.list(options.configurationID)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			var configuration configapi.Configuration
+			if err := decodeMemoryEntry(entry, &configuration); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.ConfigurationEvent{
+					Type:          configapi.ConfigurationEvent_REPLAYED,
+					Configuration: configuration,
+				})
+			}
+		}
+	}
+
+	s.watchers.Add(ctx, &configurationWatcher{id: options.configurationID, ch: ch}, replay)
+	return nil
+}
+
+// Close closes the store
+func (s *boltStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// boltEntries stores entries in a bucket within a database transaction. The revision sequence
+// is only advanced if the transaction is committed.
+type boltEntries struct {
+	bucket *bbolt.Bucket
+}
+
+func (e *boltEntries) getEntry(id configapi.ConfigurationID) *memoryEntry {
+	value := e.bucket.Get([]byte(id))
+	if value == nil {
+		return nil
+	}
+	return decodeBoltEntry(id, value)
+}
+
+func (e *boltEntries) putEntry(entry *memoryEntry) error {
+	return e.bucket.Put([]byte(entry.id), encodeBoltEntry(entry))
+}
+
+func (e *boltEntries) nextRevision() (uint64, error) {
+	return e.bucket.NextSequence()
+}
+
+// encodeBoltEntry encodes an entry as its revision followed by the configuration bytes
+func encodeBoltEntry(entry *memoryEntry) []byte {
+	bytes := make([]byte, 8+len(entry.value))
+	binary.BigEndian.PutUint64(bytes[0:8], entry.revision)
+	copy(bytes[8:], entry.value)
+	return bytes
+}
+
+// decodeBoltEntry decodes an entry read from the database. The value is copied
+// because bbolt values are only valid for the life of the database transaction.
+func decodeBoltEntry(id configapi.ConfigurationID, bytes []byte) *memoryEntry {
+	value := make([]byte, len(bytes)-8)
+	copy(value, bytes[8:])
+	return &memoryEntry{
+		id:       id,
+		revision: binary.BigEndian.Uint64(bytes[0:8]),
+		value:    value,
+	}
+}
+
+// fromBolt passes typed errors through and wraps database errors
+func fromBolt(err error) error {
+	if errors.IsNotFound(err) || errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		return err
+	}
+	return errors.NewInternal("configuration store failed: %v", err)
+}
+
+var _ Store = &boltStore{}
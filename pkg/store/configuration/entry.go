@@ -0,0 +1,138 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// entryStorage stores the encoded entries of a configuration store. The memory and bolt stores only
+// differ in how entries are stored; revisions and optimistic locking are implemented once by
+// createEntry and updateEntry so that both backends handle conflicts the same way.
+type entryStorage interface {
+	// getEntry returns the stored entry with the given ID, or nil if the entry does not exist
+	getEntry(id configapi.ConfigurationID) *memoryEntry
+	// putEntry stores the given entry
+	putEntry(entry *memoryEntry) error
+	// nextRevision returns the next revision of the store
+	nextRevision() (uint64, error)
+}
+
+// encodeCreate validates a new configuration, initializes its revision and timestamps and encodes it
+func encodeCreate(configuration *configapi.Configuration) ([]byte, error) {
+	if configuration.ID == "" {
+		return nil, errors.NewInvalid("no configuration ID specified")
+	}
+	if configuration.TargetID == "" {
+		return nil, errors.NewInvalid("no target ID specified")
+	}
+	if configuration.Revision != 0 {
+		return nil, errors.NewInvalid("cannot create configuration with revision")
+	}
+	if configuration.Version != 0 {
+		return nil, errors.NewInvalid("cannot create configuration with version")
+	}
+	configuration.Revision = 1
+	configuration.Created = time.Now()
+	configuration.Updated = time.Now()
+	return encodeConfiguration(configuration)
+}
+
+// encodeUpdate validates an updated configuration, bumps its timestamp and its revision if requested and encodes it
+func encodeUpdate(configuration *configapi.Configuration, incrementRevision bool) ([]byte, error) {
+	if err := validateUpdate(configuration); err != nil {
+		return nil, err
+	}
+	if incrementRevision {
+		configuration.Revision++
+	}
+	configuration.Updated = time.Now()
+	return encodeConfiguration(configuration)
+}
+
+// validateUpdate validates the fields required to update a configuration
+func validateUpdate(configuration *configapi.Configuration) error {
+	if configuration.ID == "" {
+		return errors.NewInvalid("no configuration ID specified")
+	}
+	if configuration.TargetID == "" {
+		return errors.NewInvalid("no target ID specified")
+	}
+	if configuration.Revision == 0 {
+		return errors.NewInvalid("configuration must contain a revision on update")
+	}
+	if configuration.Version == 0 {
+		return errors.NewInvalid("configuration must contain a version on update")
+	}
+	return nil
+}
+
+func encodeConfiguration(configuration *configapi.Configuration) ([]byte, error) {
+	bytes, err := proto.Marshal(configuration)
+	if err != nil {
+		return nil, errors.NewInvalid("configuration encoding failed: %v", err)
+	}
+	return bytes, nil
+}
+
+// createEntry stores a new entry for the given configuration bytes at the next revision
+func createEntry(storage entryStorage, id configapi.ConfigurationID, value []byte) (*memoryEntry, error) {
+	if storage.getEntry(id) != nil {
+		return nil, errors.NewAlreadyExists("configuration '%s' already exists", id)
+	}
+	return putEntry(storage, id, value)
+}
+
+// updateEntry replaces the stored entry for the given configuration bytes, using the version as an optimistic lock
+func updateEntry(storage entryStorage, id configapi.ConfigurationID, version uint64, value []byte) (*memoryEntry, error) {
+	stored := storage.getEntry(id)
+	if stored == nil {
+		return nil, errors.NewNotFound("configuration '%s' not found", id)
+	}
+	if stored.revision != version {
+		return nil, errors.NewConflict("configuration '%s' version %d does not match the stored version %d", id, version, stored.revision)
+	}
+	return putEntry(storage, id, value)
+}
+
+func putEntry(storage entryStorage, id configapi.ConfigurationID, value []byte) (*memoryEntry, error) {
+	revision, err := storage.nextRevision()
+	if err != nil {
+		return nil, err
+	}
+	entry := &memoryEntry{
+		id:       id,
+		revision: revision,
+		value:    value,
+	}
+	if err := storage.putEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func decodeMemoryEntry(entry *memoryEntry, configuration *configapi.Configuration) error {
+	if err := proto.Unmarshal(entry.value, configuration); err != nil {
+		return err
+	}
+	configuration.ID = entry.id
+	configuration.Key = string(entry.id)
+	configuration.Version = entry.revision
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// NewMemoryStore returns a new Store that keeps configurations in memory.
+// The store has the same semantics as the Atomix store but is not shared between nodes or persisted.
+func NewMemoryStore() Store {
+	store := &memoryStore{
+		entries:  make(map[configapi.ConfigurationID]*memoryEntry),
+		watchers: watchers.New(),
+	}
+	return store
+}
+
+// memoryEntry is an encoded configuration in the memory store
+type memoryEntry struct {
+	id       configapi.ConfigurationID
+	revision uint64
+	value    []byte
+}
+
+type memoryStore struct {
+	entries  map[configapi.ConfigurationID]*memoryEntry
+	revision uint64
+	mu       sync.RWMutex
+	watchers *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are made. Events are queued
+// for each watcher, so publishing never blocks on watchers.
+func (s *memoryStore) publishEvent(eventType configapi.ConfigurationEvent_EventType, entry *memoryEntry) {
+	var configuration configapi.Configuration
+	if err := decodeMemoryEntry(entry, &configuration); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.ConfigurationEvent{
+		Type:          eventType,
+		Configuration: configuration,
+	})
+}
+
+func (s *memoryStore) Get(ctx context.Context, id configapi.ConfigurationID) (*configapi.Configuration, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("configuration '%s' not found", id)
+	}
+	configuration := &configapi.Configuration{}
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return nil, errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	return configuration, nil
+}
+
+func (s *memoryStore) Create(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeCreate(configuration)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := createEntry(s, configuration.ID, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the configuration from the stored entry.
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ConfigurationEvent_CREATED, entry)
+	return nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeUpdate(configuration, true)
+	if err != nil {
+		return err
+	}
+	return s.update(configuration, bytes)
+}
+
+func (s *memoryStore) UpdateStatus(ctx context.Context, configuration *configapi.Configuration) error {
+	bytes, err := encodeUpdate(configuration, false)
+	if err != nil {
+		return err
+	}
+	return s.update(configuration, bytes)
+}
+
+// update replaces the stored configuration, using the configuration version as an optimistic lock
+func (s *memoryStore) update(configuration *configapi.Configuration, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := updateEntry(s, configuration.ID, configuration.Version, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the configuration from the stored entry.
+	if err := decodeMemoryEntry(entry, configuration); err != nil {
+		return errors.NewInvalid("configuration decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ConfigurationEvent_UPDATED, entry)
+	return nil
+}
+
+func (s *memoryStore) List(ctx context.Context) ([]*configapi.Configuration, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	configurations := make([]*configapi.Configuration, 0, len(s.entries))
+	for _, entry := range s.entries {
+		configuration := &configapi.Configuration{}
+		if err := decodeMemoryEntry(entry, configuration); err != nil {
+			log.Error(err)
+		} else {
+			configurations = append(configurations, configuration)
+		}
+	}
+	return configurations, nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, ch chan<- configapi.ConfigurationEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current configurations and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		for _, entry := range s.entries {
+			if options.configurationID != "" && entry.id != options.configurationID {
+				continue
+			}
+			var configuration configapi.Configuration
+			if err := decodeMemoryEntry(entry, &configuration); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.ConfigurationEvent{
+					Type:          configapi.ConfigurationEvent_REPLAYED,
+					Configuration: configuration,
+				})
+			}
+		}
+	}
+	s.watchers.Add(ctx, &configurationWatcher{id: options.configurationID, ch: ch}, replay)
+	return nil
+}
+
+func (s *memoryStore) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *memoryStore) getEntry(id configapi.ConfigurationID) *memoryEntry {
+	return s.entries[id]
+}
+
+func (s *memoryStore) putEntry(entry *memoryEntry) error {
+	s.entries[entry.id] = entry
+	return nil
+}
+
+func (s *memoryStore) nextRevision() (uint64, error) {
+	s.revision++
+	return s.revision, nil
+}
+
+var _ Store = &memoryStore{}
@@ -0,0 +1,104 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryConfigurationStore(t *testing.T) {
+	store := NewMemoryStore()
+	testConfigurationStore(t, store, store)
+}
+
+func TestMemoryConfigurationStoreSemantics(t *testing.T) {
+	testStoreSemantics(t, NewMemoryStore())
+}
+
+// testStoreSemantics verifies the revision and optimistic locking semantics shared by all backends
+func testStoreSemantics(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	config := &configapi.Configuration{
+		ID:       NewID("target-1"),
+		TargetID: "target-1",
+	}
+	assert.NoError(t, store.Create(ctx, config))
+	assert.True(t, errors.IsAlreadyExists(store.Create(ctx, &configapi.Configuration{ID: config.ID, TargetID: "target-1"})))
+	_, err := store.Get(ctx, NewID("target-2"))
+	assert.True(t, errors.IsNotFound(err))
+
+	// Status updates do not change the revision but do change the version
+	stale, err := store.Get(ctx, config.ID)
+	assert.NoError(t, err)
+	version := config.Version
+	config.Status.State = configapi.ConfigurationStatus_SYNCHRONIZED
+	assert.NoError(t, store.UpdateStatus(ctx, config))
+	assert.Equal(t, configapi.Revision(1), config.Revision)
+	assert.NotEqual(t, version, config.Version)
+	assert.True(t, errors.IsConflict(store.UpdateStatus(ctx, stale)))
+
+	ch := make(chan configapi.ConfigurationEvent)
+	assert.NoError(t, store.Watch(ctx, ch, WithReplay(), WithConfigurationID(config.ID)))
+	replayed := nextEvent(t, ch)
+	assert.Equal(t, config.ID, replayed.ID)
+	assert.Equal(t, configapi.ConfigurationStatus_SYNCHRONIZED, replayed.Status.State)
+}
+
+func TestMemoryConfigurationStoreSlowWatcher(t *testing.T) {
+	testSlowWatcher(t, NewMemoryStore())
+}
+
+// testSlowWatcher verifies a watcher that does not read its channel blocks neither changes nor other watchers
+func testSlowWatcher(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	slowCh := make(chan configapi.ConfigurationEvent)
+	assert.NoError(t, store.Watch(ctx, slowCh))
+	ch := make(chan configapi.ConfigurationEvent)
+	assert.NoError(t, store.Watch(ctx, ch))
+
+	config := &configapi.Configuration{
+		ID:       NewID("target-1"),
+		TargetID: "target-1",
+	}
+	assert.NoError(t, store.Create(ctx, config))
+	for i := 0; i < 100; i++ {
+		config.Status.State = configapi.ConfigurationStatus_SYNCHRONIZING
+		assert.NoError(t, store.UpdateStatus(ctx, config))
+	}
+	_, err := store.Get(ctx, config.ID)
+	assert.NoError(t, err)
+
+	// All changes are received in order by the other watcher
+	var version uint64
+	for i := 0; i <= 100; i++ {
+		event := nextEvent(t, ch)
+		assert.Greater(t, event.Version, version)
+		version = event.Version
+	}
+	assert.Equal(t, config.Version, version)
+
+	// The channel of the slow watcher is closed once the watch is canceled
+	cancel()
+	for range slowCh {
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"sync"
+
+	"github.com/atomix/atomix-go-client/pkg/atomix"
+	_map "github.com/atomix/atomix-go-client/pkg/atomix/map"
+	"github.com/golang/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// StatusStore stores the status onos-config tracks for configurations in addition to the status of the
+// configurations themselves, e.g. the result of the last drift check of the configuration's target.
+// Statuses are keyed by configuration ID and are only written by the master for the configuration's target.
+type StatusStore interface {
+	// Get gets the status of a configuration
+	Get(ctx context.Context, id configapi.ConfigurationID) (*adminapi.ConfigurationStatus, error)
+
+	// Update creates or replaces the status of a configuration
+	Update(ctx context.Context, status *adminapi.ConfigurationStatus) error
+
+	// List lists the status of all configurations
+	List(ctx context.Context) ([]*adminapi.ConfigurationStatus, error)
+
+	Close(ctx context.Context) error
+}
+
+// NewAtomixStatusStore returns a new persistent StatusStore
+func NewAtomixStatusStore(client atomix.Client) (StatusStore, error) {
+	statuses, err := client.GetMap(context.Background(), "onos-config-configuration-statuses")
+	if err != nil {
+		return nil, errors.FromAtomix(err)
+	}
+	return &atomixStatusStore{
+		statuses: statuses,
+	}, nil
+}
+
+type atomixStatusStore struct {
+	statuses _map.Map
+}
+
+func (s *atomixStatusStore) Get(ctx context.Context, id configapi.ConfigurationID) (*adminapi.ConfigurationStatus, error) {
+	if id == "" {
+		return nil, errors.NewInvalid("no configuration ID specified")
+	}
+	entry, err := s.statuses.Get(ctx, string(id))
+	if err != nil {
+		return nil, errors.FromAtomix(err)
+	}
+	return decodeStatus(entry.Value)
+}
+
+func (s *atomixStatusStore) Update(ctx context.Context, status *adminapi.ConfigurationStatus) error {
+	bytes, err := encodeStatus(status)
+	if err != nil {
+		return err
+	}
+	if _, err := s.statuses.Put(ctx, string(status.ID), bytes); err != nil {
+		return errors.FromAtomix(err)
+	}
+	return nil
+}
+
+func (s *atomixStatusStore) List(ctx context.Context) ([]*adminapi.ConfigurationStatus, error) {
+	mapCh := make(chan _map.Entry)
+	if err := s.statuses.Entries(ctx, mapCh); err != nil {
+		return nil, errors.FromAtomix(err)
+	}
+	statuses := make([]*adminapi.ConfigurationStatus, 0)
+	for entry := range mapCh {
+		status, err := decodeStatus(entry.Value)
+		if err != nil {
+			log.Error(err)
+		} else {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+func (s *atomixStatusStore) Close(ctx context.Context) error {
+	if err := s.statuses.Close(ctx); err != nil {
+		return errors.FromAtomix(err)
+	}
+	return nil
+}
+
+// NewMemoryStatusStore returns a new StatusStore that keeps statuses in memory
+func NewMemoryStatusStore() StatusStore {
+	return &memoryStatusStore{
+		statuses: make(map[configapi.ConfigurationID][]byte),
+	}
+}
+
+type memoryStatusStore struct {
+	statuses map[configapi.ConfigurationID][]byte
+	mu       sync.RWMutex
+}
+
+func (s *memoryStatusStore) Get(ctx context.Context, id configapi.ConfigurationID) (*adminapi.ConfigurationStatus, error) {
+	s.mu.RLock()
+	bytes, ok := s.statuses[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("status of configuration '%s' not found", id)
+	}
+	return decodeStatus(bytes)
+}
+
+func (s *memoryStatusStore) Update(ctx context.Context, status *adminapi.ConfigurationStatus) error {
+	bytes, err := encodeStatus(status)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.statuses[status.ID] = bytes
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *memoryStatusStore) List(ctx context.Context) ([]*adminapi.ConfigurationStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]*adminapi.ConfigurationStatus, 0, len(s.statuses))
+	for _, bytes := range s.statuses {
+		status, err := decodeStatus(bytes)
+		if err != nil {
+			log.Error(err)
+		} else {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+func (s *memoryStatusStore) Close(ctx context.Context) error {
+	return nil
+}
+
+var boltStatusBucket = []byte("configuration-statuses")
+
+// NewBoltStatusStore returns a new StatusStore that persists statuses in the given bbolt database.
+// The database is owned by the caller and is not closed by the store.
+func NewBoltStatusStore(db *bbolt.DB) (StatusStore, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltStatusBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.NewInternal("failed to initialize configuration status store: %v", err)
+	}
+	return &boltStatusStore{
+		db: db,
+	}, nil
+}
+
+type boltStatusStore struct {
+	db *bbolt.DB
+}
+
+func (s *boltStatusStore) Get(ctx context.Context, id configapi.ConfigurationID) (*adminapi.ConfigurationStatus, error) {
+	var bytes []byte
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(boltStatusBucket).Get([]byte(id))
+		if value == nil {
+			return errors.NewNotFound("status of configuration '%s' not found", id)
+		}
+		bytes = append([]byte(nil), value...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeStatus(bytes)
+}
+
+func (s *boltStatusStore) Update(ctx context.Context, status *adminapi.ConfigurationStatus) error {
+	bytes, err := encodeStatus(status)
+	if err != nil {
+		return err
+	}
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltStatusBucket).Put([]byte(status.ID), bytes)
+	})
+	if err != nil {
+		return errors.NewInternal("failed to update status of configuration '%s': %v", status.ID, err)
+	}
+	return nil
+}
+
+func (s *boltStatusStore) List(ctx context.Context) ([]*adminapi.ConfigurationStatus, error) {
+	statuses := make([]*adminapi.ConfigurationStatus, 0)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltStatusBucket).ForEach(func(key, value []byte) error {
+			status, err := decodeStatus(value)
+			if err != nil {
+				log.Error(err)
+			} else {
+				statuses = append(statuses, status)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.NewInternal("failed to list configuration statuses: %v", err)
+	}
+	return statuses, nil
+}
+
+func (s *boltStatusStore) Close(ctx context.Context) error {
+	return nil
+}
+
+func encodeStatus(status *adminapi.ConfigurationStatus) ([]byte, error) {
+	if status.ID == "" {
+		return nil, errors.NewInvalid("no configuration ID specified")
+	}
+	bytes, err := proto.Marshal(status)
+	if err != nil {
+		return nil, errors.NewInvalid("configuration status encoding failed: %v", err)
+	}
+	return bytes, nil
+}
+
+func decodeStatus(bytes []byte) (*adminapi.ConfigurationStatus, error) {
+	status := &adminapi.ConfigurationStatus{}
+	if err := proto.Unmarshal(bytes, status); err != nil {
+		return nil, errors.NewInvalid("configuration status decoding failed: %v", err)
+	}
+	return status, nil
+}
+
+var _ StatusStore = &atomixStatusStore{}
+var _ StatusStore = &memoryStatusStore{}
+var _ StatusStore = &boltStatusStore{}
@@ -0,0 +1,86 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	adminapi "github.com/onosproject/onos-config/api/admin"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestMemoryStatusStore(t *testing.T) {
+	testStatusStore(t, NewMemoryStatusStore())
+}
+
+func TestBoltStatusStore(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "onos-config.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err := NewBoltStatusStore(db)
+	assert.NoError(t, err)
+	testStatusStore(t, store)
+}
+
+func testStatusStore(t *testing.T, store StatusStore) {
+	ctx := context.Background()
+	id := NewID("target-1")
+
+	_, err := store.Get(ctx, id)
+	assert.True(t, errors.IsNotFound(err))
+	assert.True(t, errors.IsInvalid(store.Update(ctx, &adminapi.ConfigurationStatus{})))
+
+	checked := time.Now().UTC()
+	status := &adminapi.ConfigurationStatus{
+		ID:       id,
+		TargetID: "target-1",
+		Drift: &adminapi.DriftStatus{
+			Checked: &checked,
+			Index:   1,
+			DriftedPaths: []adminapi.DriftedPath{
+				{Path: "/a", Intended: "1", Actual: "2"},
+			},
+		},
+	}
+	assert.NoError(t, store.Update(ctx, status))
+
+	status, err = store.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.TargetID("target-1"), status.TargetID)
+	assert.True(t, checked.Equal(*status.Drift.Checked))
+	assert.Len(t, status.Drift.DriftedPaths, 1)
+
+	// Updating the status replaces it
+	status.Drift.DriftedPaths = nil
+	assert.NoError(t, store.Update(ctx, status))
+	status, err = store.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Empty(t, status.Drift.DriftedPaths)
+
+	assert.NoError(t, store.Update(ctx, &adminapi.ConfigurationStatus{
+		ID:       NewID("target-2"),
+		TargetID: "target-2",
+	}))
+	statuses, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.NoError(t, store.Close(ctx))
+}
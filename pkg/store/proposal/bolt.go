@@ -0,0 +1,281 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var boltBucket = []byte("proposals")
+
+// NewBoltStore returns a new Store that persists proposals in the given bbolt database.
+// Each change is committed to disk before it is acknowledged or published to watchers, so the
+// store survives process crashes. The database is owned by the caller and is not closed by the store.
+func NewBoltStore(db *bbolt.DB) (Store, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		return nil, errors.NewInternal("failed to initialize proposal store: %v", err)
+	}
+	store := &boltStore{
+		db:       db,
+		watchers: watchers.New(),
+	}
+	return store, nil
+}
+
+type boltStore struct {
+	db       *bbolt.DB
+	mu       sync.RWMutex
+	watchers *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are committed.
+func (s *boltStore) publishEvent(eventType configapi.ProposalEvent_EventType, entry *memoryEntry) {
+	var proposal configapi.Proposal
+	if err := decodeMemoryEntry(entry, &proposal); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.ProposalEvent{
+		Type:     eventType,
+		Proposal: proposal,
+	})
+}
+
+// Get gets a proposal
+func (s *boltStore) Get(ctx context.Context, id configapi.ProposalID) (*configapi.Proposal, error) {
+	var entry *memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(boltBucket).Get([]byte(id))
+		if value == nil {
+			return errors.NewNotFound("proposal '%s' not found", id)
+		}
+		entry = decodeBoltEntry(id, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	proposal := &configapi.Proposal{}
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return nil, errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	return proposal, nil
+}
+
+// Create creates a new proposal
+func (s *boltStore) Create(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeCreate(proposal)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = createEntry(&boltEntries{bucket: tx.Bucket(boltBucket)}, proposal.ID, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the proposal from the stored entry.
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ProposalEvent_CREATED, entry)
+	return nil
+}
+
+// Update updates an existing proposal
+func (s *boltStore) Update(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeUpdate(proposal, true)
+	if err != nil {
+		return err
+	}
+	return s.update(proposal, bytes)
+}
+
+// UpdateStatus updates an existing proposal status
+func (s *boltStore) UpdateStatus(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeUpdate(proposal, false)
+	if err != nil {
+		return err
+	}
+	return s.update(proposal, bytes)
+}
+
+// update replaces the stored proposal, using the proposal version as an optimistic lock
+func (s *boltStore) update(proposal *configapi.Proposal, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = updateEntry(&boltEntries{bucket: tx.Bucket(boltBucket)}, proposal.ID, proposal.Version, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the proposal from the stored entry.
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ProposalEvent_UPDATED, entry)
+	return nil
+}
+
+// List lists proposals
+func (s *boltStore) List(ctx context.Context) ([]*configapi.Proposal, error) {
+	entries, err := s.list("")
+	if err != nil {
+		return nil, err
+	}
+	proposals := make([]*configapi.Proposal, 0, len(entries))
+	for _, entry := range entries {
+		proposal := &configapi.Proposal{}
+		if err := decodeMemoryEntry(entry, proposal); err != nil {
+			log.Error(err)
+		} else {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+// list reads the stored entries, optionally filtered by proposal ID
+func (s *boltStore) list(id configapi.ProposalID) ([]*memoryEntry, error) {
+	var entries []*memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).ForEach(func(key, value []byte) error {
+			if id == "" || configapi.ProposalID(key) == id {
+				entries = append(entries, decodeBoltEntry(configapi.ProposalID(key), value))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fromBolt(err)
+	}
+	return entries, nil
+}
+
+// Watch watches the proposal store for changes
+func (s *boltStore) Watch(ctx context.Context, ch chan<- configapi.ProposalEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current proposals and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		entries, err := s.list(options.proposalID)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			var proposal configapi.Proposal
+			if err := decodeMemoryEntry(entry, &proposal); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.ProposalEvent{
+					Type:     configapi.ProposalEvent_REPLAYED,
+					Proposal: proposal,
+				})
+			}
+		}
+	}
+
+	s.watchers.Add(ctx, &proposalWatcher{id: options.proposalID, ch: ch}, replay)
+	return nil
+}
+
+// Close closes the store
+func (s *boltStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// boltEntries stores entries in a bucket within a database transaction. The revision sequence
+// is only advanced if the transaction is committed.
+type boltEntries struct {
+	bucket *bbolt.Bucket
+}
+
+func (e *boltEntries) getEntry(id configapi.ProposalID) *memoryEntry {
+	value := e.bucket.Get([]byte(id))
+	if value == nil {
+		return nil
+	}
+	return decodeBoltEntry(id, value)
+}
+
+func (e *boltEntries) putEntry(entry *memoryEntry) error {
+	return e.bucket.Put([]byte(entry.id), encodeBoltEntry(entry))
+}
+
+func (e *boltEntries) nextRevision() (uint64, error) {
+	return e.bucket.NextSequence()
+}
+
+// encodeBoltEntry encodes an entry as its revision followed by the proposal bytes
+func encodeBoltEntry(entry *memoryEntry) []byte {
+	bytes := make([]byte, 8+len(entry.value))
+	binary.BigEndian.PutUint64(bytes[0:8], entry.revision)
+	copy(bytes[8:], entry.value)
+	return bytes
+}
+
+// decodeBoltEntry decodes an entry read from the database. The value is copied
+// because bbolt values are only valid for the life of the database transaction.
+func decodeBoltEntry(id configapi.ProposalID, bytes []byte) *memoryEntry {
+	value := make([]byte, len(bytes)-8)
+	copy(value, bytes[8:])
+	return &memoryEntry{
+		id:       id,
+		revision: binary.BigEndian.Uint64(bytes[0:8]),
+		value:    value,
+	}
+}
+
+// fromBolt passes typed errors through and wraps database errors
+func fromBolt(err error) error {
+	if errors.IsNotFound(err) || errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		return err
+	}
+	return errors.NewInternal("proposal store failed: %v", err)
+}
+
+var _ Store = &boltStore{}
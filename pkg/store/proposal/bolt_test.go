@@ -0,0 +1,79 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestBoltProposalStore(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "onos-config.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	testProposalStore(t, store, store)
+}
+
+func TestBoltProposalStoreSemantics(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "onos-config.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	testStoreSemantics(t, store)
+}
+
+func TestBoltProposalStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onos-config.db")
+	db, err := bbolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	proposal := &configapi.Proposal{
+		ID:               NewID("target-1", 1),
+		TargetID:         "target-1",
+		TransactionIndex: 1,
+	}
+	assert.NoError(t, store.Create(ctx, proposal))
+	stale, err := store.Get(ctx, proposal.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Update(ctx, proposal))
+	assert.NoError(t, db.Close())
+
+	// Reopen the database and verify the proposal was persisted
+	db, err = bbolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err = NewBoltStore(db)
+	assert.NoError(t, err)
+
+	persisted, err := store.Get(ctx, proposal.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, configapi.Revision(2), persisted.Revision)
+	assert.Equal(t, proposal.Version, persisted.Version)
+	assert.True(t, errors.IsConflict(store.Update(ctx, stale)))
+	assert.NoError(t, store.Update(ctx, persisted))
+	assert.Greater(t, persisted.Version, proposal.Version)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// entryStorage stores the encoded entries of a proposal store. The memory and bolt stores only
+// differ in how entries are stored; revisions and optimistic locking are implemented once by
+// createEntry and updateEntry so that both backends handle conflicts the same way.
+type entryStorage interface {
+	// getEntry returns the stored entry with the given ID, or nil if the entry does not exist
+	getEntry(id configapi.ProposalID) *memoryEntry
+	// putEntry stores the given entry
+	putEntry(entry *memoryEntry) error
+	// nextRevision returns the next revision of the store
+	nextRevision() (uint64, error)
+}
+
+// encodeCreate validates a new proposal, initializes its revision and timestamps and encodes it
+func encodeCreate(proposal *configapi.Proposal) ([]byte, error) {
+	if proposal.ID == "" {
+		return nil, errors.NewInvalid("no proposal ID specified")
+	}
+	if proposal.TargetID == "" {
+		return nil, errors.NewInvalid("no target ID specified")
+	}
+	if proposal.Revision != 0 {
+		return nil, errors.NewInvalid("cannot create proposal with revision")
+	}
+	if proposal.Version != 0 {
+		return nil, errors.NewInvalid("cannot create proposal with version")
+	}
+	proposal.Revision = 1
+	proposal.Created = time.Now()
+	proposal.Updated = time.Now()
+	return encodeProposal(proposal)
+}
+
+// encodeUpdate validates an updated proposal, bumps its timestamp and its revision if requested and encodes it
+func encodeUpdate(proposal *configapi.Proposal, incrementRevision bool) ([]byte, error) {
+	if err := validateUpdate(proposal); err != nil {
+		return nil, err
+	}
+	if incrementRevision {
+		proposal.Revision++
+	}
+	proposal.Updated = time.Now()
+	return encodeProposal(proposal)
+}
+
+// validateUpdate validates the fields required to update a proposal
+func validateUpdate(proposal *configapi.Proposal) error {
+	if proposal.ID == "" {
+		return errors.NewInvalid("no proposal ID specified")
+	}
+	if proposal.TransactionIndex == 0 {
+		return errors.NewInvalid("no transaction index specified")
+	}
+	if proposal.TargetID == "" {
+		return errors.NewInvalid("no target ID specified")
+	}
+	if proposal.Revision == 0 {
+		return errors.NewInvalid("proposal must contain a revision on update")
+	}
+	if proposal.Version == 0 {
+		return errors.NewInvalid("proposal must contain a version on update")
+	}
+	return nil
+}
+
+func encodeProposal(proposal *configapi.Proposal) ([]byte, error) {
+	bytes, err := proto.Marshal(proposal)
+	if err != nil {
+		return nil, errors.NewInvalid("proposal encoding failed: %v", err)
+	}
+	return bytes, nil
+}
+
+// createEntry stores a new entry for the given proposal bytes at the next revision
+func createEntry(storage entryStorage, id configapi.ProposalID, value []byte) (*memoryEntry, error) {
+	if storage.getEntry(id) != nil {
+		return nil, errors.NewAlreadyExists("proposal '%s' already exists", id)
+	}
+	return putEntry(storage, id, value)
+}
+
+// updateEntry replaces the stored entry for the given proposal bytes, using the version as an optimistic lock
+func updateEntry(storage entryStorage, id configapi.ProposalID, version uint64, value []byte) (*memoryEntry, error) {
+	stored := storage.getEntry(id)
+	if stored == nil {
+		return nil, errors.NewNotFound("proposal '%s' not found", id)
+	}
+	if stored.revision != version {
+		return nil, errors.NewConflict("proposal '%s' version %d does not match the stored version %d", id, version, stored.revision)
+	}
+	return putEntry(storage, id, value)
+}
+
+func putEntry(storage entryStorage, id configapi.ProposalID, value []byte) (*memoryEntry, error) {
+	revision, err := storage.nextRevision()
+	if err != nil {
+		return nil, err
+	}
+	entry := &memoryEntry{
+		id:       id,
+		revision: revision,
+		value:    value,
+	}
+	if err := storage.putEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func decodeMemoryEntry(entry *memoryEntry, proposal *configapi.Proposal) error {
+	if err := proto.Unmarshal(entry.value, proposal); err != nil {
+		return err
+	}
+	proposal.ID = entry.id
+	proposal.Key = string(entry.id)
+	proposal.Version = entry.revision
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"context"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// NewMemoryStore returns a new Store that keeps proposals in memory.
+// The store has the same semantics as the Atomix store but is not shared between nodes or persisted.
+func NewMemoryStore() Store {
+	store := &memoryStore{
+		entries:  make(map[configapi.ProposalID]*memoryEntry),
+		watchers: watchers.New(),
+	}
+	return store
+}
+
+// memoryEntry is an encoded proposal in the memory store
+type memoryEntry struct {
+	id       configapi.ProposalID
+	revision uint64
+	value    []byte
+}
+
+type memoryStore struct {
+	entries  map[configapi.ProposalID]*memoryEntry
+	revision uint64
+	mu       sync.RWMutex
+	watchers *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are made. Events are queued
+// for each watcher, so publishing never blocks on watchers.
+func (s *memoryStore) publishEvent(eventType configapi.ProposalEvent_EventType, entry *memoryEntry) {
+	var proposal configapi.Proposal
+	if err := decodeMemoryEntry(entry, &proposal); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.ProposalEvent{
+		Type:     eventType,
+		Proposal: proposal,
+	})
+}
+
+func (s *memoryStore) Get(ctx context.Context, id configapi.ProposalID) (*configapi.Proposal, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("proposal '%s' not found", id)
+	}
+	proposal := &configapi.Proposal{}
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return nil, errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	return proposal, nil
+}
+
+func (s *memoryStore) Create(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeCreate(proposal)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := createEntry(s, proposal.ID, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the proposal from the stored entry.
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ProposalEvent_CREATED, entry)
+	return nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeUpdate(proposal, true)
+	if err != nil {
+		return err
+	}
+	return s.update(proposal, bytes)
+}
+
+func (s *memoryStore) UpdateStatus(ctx context.Context, proposal *configapi.Proposal) error {
+	bytes, err := encodeUpdate(proposal, false)
+	if err != nil {
+		return err
+	}
+	return s.update(proposal, bytes)
+}
+
+// update replaces the stored proposal, using the proposal version as an optimistic lock
+func (s *memoryStore) update(proposal *configapi.Proposal, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := updateEntry(s, proposal.ID, proposal.Version, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the proposal from the stored entry.
+	if err := decodeMemoryEntry(entry, proposal); err != nil {
+		return errors.NewInvalid("proposal decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.ProposalEvent_UPDATED, entry)
+	return nil
+}
+
+func (s *memoryStore) List(ctx context.Context) ([]*configapi.Proposal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	proposals := make([]*configapi.Proposal, 0, len(s.entries))
+	for _, entry := range s.entries {
+		proposal := &configapi.Proposal{}
+		if err := decodeMemoryEntry(entry, proposal); err != nil {
+			log.Error(err)
+		} else {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, ch chan<- configapi.ProposalEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current proposals and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		for _, entry := range s.entries {
+			if options.proposalID != "" && entry.id != options.proposalID {
+				continue
+			}
+			var proposal configapi.Proposal
+			if err := decodeMemoryEntry(entry, &proposal); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.ProposalEvent{
+					Type:     configapi.ProposalEvent_REPLAYED,
+					Proposal: proposal,
+				})
+			}
+		}
+	}
+
+	s.watchers.Add(ctx, &proposalWatcher{id: options.proposalID, ch: ch}, replay)
+	return nil
+}
+
+func (s *memoryStore) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *memoryStore) getEntry(id configapi.ProposalID) *memoryEntry {
+	return s.entries[id]
+}
+
+func (s *memoryStore) putEntry(entry *memoryEntry) error {
+	s.entries[entry.id] = entry
+	return nil
+}
+
+func (s *memoryStore) nextRevision() (uint64, error) {
+	s.revision++
+	return s.revision, nil
+}
+
+var _ Store = &memoryStore{}
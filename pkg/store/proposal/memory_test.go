@@ -0,0 +1,64 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposal
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryProposalStore(t *testing.T) {
+	store := NewMemoryStore()
+	testProposalStore(t, store, store)
+}
+
+func TestMemoryProposalStoreSemantics(t *testing.T) {
+	testStoreSemantics(t, NewMemoryStore())
+}
+
+// testStoreSemantics verifies the revision and optimistic locking semantics shared by all backends
+func testStoreSemantics(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	proposal := &configapi.Proposal{
+		ID:               NewID("target-1", 1),
+		TargetID:         "target-1",
+		TransactionIndex: 1,
+	}
+	assert.NoError(t, store.Create(ctx, proposal))
+	assert.True(t, errors.IsAlreadyExists(store.Create(ctx, &configapi.Proposal{ID: proposal.ID, TargetID: "target-1"})))
+	_, err := store.Get(ctx, NewID("target-1", 2))
+	assert.True(t, errors.IsNotFound(err))
+
+	// Status updates do not change the revision but do change the version
+	stale, err := store.Get(ctx, proposal.ID)
+	assert.NoError(t, err)
+	version := proposal.Version
+	proposal.Status.Phases.Commit = &configapi.ProposalCommitPhase{State: configapi.ProposalCommitPhase_COMMITTED}
+	assert.NoError(t, store.UpdateStatus(ctx, proposal))
+	assert.Equal(t, configapi.Revision(1), proposal.Revision)
+	assert.NotEqual(t, version, proposal.Version)
+	assert.True(t, errors.IsConflict(store.UpdateStatus(ctx, stale)))
+
+	ch := make(chan configapi.ProposalEvent)
+	assert.NoError(t, store.Watch(ctx, ch, WithReplay(), WithProposalID(proposal.ID)))
+	replayed := nextEvent(t, ch)
+	assert.Equal(t, proposal.ID, replayed.ID)
+	assert.Equal(t, configapi.ProposalCommitPhase_COMMITTED, replayed.Status.Phases.Commit.State)
+}
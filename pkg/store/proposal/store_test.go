@@ -46,11 +46,15 @@ func TestProposalStore(t *testing.T) {
 	store2, err := NewAtomixStore(client2)
 	assert.NoError(t, err)
 
+	testProposalStore(t, store1, store2)
+}
+
+func testProposalStore(t *testing.T, store1 Store, store2 Store) {
 	target1 := configapi.TargetID("target-1")
 	target2 := configapi.TargetID("target-2")
 
 	ch := make(chan configapi.ProposalEvent)
-	err = store2.Watch(context.Background(), ch)
+	err := store2.Watch(context.Background(), ch)
 	assert.NoError(t, err)
 
 	target1ConfigValues := make(map[string]*configapi.PathValue)
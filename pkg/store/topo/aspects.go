@@ -0,0 +1,601 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Aspects specific to onos-config are not defined in the topology API. They are stored as raw JSON
+// aspects on the target entity and decoded into the types below.
+const (
+	// SyncOptionsAspect is the name of the aspect carrying the target's synchronization options
+	SyncOptionsAspect = "onos.config.SyncOptions"
+	// DriftOptionsAspect is the name of the aspect carrying the target's drift detection options
+	DriftOptionsAspect = "onos.config.DriftOptions"
+	// ModelCompatibilityAspect is the name of the aspect carrying the result of verifying the target's
+	// capabilities against the capabilities of its model plugin
+	ModelCompatibilityAspect = "onos.config.ModelCompatibility"
+	// SetProgressAspect is the name of the aspect tracking the progress of changes split into multiple Set requests
+	SetProgressAspect = "onos.config.SetProgress"
+	// ConnectionOptionsAspect is the name of the aspect carrying the target's southbound connection options
+	ConnectionOptionsAspect = "onos.config.ConnectionOptions"
+	// CredentialsAspect is the name of the aspect referencing the target's southbound credentials
+	CredentialsAspect = "onos.config.Credentials"
+	// CertificateOptionsAspect is the name of the aspect configuring the verification of a target's certificates
+	CertificateOptionsAspect = "onos.config.CertificateOptions"
+	// CertificateStatusAspect is the name of the aspect holding the certificates used by a southbound connection
+	CertificateStatusAspect = "onos.config.CertificateStatus"
+	// TelemetryCacheAspect is the name of the aspect configuring the telemetry cache for a target
+	TelemetryCacheAspect = "onos.config.TelemetryCache"
+	// SouthboundAspect is the name of the aspect selecting the southbound protocol used to manage a target
+	SouthboundAspect = "onos.config.Southbound"
+)
+
+// Duration is a time.Duration encoded in JSON as a duration string, e.g. "30s"
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes the duration from a string
+func (d *Duration) UnmarshalJSON(bytes []byte) error {
+	var s string
+	if err := json.Unmarshal(bytes, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// SyncOptions configures how onos-config pushes configuration changes to a target
+type SyncOptions struct {
+	// Coalesce indicates whether all committed but not yet applied proposals for the target
+	// should be merged into a single southbound Set request
+	Coalesce bool `json:"coalesce,omitempty"`
+	// Adopt indicates whether the configuration found on the target when it is first registered
+	// should be imported as the target's initial configuration
+	Adopt bool `json:"adopt,omitempty"`
+	// Replace indicates whether the target should be resynchronized by replacing its entire configuration
+	// at the root, removing any configuration on the target not present in the intended configuration
+	Replace bool `json:"replace,omitempty"`
+	// Encoding is the gNMI encoding used for southbound requests to the target, e.g. "PROTO" or "JSON_IETF".
+	// If not set, the encoding is negotiated from the encodings supported by the target.
+	Encoding string `json:"encoding,omitempty"`
+	// MaxUpdatesPerSet is the maximum number of operations sent to the target in a single Set request.
+	// Larger changes are split into multiple requests. If not set, the number of operations is unlimited.
+	MaxUpdatesPerSet int `json:"maxUpdatesPerSet,omitempty"`
+	// MaxBytesPerSet is the maximum size in bytes of the operations sent to the target in a single Set request.
+	// Larger changes are split into multiple requests. If not set, the size is unlimited.
+	MaxBytesPerSet int `json:"maxBytesPerSet,omitempty"`
+}
+
+// GetSyncOptions returns the synchronization options for the given target entity.
+// If the aspect is not set, the default options are returned.
+func GetSyncOptions(object *topoapi.Object) (*SyncOptions, error) {
+	options := &SyncOptions{}
+	if err := getAspect(object, SyncOptionsAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return options, nil
+}
+
+// preferredEncodings are the encodings onos-config can use for southbound requests, in the order in which
+// encodings supported by a target are chosen when negotiating
+var preferredEncodings = []gpb.Encoding{
+	gpb.Encoding_PROTO,
+	gpb.Encoding_JSON_IETF,
+	gpb.Encoding_JSON,
+}
+
+// GetEncoding returns the gNMI encoding to use for southbound requests to the given target entity.
+// If an encoding is configured in the target's sync options, it is returned. Otherwise, the requested encoding is
+// returned if the target supports it; if not, the first supported encoding in order of preference is returned.
+// If the target's supported encodings are not known, the requested encoding is returned.
+func GetEncoding(object *topoapi.Object, requested gpb.Encoding) (gpb.Encoding, error) {
+	options, err := GetSyncOptions(object)
+	if err != nil {
+		return requested, err
+	}
+	if options.Encoding != "" {
+		encoding, ok := gpb.Encoding_value[strings.ToUpper(options.Encoding)]
+		if !ok || !isPreferredEncoding(gpb.Encoding(encoding)) {
+			return requested, errors.NewInvalid("unsupported encoding '%s' for object '%s'", options.Encoding, object.ID)
+		}
+		return gpb.Encoding(encoding), nil
+	}
+
+	compatibility, err := GetModelCompatibility(object)
+	if err != nil || len(compatibility.Encodings) == 0 {
+		return requested, nil
+	}
+	supported := make(map[string]bool)
+	for _, encoding := range compatibility.Encodings {
+		supported[encoding] = true
+	}
+	if supported[requested.String()] && isPreferredEncoding(requested) {
+		return requested, nil
+	}
+	for _, encoding := range preferredEncodings {
+		if supported[encoding.String()] {
+			return encoding, nil
+		}
+	}
+	return requested, nil
+}
+
+// GetReplaceEncoding returns the gNMI encoding to use for replacing the full configuration of the given target
+// entity. A full configuration can only be sent as a JSON tree, so an error is returned if neither JSON_IETF nor
+// JSON is configured in the target's sync options or supported by the target.
+func GetReplaceEncoding(object *topoapi.Object) (gpb.Encoding, error) {
+	for _, requested := range []gpb.Encoding{gpb.Encoding_JSON_IETF, gpb.Encoding_JSON} {
+		encoding, err := GetEncoding(object, requested)
+		if err != nil {
+			return requested, err
+		}
+		if encoding == requested {
+			return encoding, nil
+		}
+	}
+	return gpb.Encoding_JSON_IETF, errors.NewNotSupported("object '%s' supports no JSON encoding to replace its configuration", object.ID)
+}
+
+// isPreferredEncoding returns whether the given encoding is one in which onos-config can exchange values with targets
+func isPreferredEncoding(encoding gpb.Encoding) bool {
+	for _, preferred := range preferredEncodings {
+		if encoding == preferred {
+			return true
+		}
+	}
+	return false
+}
+
+// DriftRemediation is the action taken when configuration drift is detected on a target
+type DriftRemediation string
+
+const (
+	// DriftRemediationAlert only records and reports the drifted paths
+	DriftRemediationAlert DriftRemediation = "alert"
+	// DriftRemediationReconcile pushes the intended configuration back to the target
+	DriftRemediationReconcile DriftRemediation = "reconcile"
+	// DriftRemediationAdopt adopts the target's values as a new change transaction
+	DriftRemediationAdopt DriftRemediation = "adopt"
+)
+
+// DriftOptions configures periodic detection of configuration drift on a target
+type DriftOptions struct {
+	// Interval is the interval at which the target configuration is compared with the intended configuration.
+	// Drift detection is disabled if the interval is not set.
+	Interval Duration `json:"interval,omitempty"`
+	// Remediation is the action taken when drift is detected; defaults to DriftRemediationAlert
+	Remediation DriftRemediation `json:"remediation,omitempty"`
+}
+
+// GetDriftOptions returns the drift detection options for the given target entity.
+// If the aspect is not set, drift detection is disabled.
+func GetDriftOptions(object *topoapi.Object) (*DriftOptions, error) {
+	options := &DriftOptions{}
+	if err := getAspect(object, DriftOptionsAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	switch options.Remediation {
+	case "":
+		options.Remediation = DriftRemediationAlert
+	case DriftRemediationAlert, DriftRemediationReconcile, DriftRemediationAdopt:
+	default:
+		return nil, errors.NewInvalid("unknown drift remediation '%s' for object '%s'", options.Remediation, object.ID)
+	}
+	return options, nil
+}
+
+// ModelCompatibility is the result of comparing the models and encodings supported by a target
+// with those required by the model plugin for the target's type and version
+type ModelCompatibility struct {
+	// Checked is the time at which the target capabilities were last checked
+	Checked time.Time `json:"checked"`
+	// Type is the target type the capabilities were checked against
+	Type string `json:"type"`
+	// Version is the target version the capabilities were checked against
+	Version string `json:"version"`
+	// Compatible indicates whether the target supports all the plugin's models and at least one of its encodings
+	Compatible bool `json:"compatible"`
+	// MissingModels is the list of plugin models not supported by the target, formatted as name@version
+	MissingModels []string `json:"missingModels,omitempty"`
+	// Encodings is the list of encodings supported by both the target and the plugin
+	Encodings []string `json:"encodings,omitempty"`
+}
+
+// GetModelCompatibility returns the result of the last capabilities check for the given target entity.
+// If the target has not been checked, a NotFound error is returned.
+func GetModelCompatibility(object *topoapi.Object) (*ModelCompatibility, error) {
+	compatibility := &ModelCompatibility{}
+	if err := getAspect(object, ModelCompatibilityAspect, compatibility); err != nil {
+		return nil, err
+	}
+	return compatibility, nil
+}
+
+// SetModelCompatibility sets the result of the last capabilities check on the given target entity
+func SetModelCompatibility(object *topoapi.Object, compatibility *ModelCompatibility) error {
+	return setAspect(object, ModelCompatibilityAspect, compatibility)
+}
+
+// SetOperation is the operation for which changes are sent to a target
+type SetOperation string
+
+const (
+	// SetOperationApply indicates changes are sent to apply a proposal
+	SetOperationApply SetOperation = "apply"
+	// SetOperationSync indicates changes are sent to synchronize the target configuration
+	SetOperationSync SetOperation = "sync"
+)
+
+// SetProgress tracks the progress of changes that are split into multiple Set requests to a target
+type SetProgress struct {
+	// Operation is the operation for which the changes are sent
+	Operation SetOperation `json:"operation"`
+	// Index is the configuration index the changes are sent for
+	Index uint64 `json:"index"`
+	// Term is the mastership term in which the changes are sent
+	Term uint64 `json:"term"`
+	// Chunks is the total number of Set requests
+	Chunks int `json:"chunks"`
+	// Applied is the number of Set requests known to be applied to the target. It is recorded for groups
+	// of requests, so more requests may have been applied if the Set was interrupted.
+	Applied int `json:"applied"`
+	// Error is the error returned by the target for the first request that was not applied, if any
+	Error string `json:"error,omitempty"`
+}
+
+// GetSetProgress returns the progress of the changes last sent to the given target entity.
+// If no changes have been split into multiple requests, a NotFound error is returned.
+func GetSetProgress(object *topoapi.Object) (*SetProgress, error) {
+	progress := &SetProgress{}
+	if err := getAspect(object, SetProgressAspect, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// SetSetProgress sets the progress of the changes last sent to the given target entity
+func SetSetProgress(object *topoapi.Object, progress *SetProgress) error {
+	return setAspect(object, SetProgressAspect, progress)
+}
+
+// ConnectionOptions configures the southbound connection to a target.
+// Options that are not set fall back to the gRPC defaults.
+type ConnectionOptions struct {
+	// KeepaliveInterval is the interval after which the connection is pinged if there is no activity
+	KeepaliveInterval Duration `json:"keepaliveInterval,omitempty"`
+	// KeepaliveTimeout is the time to wait for a keepalive ping to be acknowledged before closing the connection
+	KeepaliveTimeout Duration `json:"keepaliveTimeout,omitempty"`
+	// BackoffBaseDelay is the delay before the first reconnection attempt
+	BackoffBaseDelay Duration `json:"backoffBaseDelay,omitempty"`
+	// BackoffMaxDelay is the upper bound of the delay between reconnection attempts
+	BackoffMaxDelay Duration `json:"backoffMaxDelay,omitempty"`
+	// MaxRecvMsgSize is the maximum size in bytes of a message received from the target
+	MaxRecvMsgSize int `json:"maxRecvMsgSize,omitempty"`
+	// MaxSendMsgSize is the maximum size in bytes of a message sent to the target
+	MaxSendMsgSize int `json:"maxSendMsgSize,omitempty"`
+	// Compression is the name of the compressor used for requests to the target, e.g. "gzip"
+	Compression string `json:"compression,omitempty"`
+	// GetTimeout is the deadline for Get requests to the target
+	GetTimeout Duration `json:"getTimeout,omitempty"`
+	// SetTimeout is the deadline for Set requests to the target
+	SetTimeout Duration `json:"setTimeout,omitempty"`
+	// DialOut indicates the target initiates the connection to the onos-config dial-out listener
+	DialOut bool `json:"dialOut,omitempty"`
+}
+
+// GetConnectionOptions returns the southbound connection options for the given target entity.
+// If the aspect is not set, the default options are returned.
+func GetConnectionOptions(object *topoapi.Object) (*ConnectionOptions, error) {
+	options := &ConnectionOptions{}
+	if err := getAspect(object, ConnectionOptionsAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return options, nil
+}
+
+// CredentialsSource is the source from which a target's username/password credentials are loaded
+type CredentialsSource string
+
+const (
+	// CredentialsSourceSecret loads credentials from a secret mounted in the secrets directory
+	CredentialsSourceSecret CredentialsSource = "secret"
+	// CredentialsSourceFile loads credentials from the credentials file entry for the target ID
+	CredentialsSourceFile CredentialsSource = "file"
+	// CredentialsSourceEnv loads credentials from environment variables
+	CredentialsSourceEnv CredentialsSource = "env"
+)
+
+// Credentials references the username/password credentials used to connect to a target.
+// The aspect never carries the credentials themselves.
+type Credentials struct {
+	// Source is the source from which the credentials are loaded
+	Source CredentialsSource `json:"source"`
+	// Secret is the name of the secret for the secret source. The secret is expected to be mounted in the
+	// secrets directory with the 'username' and 'password' keys as files.
+	Secret string `json:"secret,omitempty"`
+	// UsernameEnv is the name of the environment variable holding the username for the env source.
+	// The name must start with the target's credentials environment prefix and defaults to the prefix
+	// followed by USERNAME.
+	UsernameEnv string `json:"usernameEnv,omitempty"`
+	// PasswordEnv is the name of the environment variable holding the password for the env source.
+	// The name must start with the target's credentials environment prefix and defaults to the prefix
+	// followed by PASSWORD.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+}
+
+// CredentialsEnvPrefix is the prefix of all environment variables from which target credentials may be loaded
+const CredentialsEnvPrefix = "ONOS_CONFIG_CREDENTIALS_"
+
+// GetCredentialsEnvPrefix returns the prefix of the environment variables from which the credentials of the
+// given target may be loaded, e.g. ONOS_CONFIG_CREDENTIALS_TARGET_1_ for target-1. Restricting the variables
+// to the prefix prevents a target from referencing other variables of the onos-config process.
+func GetCredentialsEnvPrefix(targetID topoapi.ID) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, string(targetID))
+	return CredentialsEnvPrefix + name + "_"
+}
+
+// GetCredentials returns the credentials reference for the given target entity.
+// If the aspect is not set, a NotFound error is returned.
+func GetCredentials(object *topoapi.Object) (*Credentials, error) {
+	credentials := &Credentials{}
+	if err := getAspect(object, CredentialsAspect, credentials); err != nil {
+		return nil, err
+	}
+	switch credentials.Source {
+	case CredentialsSourceSecret:
+		if credentials.Secret == "" {
+			return nil, errors.NewInvalid("no secret specified for credentials of object '%s'", object.ID)
+		}
+	case CredentialsSourceEnv:
+		prefix := GetCredentialsEnvPrefix(object.ID)
+		if credentials.UsernameEnv == "" {
+			credentials.UsernameEnv = prefix + "USERNAME"
+		}
+		if credentials.PasswordEnv == "" {
+			credentials.PasswordEnv = prefix + "PASSWORD"
+		}
+		for _, env := range []string{credentials.UsernameEnv, credentials.PasswordEnv} {
+			if !strings.HasPrefix(env, prefix) || len(env) == len(prefix) {
+				return nil, errors.NewInvalid("environment variable '%s' for credentials of object '%s' does not start with '%s'",
+					env, object.ID, prefix)
+			}
+		}
+	case CredentialsSourceFile:
+	default:
+		return nil, errors.NewInvalid("unknown credentials source '%s' for object '%s'", credentials.Source, object.ID)
+	}
+	return credentials, nil
+}
+
+// DefaultCertificateReloadInterval is the default interval at which certificate files are checked for changes
+const DefaultCertificateReloadInterval = Duration(time.Minute)
+
+// CertificateOptions configures the TLS certificates used for the southbound connection to a target.
+// The certificate files themselves are configured in the target's onos.topo.TLSOptions aspect.
+type CertificateOptions struct {
+	// ServerName overrides the name against which the target's server certificate is verified
+	ServerName string `json:"serverName,omitempty"`
+	// ReloadInterval is the interval at which the certificate files are checked for changes
+	ReloadInterval Duration `json:"reloadInterval,omitempty"`
+}
+
+// GetCertificateOptions returns the certificate options for the given target entity.
+// If the aspect is not set, the default options are returned.
+func GetCertificateOptions(object *topoapi.Object) (*CertificateOptions, error) {
+	options := &CertificateOptions{}
+	if err := getAspect(object, CertificateOptionsAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if options.ReloadInterval == 0 {
+		options.ReloadInterval = DefaultCertificateReloadInterval
+	}
+	return options, nil
+}
+
+// CertificateStatus describes the certificates used by a southbound connection
+type CertificateStatus struct {
+	// Loaded is the time at which the certificate files were last loaded
+	Loaded time.Time `json:"loaded"`
+	// Client is the client certificate presented to the target
+	Client *CertificateInfo `json:"client,omitempty"`
+	// CA is the earliest expiring certificate in the CA bundle used to verify the target
+	CA *CertificateInfo `json:"ca,omitempty"`
+	// Server is the certificate presented by the target in the last handshake
+	Server *CertificateInfo `json:"server,omitempty"`
+	// Error is the error that prevented the certificate files from being reloaded, if any
+	Error string `json:"error,omitempty"`
+}
+
+// CertificateInfo identifies a certificate and its validity
+type CertificateInfo struct {
+	Subject  string    `json:"subject"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// GetCertificateStatus returns the certificate status for the given connection relation.
+// If the aspect is not set, a NotFound error is returned.
+func GetCertificateStatus(object *topoapi.Object) (*CertificateStatus, error) {
+	status := &CertificateStatus{}
+	if err := getAspect(object, CertificateStatusAspect, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// SetCertificateStatus sets the certificate status on the given connection relation
+func SetCertificateStatus(object *topoapi.Object, status *CertificateStatus) error {
+	return setAspect(object, CertificateStatusAspect, status)
+}
+
+// TelemetryCacheMode is the subscription mode used to maintain a target's telemetry cache
+type TelemetryCacheMode string
+
+const (
+	// TelemetryCacheOnChange subscribes to the cached paths in ON_CHANGE mode
+	TelemetryCacheOnChange TelemetryCacheMode = "on_change"
+	// TelemetryCacheSample subscribes to the cached paths in SAMPLE mode
+	TelemetryCacheSample TelemetryCacheMode = "sample"
+)
+
+// TelemetryCacheOptions configures the cache of a target's state maintained by a southbound subscription.
+// State requests for cached paths are answered from the cache rather than by the target.
+// Each replica maintains its own cache, so by default the target serves one subscription per replica.
+type TelemetryCacheOptions struct {
+	// Paths is the list of state paths to cache; the cache is disabled if no paths are configured
+	Paths []string `json:"paths,omitempty"`
+	// Mode is the subscription mode; defaults to TelemetryCacheOnChange
+	Mode TelemetryCacheMode `json:"mode,omitempty"`
+	// SampleInterval is the sample interval for the TelemetryCacheSample mode
+	SampleInterval Duration `json:"sampleInterval,omitempty"`
+	// MaxAge is the time after the last update from the target after which the cache is considered stale.
+	// It should only be set for sampled subscriptions, since on-change subscriptions may not be updated for long periods.
+	MaxAge Duration `json:"maxAge,omitempty"`
+	// MasterOnly limits the subscription to the replica mastering the target. Other replicas then read
+	// state from the target and do not serve Subscribe requests for it.
+	MasterOnly bool `json:"masterOnly,omitempty"`
+}
+
+// GetTelemetryCacheOptions returns the telemetry cache options for the given target entity.
+// If the aspect is not set, the cache is disabled.
+func GetTelemetryCacheOptions(object *topoapi.Object) (*TelemetryCacheOptions, error) {
+	options := &TelemetryCacheOptions{}
+	if err := getAspect(object, TelemetryCacheAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	switch options.Mode {
+	case "":
+		options.Mode = TelemetryCacheOnChange
+	case TelemetryCacheOnChange:
+	case TelemetryCacheSample:
+		if options.SampleInterval == 0 {
+			return nil, errors.NewInvalid("no sample interval configured for telemetry cache of object '%s'", object.ID)
+		}
+	default:
+		return nil, errors.NewInvalid("unknown telemetry cache mode '%s' for object '%s'", options.Mode, object.ID)
+	}
+	return options, nil
+}
+
+// getAspect decodes the JSON aspect with the given type from the given object
+func getAspect(object *topoapi.Object, aspectType string, value interface{}) error {
+	if object.Aspects == nil || object.Aspects[aspectType] == nil {
+		return errors.NewNotFound("aspect '%s' not found for object '%s'", aspectType, object.ID)
+	}
+	bytes, err := object.GetAspectBytes(aspectType)
+	if err != nil {
+		return errors.NewNotFound(err.Error())
+	}
+	if err := json.Unmarshal(bytes, value); err != nil {
+		return errors.NewInvalid("failed decoding aspect '%s' for object '%s': %v", aspectType, object.ID, err)
+	}
+	return nil
+}
+
+// setAspect encodes the given value as a JSON aspect with the given type on the given object
+func setAspect(object *topoapi.Object, aspectType string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return errors.NewInvalid("failed encoding aspect '%s' for object '%s': %v", aspectType, object.ID, err)
+	}
+	return object.SetAspectBytes(aspectType, bytes)
+}
+
+// SouthboundProtocol is the protocol used to manage a target
+type SouthboundProtocol string
+
+const (
+	// SouthboundProtocolGNMI manages the target with gNMI
+	SouthboundProtocolGNMI SouthboundProtocol = "gnmi"
+	// SouthboundProtocolNETCONF manages the target with NETCONF over SSH
+	SouthboundProtocolNETCONF SouthboundProtocol = "netconf"
+)
+
+// NETCONFDatastore is the NETCONF datastore to which configuration changes are written
+type NETCONFDatastore string
+
+const (
+	// NETCONFDatastoreRunning writes changes directly to the running datastore
+	NETCONFDatastoreRunning NETCONFDatastore = "running"
+	// NETCONFDatastoreCandidate writes changes to the candidate datastore and commits them
+	NETCONFDatastoreCandidate NETCONFDatastore = "candidate"
+)
+
+// SouthboundOptions selects the protocol used to manage a target
+type SouthboundOptions struct {
+	// Protocol is the southbound protocol; defaults to SouthboundProtocolGNMI
+	Protocol SouthboundProtocol `json:"protocol,omitempty"`
+	// NETCONF configures the NETCONF sessions to the target
+	NETCONF NETCONFOptions `json:"netconf,omitempty"`
+}
+
+// NETCONFOptions configures the NETCONF sessions to a target
+type NETCONFOptions struct {
+	// Datastore is the datastore to which changes are written; defaults to NETCONFDatastoreRunning
+	Datastore NETCONFDatastore `json:"datastore,omitempty"`
+	// Namespaces maps the names of top-level elements to their XML namespaces
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+	// HostKeys is the list of accepted SSH host keys of the target in authorized_keys format.
+	// If no host keys are configured, the host key is only accepted if the target's TLS options are insecure.
+	HostKeys []string `json:"hostKeys,omitempty"`
+}
+
+// GetSouthboundOptions returns the southbound options for the given target entity.
+// If the aspect is not set, the target is managed with gNMI.
+func GetSouthboundOptions(object *topoapi.Object) (*SouthboundOptions, error) {
+	options := &SouthboundOptions{}
+	if err := getAspect(object, SouthboundAspect, options); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	switch options.Protocol {
+	case "":
+		options.Protocol = SouthboundProtocolGNMI
+	case SouthboundProtocolGNMI, SouthboundProtocolNETCONF:
+	default:
+		return nil, errors.NewInvalid("unknown southbound protocol '%s' for target '%s'", options.Protocol, object.ID)
+	}
+	switch options.NETCONF.Datastore {
+	case "":
+		options.NETCONF.Datastore = NETCONFDatastoreRunning
+	case NETCONFDatastoreRunning, NETCONFDatastoreCandidate:
+	default:
+		return nil, errors.NewInvalid("unknown NETCONF datastore '%s' for target '%s'", options.NETCONF.Datastore, object.ID)
+	}
+	return options, nil
+}
@@ -0,0 +1,295 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncOptionsAspect(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	options, err := GetSyncOptions(target)
+	assert.NoError(t, err)
+	assert.False(t, options.Coalesce)
+
+	assert.False(t, options.Adopt)
+
+	assert.False(t, options.Replace)
+
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{"coalesce": true, "adopt": true, "replace": true}`))
+	assert.NoError(t, err)
+	options, err = GetSyncOptions(target)
+	assert.NoError(t, err)
+	assert.True(t, options.Coalesce)
+	assert.True(t, options.Adopt)
+	assert.True(t, options.Replace)
+
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{"coalesce": "yes"}`))
+	assert.NoError(t, err)
+	_, err = GetSyncOptions(target)
+	assert.Error(t, err)
+}
+
+func TestDriftAspects(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	options, err := GetDriftOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, Duration(0), options.Interval)
+	assert.Equal(t, DriftRemediationAlert, options.Remediation)
+
+	err = target.SetAspectBytes(DriftOptionsAspect, []byte(`{"interval": "1m30s", "remediation": "adopt"}`))
+	assert.NoError(t, err)
+	options, err = GetDriftOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, Duration(90*time.Second), options.Interval)
+	assert.Equal(t, DriftRemediationAdopt, options.Remediation)
+
+	err = target.SetAspectBytes(DriftOptionsAspect, []byte(`{"interval": "1m", "remediation": "ignore"}`))
+	assert.NoError(t, err)
+	_, err = GetDriftOptions(target)
+	assert.Error(t, err)
+
+	err = target.SetAspectBytes(DriftOptionsAspect, []byte(`{"interval": "soon"}`))
+	assert.NoError(t, err)
+	_, err = GetDriftOptions(target)
+	assert.Error(t, err)
+}
+
+func TestModelCompatibilityAspect(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	_, err := GetModelCompatibility(target)
+	assert.True(t, errors.IsNotFound(err))
+
+	err = SetModelCompatibility(target, &ModelCompatibility{
+		Checked:       time.Now(),
+		Type:          "devicesim",
+		Version:       "1.0.x",
+		MissingModels: []string{"openconfig-interfaces@2.0.0"},
+		Encodings:     []string{"JSON_IETF"},
+	})
+	assert.NoError(t, err)
+	compatibility, err := GetModelCompatibility(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "devicesim", compatibility.Type)
+	assert.Equal(t, "1.0.x", compatibility.Version)
+	assert.False(t, compatibility.Compatible)
+	assert.Equal(t, []string{"openconfig-interfaces@2.0.0"}, compatibility.MissingModels)
+	assert.Equal(t, []string{"JSON_IETF"}, compatibility.Encodings)
+}
+
+func TestGetEncoding(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	encoding, err := GetEncoding(target, gpb.Encoding_PROTO)
+	assert.NoError(t, err)
+	assert.Equal(t, gpb.Encoding_PROTO, encoding)
+
+	err = SetModelCompatibility(target, &ModelCompatibility{
+		Compatible: true,
+		Encodings:  []string{"JSON", "JSON_IETF"},
+	})
+	assert.NoError(t, err)
+	encoding, err = GetEncoding(target, gpb.Encoding_PROTO)
+	assert.NoError(t, err)
+	assert.Equal(t, gpb.Encoding_JSON_IETF, encoding)
+	encoding, err = GetEncoding(target, gpb.Encoding_JSON)
+	assert.NoError(t, err)
+	assert.Equal(t, gpb.Encoding_JSON, encoding)
+
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{"encoding": "proto"}`))
+	assert.NoError(t, err)
+	encoding, err = GetEncoding(target, gpb.Encoding_JSON)
+	assert.NoError(t, err)
+	assert.Equal(t, gpb.Encoding_PROTO, encoding)
+
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{"encoding": "XML"}`))
+	assert.NoError(t, err)
+	_, err = GetEncoding(target, gpb.Encoding_JSON)
+	assert.Error(t, err)
+
+	// Encodings in which values cannot be exchanged with the target are not used
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{"encoding": "ascii"}`))
+	assert.NoError(t, err)
+	encoding, err = GetEncoding(target, gpb.Encoding_JSON)
+	assert.True(t, errors.IsInvalid(err))
+	assert.Equal(t, gpb.Encoding_JSON, encoding)
+
+	err = target.SetAspectBytes(SyncOptionsAspect, []byte(`{}`))
+	assert.NoError(t, err)
+	err = SetModelCompatibility(target, &ModelCompatibility{
+		Compatible: true,
+		Encodings:  []string{"ASCII", "BYTES"},
+	})
+	assert.NoError(t, err)
+	encoding, err = GetEncoding(target, gpb.Encoding_PROTO)
+	assert.NoError(t, err)
+	assert.Equal(t, gpb.Encoding_PROTO, encoding)
+}
+
+func TestCredentialsAspect(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	_, err := GetCredentials(target)
+	assert.True(t, errors.IsNotFound(err))
+
+	err = target.SetAspectBytes(CredentialsAspect, []byte(`{"source": "secret", "secret": "target-1"}`))
+	assert.NoError(t, err)
+	credentials, err := GetCredentials(target)
+	assert.NoError(t, err)
+	assert.Equal(t, CredentialsSourceSecret, credentials.Source)
+	assert.Equal(t, "target-1", credentials.Secret)
+
+	err = target.SetAspectBytes(CredentialsAspect, []byte(`{"source": "env"}`))
+	assert.NoError(t, err)
+	credentials, err = GetCredentials(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "ONOS_CONFIG_CREDENTIALS_TARGET_1_USERNAME", credentials.UsernameEnv)
+	assert.Equal(t, "ONOS_CONFIG_CREDENTIALS_TARGET_1_PASSWORD", credentials.PasswordEnv)
+
+	err = target.SetAspectBytes(CredentialsAspect, []byte(`{"source": "env", "usernameEnv": "ONOS_CONFIG_CREDENTIALS_TARGET_1_USER"}`))
+	assert.NoError(t, err)
+	credentials, err = GetCredentials(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "ONOS_CONFIG_CREDENTIALS_TARGET_1_USER", credentials.UsernameEnv)
+
+	// Variables outside the target's prefix are rejected, including those of other targets
+	for _, env := range []string{"USERNAME", "ONOS_CONFIG_CREDENTIALS_TARGET_2_PASSWORD", "ONOS_CONFIG_CREDENTIALS_TARGET_1_"} {
+		err = target.SetAspectBytes(CredentialsAspect, []byte(`{"source": "env", "passwordEnv": "`+env+`"}`))
+		assert.NoError(t, err)
+		_, err = GetCredentials(target)
+		assert.True(t, errors.IsInvalid(err), env)
+	}
+
+	err = target.SetAspectBytes(CredentialsAspect, []byte(`{"source": "vault"}`))
+	assert.NoError(t, err)
+	_, err = GetCredentials(target)
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestTelemetryCacheAspect(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	options, err := GetTelemetryCacheOptions(target)
+	assert.NoError(t, err)
+	assert.Len(t, options.Paths, 0)
+
+	err = target.SetAspectBytes(TelemetryCacheAspect, []byte(`{"paths": ["/interfaces/interface[name=*]/state"]}`))
+	assert.NoError(t, err)
+	options, err = GetTelemetryCacheOptions(target)
+	assert.NoError(t, err)
+	assert.Len(t, options.Paths, 1)
+	assert.Equal(t, TelemetryCacheOnChange, options.Mode)
+
+	err = target.SetAspectBytes(TelemetryCacheAspect, []byte(`{"paths": ["/system/state"], "mode": "sample", "sampleInterval": "10s", "maxAge": "1m"}`))
+	assert.NoError(t, err)
+	options, err = GetTelemetryCacheOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, TelemetryCacheSample, options.Mode)
+	assert.Equal(t, 10*time.Second, time.Duration(options.SampleInterval))
+	assert.Equal(t, time.Minute, time.Duration(options.MaxAge))
+
+	err = target.SetAspectBytes(TelemetryCacheAspect, []byte(`{"paths": ["/system/state"], "mode": "sample"}`))
+	assert.NoError(t, err)
+	_, err = GetTelemetryCacheOptions(target)
+	assert.True(t, errors.IsInvalid(err))
+
+	err = target.SetAspectBytes(TelemetryCacheAspect, []byte(`{"paths": ["/system/state"], "mode": "poll"}`))
+	assert.NoError(t, err)
+	_, err = GetTelemetryCacheOptions(target)
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestSouthboundAspect(t *testing.T) {
+	target := &topoapi.Object{
+		ID:   "target-1",
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{},
+		},
+	}
+
+	options, err := GetSouthboundOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, SouthboundProtocolGNMI, options.Protocol)
+
+	err = target.SetAspectBytes(SouthboundAspect, []byte(`{"protocol": "netconf", "netconf": {"datastore": "candidate", "namespaces": {"interfaces": "urn:ietf:params:xml:ns:yang:ietf-interfaces"}}}`))
+	assert.NoError(t, err)
+	options, err = GetSouthboundOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, SouthboundProtocolNETCONF, options.Protocol)
+	assert.Equal(t, NETCONFDatastoreCandidate, options.NETCONF.Datastore)
+	assert.Equal(t, "urn:ietf:params:xml:ns:yang:ietf-interfaces", options.NETCONF.Namespaces["interfaces"])
+
+	err = target.SetAspectBytes(SouthboundAspect, []byte(`{"protocol": "netconf"}`))
+	assert.NoError(t, err)
+	options, err = GetSouthboundOptions(target)
+	assert.NoError(t, err)
+	assert.Equal(t, NETCONFDatastoreRunning, options.NETCONF.Datastore)
+
+	err = target.SetAspectBytes(SouthboundAspect, []byte(`{"protocol": "snmp"}`))
+	assert.NoError(t, err)
+	_, err = GetSouthboundOptions(target)
+	assert.True(t, errors.IsInvalid(err))
+
+	err = target.SetAspectBytes(SouthboundAspect, []byte(`{"protocol": "netconf", "netconf": {"datastore": "startup"}}`))
+	assert.NoError(t, err)
+	_, err = GetSouthboundOptions(target)
+	assert.True(t, errors.IsInvalid(err))
+}
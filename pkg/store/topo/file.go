@@ -0,0 +1,424 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// filePollInterval is the interval at which the topology file is checked for changes
+const filePollInterval = time.Second
+
+// File is the format of a topology file
+type File struct {
+	// Targets is the list of target entities
+	Targets []FileTarget `yaml:"targets" json:"targets"`
+}
+
+// FileTarget is a target entity in a topology file
+type FileTarget struct {
+	// ID is the target ID
+	ID string `yaml:"id" json:"id"`
+	// Kind is the optional entity kind
+	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	// Labels are the entity labels
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Aspects maps aspect types, e.g. onos.topo.Configurable, to their JSON or YAML encoded values
+	Aspects map[string]interface{} `yaml:"aspects,omitempty" json:"aspects,omitempty"`
+}
+
+// NewFileStore creates a topology store backed by a local YAML or JSON file of target entities.
+// Objects created by onos-config itself, e.g. node entities and relations, are kept in memory
+// along with runtime changes to targets; the file itself is never written. The file is
+// re-read when it changes: targets added to, changed in or removed from the file are
+// created, updated or deleted in the store and the corresponding events are sent to watchers.
+func NewFileStore(path string) (Store, error) {
+	store := &fileStore{
+		path:        path,
+		objects:     make(map[topoapi.ID]*topoapi.Object),
+		fileObjects: make(map[topoapi.ID]*topoapi.Object),
+		watchers:    watchers.New(),
+	}
+	if err := store.reload(); err != nil {
+		return nil, err
+	}
+	go store.poll()
+	return store, nil
+}
+
+type fileStore struct {
+	path        string
+	contents    []byte
+	objects     map[topoapi.ID]*topoapi.Object
+	fileObjects map[topoapi.ID]*topoapi.Object
+	revision    topoapi.Revision
+	mu          sync.RWMutex
+	watchers    *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given object. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are made. Events are queued
+// for each watcher, so publishing never blocks on watchers.
+func (s *fileStore) publishEvent(eventType topoapi.EventType, object *topoapi.Object) {
+	s.watchers.Publish(topoapi.Event{
+		Type:   eventType,
+		Object: *proto.Clone(object).(*topoapi.Object),
+	})
+}
+
+// poll periodically reloads the topology file, logging each distinct failure once
+func (s *fileStore) poll() {
+	ticker := time.NewTicker(filePollInterval)
+	defer ticker.Stop()
+	var lastErr string
+	for range ticker.C {
+		if err := s.reload(); err != nil {
+			if err.Error() != lastErr {
+				log.Warnf("Failed reloading topology file %s: %v", s.path, err)
+				lastErr = err.Error()
+			}
+		} else {
+			lastErr = ""
+		}
+	}
+}
+
+// reload reads the topology file and applies any changes to the store
+func (s *fileStore) reload() error {
+	contents, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return errors.NewUnavailable("failed reading topology file %s: %v", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.contents != nil && bytes.Equal(contents, s.contents) {
+		return nil
+	}
+
+	fileObjects, err := parseFile(contents)
+	if err != nil {
+		return err
+	}
+	// Objects loaded when the store is created are replayed to watchers rather than published
+	initial := s.contents == nil
+	s.contents = contents
+	log.Infof("Loading %d targets from topology file %s", len(fileObjects), s.path)
+
+	for id, fileObject := range fileObjects {
+		prevFileObject := s.fileObjects[id]
+		if prevFileObject != nil && reflect.DeepEqual(fileObject, prevFileObject) {
+			continue
+		}
+		object, ok := s.objects[id]
+		if !ok {
+			object = proto.Clone(fileObject).(*topoapi.Object)
+			s.revision++
+			object.Revision = s.revision
+			s.objects[id] = object
+			if !initial {
+				s.publishEvent(topoapi.EventType_ADDED, object)
+			}
+			continue
+		}
+
+		// Apply the file definition to the stored object, retaining aspects added at runtime
+		object.Obj = fileObject.Obj
+		object.Labels = fileObject.Labels
+		if object.Aspects == nil {
+			object.Aspects = make(map[string]*gogotypes.Any)
+		}
+		if prevFileObject != nil {
+			for aspectType := range prevFileObject.Aspects {
+				if _, ok := fileObject.Aspects[aspectType]; !ok {
+					delete(object.Aspects, aspectType)
+				}
+			}
+		}
+		for aspectType, aspect := range fileObject.Aspects {
+			object.Aspects[aspectType] = aspect
+		}
+		s.revision++
+		object.Revision = s.revision
+		s.publishEvent(topoapi.EventType_UPDATED, object)
+	}
+
+	for id := range s.fileObjects {
+		if _, ok := fileObjects[id]; ok {
+			continue
+		}
+		if object, ok := s.objects[id]; ok {
+			delete(s.objects, id)
+			s.publishEvent(topoapi.EventType_REMOVED, object)
+		}
+	}
+	s.fileObjects = fileObjects
+	return nil
+}
+
+// parseFile parses the given topology file contents into target entities
+func parseFile(contents []byte) (map[topoapi.ID]*topoapi.Object, error) {
+	var file File
+	if trimmed := bytes.TrimSpace(contents); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&file); err != nil {
+			return nil, errors.NewInvalid("failed parsing topology file: %v", err)
+		}
+	} else if err := yaml.UnmarshalStrict(contents, &file); err != nil {
+		return nil, errors.NewInvalid("failed parsing topology file: %v", err)
+	}
+	objects := make(map[topoapi.ID]*topoapi.Object)
+	for _, target := range file.Targets {
+		if target.ID == "" {
+			return nil, errors.NewInvalid("target ID not specified in topology file")
+		}
+		id := topoapi.ID(target.ID)
+		if _, ok := objects[id]; ok {
+			return nil, errors.NewInvalid("duplicate target '%s' in topology file", id)
+		}
+		object := &topoapi.Object{
+			ID:   id,
+			Type: topoapi.Object_ENTITY,
+			Obj: &topoapi.Object_Entity{
+				Entity: &topoapi.Entity{
+					KindID: topoapi.ID(target.Kind),
+				},
+			},
+			Aspects: make(map[string]*gogotypes.Any),
+			Labels:  target.Labels,
+		}
+		for aspectType, value := range target.Aspects {
+			bytes, err := json.Marshal(toJSONValue(value))
+			if err != nil {
+				return nil, errors.NewInvalid("failed encoding aspect '%s' for target '%s': %v", aspectType, id, err)
+			}
+			if err := object.SetAspectBytes(aspectType, bytes); err != nil {
+				return nil, errors.NewInvalid("failed encoding aspect '%s' for target '%s': %v", aspectType, id, err)
+			}
+		}
+		objects[id] = object
+	}
+	return objects, nil
+}
+
+// toJSONValue converts YAML maps, which may have non-string keys, into values that can be encoded as JSON
+func toJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = toJSONValue(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = toJSONValue(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = toJSONValue(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// Create creates topology object in the store
+func (s *fileStore) Create(ctx context.Context, object *topoapi.Object) error {
+	if object.ID == "" {
+		return errors.NewInvalid("object ID not specified")
+	}
+	if object.Revision != 0 {
+		return errors.NewInvalid("cannot create object with revision")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[object.ID]; ok {
+		return errors.NewAlreadyExists("object '%s' already exists", object.ID)
+	}
+	s.revision++
+	object.Revision = s.revision
+	stored := proto.Clone(object).(*topoapi.Object)
+	s.objects[object.ID] = stored
+	s.publishEvent(topoapi.EventType_ADDED, stored)
+	return nil
+}
+
+// Update updates the given topology object in the store, using the object revision as an optimistic lock
+func (s *fileStore) Update(ctx context.Context, object *topoapi.Object) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.objects[object.ID]
+	if !ok {
+		return errors.NewNotFound("object '%s' not found", object.ID)
+	}
+	if object.Revision != 0 && object.Revision != stored.Revision {
+		return errors.NewConflict("object '%s' revision %d does not match the stored revision %d", object.ID, object.Revision, stored.Revision)
+	}
+	s.revision++
+	object.Revision = s.revision
+	stored = proto.Clone(object).(*topoapi.Object)
+	s.objects[object.ID] = stored
+	s.publishEvent(topoapi.EventType_UPDATED, stored)
+	return nil
+}
+
+// Get gets topology object based on a given ID
+func (s *fileStore) Get(ctx context.Context, id topoapi.ID) (*topoapi.Object, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.objects[id]
+	if !ok {
+		return nil, errors.NewNotFound("object '%s' not found", id)
+	}
+	return proto.Clone(stored).(*topoapi.Object), nil
+}
+
+// List lists the topology objects matching the given filters
+func (s *fileStore) List(ctx context.Context, filters *topoapi.Filters) ([]topoapi.Object, error) {
+	if filters != nil && filters.RelationFilter != nil {
+		return nil, errors.NewNotSupported("relation filters are not supported by the topology file store")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	objects := make([]topoapi.Object, 0, len(s.objects))
+	for _, stored := range s.objects {
+		if matchFilters(stored, filters) {
+			objects = append(objects, *proto.Clone(stored).(*topoapi.Object))
+		}
+	}
+	return objects, nil
+}
+
+// Delete deletes the given topology object, using the object revision as an optimistic lock
+func (s *fileStore) Delete(ctx context.Context, object *topoapi.Object) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.objects[object.ID]
+	if !ok {
+		return errors.NewNotFound("object '%s' not found", object.ID)
+	}
+	if object.Revision != 0 && object.Revision != stored.Revision {
+		return errors.NewConflict("object '%s' revision %d does not match the stored revision %d", object.ID, object.Revision, stored.Revision)
+	}
+	delete(s.objects, object.ID)
+	s.publishEvent(topoapi.EventType_REMOVED, stored)
+	return nil
+}
+
+// Watch watches topology events, replaying the existing objects first
+func (s *fileStore) Watch(ctx context.Context, ch chan<- topoapi.Event, filters *topoapi.Filters) error {
+	if filters != nil && filters.RelationFilter != nil {
+		return errors.NewNotSupported("relation filters are not supported by the topology file store")
+	}
+	// Replay the current objects and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	replay := make([]interface{}, 0, len(s.objects))
+	for _, stored := range s.objects {
+		if matchFilters(stored, filters) {
+			replay = append(replay, topoapi.Event{
+				Type:   topoapi.EventType_NONE,
+				Object: *proto.Clone(stored).(*topoapi.Object),
+			})
+		}
+	}
+	s.watchers.Add(ctx, &objectWatcher{filters: filters, ch: ch}, replay)
+	return nil
+}
+
+// matchFilters returns whether the given object matches the given filters
+func matchFilters(object *topoapi.Object, filters *topoapi.Filters) bool {
+	if filters == nil {
+		return true
+	}
+	if len(filters.ObjectTypes) > 0 {
+		match := false
+		for _, objectType := range filters.ObjectTypes {
+			if object.Type == objectType {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	if filters.KindFilter != nil {
+		var kind topoapi.ID
+		switch object.Type {
+		case topoapi.Object_ENTITY:
+			kind = object.GetEntity().KindID
+		case topoapi.Object_RELATION:
+			kind = object.GetRelation().KindID
+		case topoapi.Object_KIND:
+			kind = object.ID
+		}
+		if !matchFilter(filters.KindFilter, string(kind)) {
+			return false
+		}
+	}
+	for _, filter := range filters.LabelFilters {
+		if !matchFilter(filter, object.Labels[filter.Key]) {
+			return false
+		}
+	}
+	for _, aspectType := range filters.WithAspects {
+		if _, ok := object.Aspects[aspectType]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFilter returns whether the given value matches the given filter
+func matchFilter(filter *topoapi.Filter, value string) bool {
+	switch f := filter.Filter.(type) {
+	case *topoapi.Filter_Equal_:
+		return value == f.Equal_.Value
+	case *topoapi.Filter_In:
+		for _, v := range f.In.Values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	case *topoapi.Filter_Not:
+		return !matchFilter(f.Not.Inner, value)
+	default:
+		return true
+	}
+}
+
+var _ Store = &fileStore{}
@@ -0,0 +1,284 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTopoFile = `
+targets:
+  - id: device-1
+    kind: devicesim
+    labels:
+      site: lab
+    aspects:
+      onos.topo.Configurable:
+        address: device-1:11161
+        type: devicesim
+        version: 1.0.0
+      onos.topo.TLSOptions:
+        insecure: true
+        plain: true
+  - id: device-2
+    aspects:
+      onos.topo.Configurable:
+        address: device-2:11161
+        type: devicesim
+        version: 1.0.0
+`
+
+const testTopoFileJSON = `{
+	"targets": [
+		{
+			"id": "device-1",
+			"aspects": {
+				"onos.topo.Configurable": {"address": "device-1:11161", "type": "devicesim", "version": "1.0.0"}
+			}
+		}
+	]
+}`
+
+func newTestFileStore(t *testing.T, contents string) (Store, string) {
+	path := filepath.Join(t.TempDir(), "topo.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	return store, path
+}
+
+func nextTopoEvent(t *testing.T, ch chan topoapi.Event) topoapi.Event {
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(5 * time.Second):
+		t.FailNow()
+	}
+	return topoapi.Event{}
+}
+
+func TestFileStoreAspects(t *testing.T) {
+	store, _ := newTestFileStore(t, testTopoFile)
+	ctx := context.Background()
+
+	target, err := store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	assert.Equal(t, topoapi.ID("devicesim"), target.GetEntity().KindID)
+	assert.Equal(t, "lab", target.Labels["site"])
+	configurable := &topoapi.Configurable{}
+	assert.NoError(t, target.GetAspect(configurable))
+	assert.Equal(t, "device-1:11161", configurable.Address)
+	assert.Equal(t, "devicesim", configurable.Type)
+	assert.Equal(t, "1.0.0", configurable.Version)
+	tlsOptions := &topoapi.TLSOptions{}
+	assert.NoError(t, target.GetAspect(tlsOptions))
+	assert.True(t, tlsOptions.Insecure)
+	assert.True(t, tlsOptions.Plain)
+
+	store, _ = newTestFileStore(t, testTopoFileJSON)
+	target, err = store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	assert.NoError(t, target.GetAspect(configurable))
+	assert.Equal(t, "device-1:11161", configurable.Address)
+
+	path := filepath.Join(t.TempDir(), "topo.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("targets:\n  - kind: devicesim\n"), 0644))
+	_, err = NewFileStore(path)
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewFileStore(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestFileStoreObjects(t *testing.T) {
+	store, _ := newTestFileStore(t, testTopoFile)
+	ctx := context.Background()
+
+	objects, err := store.List(ctx, &topoapi.Filters{
+		ObjectTypes: []topoapi.Object_Type{topoapi.Object_ENTITY},
+		WithAspects: []string{"onos.topo.TLSOptions"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, topoapi.ID("device-1"), objects[0].ID)
+
+	relation := &topoapi.Object{
+		ID:   "onos-config-1-device-1",
+		Type: topoapi.Object_RELATION,
+		Obj: &topoapi.Object_Relation{
+			Relation: &topoapi.Relation{
+				KindID:      topoapi.CONTROLS,
+				SrcEntityID: "onos-config-1",
+				TgtEntityID: "device-1",
+			},
+		},
+	}
+	assert.NoError(t, store.Create(ctx, relation))
+	assert.NotZero(t, relation.Revision)
+	assert.True(t, errors.IsAlreadyExists(store.Create(ctx, &topoapi.Object{ID: relation.ID})))
+
+	objects, err = store.List(ctx, &topoapi.Filters{
+		KindFilter: &topoapi.Filter{
+			Filter: &topoapi.Filter_Equal_{
+				Equal_: &topoapi.EqualFilter{
+					Value: topoapi.CONTROLS,
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, relation.ID, objects[0].ID)
+
+	objects, err = store.List(ctx, &topoapi.Filters{
+		LabelFilters: []*topoapi.Filter{{
+			Key: "site",
+			Filter: &topoapi.Filter_Not{
+				Not: &topoapi.NotFilter{
+					Inner: &topoapi.Filter{
+						Filter: &topoapi.Filter_Equal_{
+							Equal_: &topoapi.EqualFilter{Value: "lab"},
+						},
+					},
+				},
+			},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	// Updates use the object revision as an optimistic lock
+	target, err := store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	stale, err := store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{Term: 1, NodeId: string(relation.ID)}))
+	assert.NoError(t, store.Update(ctx, target))
+	assert.True(t, errors.IsConflict(store.Update(ctx, stale)))
+	assert.True(t, errors.IsConflict(store.Delete(ctx, stale)))
+
+	target, err = store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	mastership := &topoapi.MastershipState{}
+	assert.NoError(t, target.GetAspect(mastership))
+	assert.Equal(t, uint64(1), mastership.Term)
+
+	assert.NoError(t, store.Delete(ctx, relation))
+	_, err = store.Get(ctx, relation.ID)
+	assert.True(t, errors.IsNotFound(err))
+	assert.True(t, errors.IsNotFound(store.Delete(ctx, relation)))
+}
+
+func TestFileStoreWatch(t *testing.T) {
+	store, path := newTestFileStore(t, testTopoFile)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan topoapi.Event)
+	assert.NoError(t, store.Watch(ctx, ch, nil))
+	replayed := map[topoapi.ID]bool{}
+	for i := 0; i < 2; i++ {
+		event := nextTopoEvent(t, ch)
+		assert.Equal(t, topoapi.EventType_NONE, event.Type)
+		replayed[event.Object.ID] = true
+	}
+	assert.True(t, replayed["device-1"])
+	assert.True(t, replayed["device-2"])
+
+	// Runtime aspects are retained when the file changes
+	target, err := store.Get(ctx, "device-1")
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetAspect(&topoapi.MastershipState{Term: 1, NodeId: "onos-config-1"}))
+	assert.NoError(t, store.Update(ctx, target))
+	event := nextTopoEvent(t, ch)
+	assert.Equal(t, topoapi.EventType_UPDATED, event.Type)
+
+	// Change device-1, remove device-2 and add device-3
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+targets:
+  - id: device-1
+    aspects:
+      onos.topo.Configurable:
+        address: device-1:22161
+        type: devicesim
+        version: 1.0.0
+  - id: device-3
+    aspects:
+      onos.topo.Configurable:
+        address: device-3:11161
+        type: devicesim
+        version: 1.0.0
+`), 0644))
+
+	events := map[topoapi.ID]topoapi.Event{}
+	for i := 0; i < 3; i++ {
+		event := nextTopoEvent(t, ch)
+		events[event.Object.ID] = event
+	}
+	assert.Equal(t, topoapi.EventType_UPDATED, events["device-1"].Type)
+	assert.Equal(t, topoapi.EventType_REMOVED, events["device-2"].Type)
+	assert.Equal(t, topoapi.EventType_ADDED, events["device-3"].Type)
+
+	updated := events["device-1"].Object
+	target = &updated
+	configurable := &topoapi.Configurable{}
+	assert.NoError(t, target.GetAspect(configurable))
+	assert.Equal(t, "device-1:22161", configurable.Address)
+	assert.Error(t, target.GetAspect(&topoapi.TLSOptions{}))
+	mastership := &topoapi.MastershipState{}
+	assert.NoError(t, target.GetAspect(mastership))
+	assert.Equal(t, "onos-config-1", mastership.NodeId)
+
+	_, err = store.Get(ctx, "device-2")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestFileStoreSlowWatcher(t *testing.T) {
+	store, _ := newTestFileStore(t, testTopoFile)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A watcher that does not read its channel blocks neither changes nor other watchers
+	slowCh := make(chan topoapi.Event)
+	assert.NoError(t, store.Watch(ctx, slowCh, nil))
+	ch := make(chan topoapi.Event)
+	assert.NoError(t, store.Watch(ctx, ch, nil))
+	nextTopoEvent(t, ch)
+	nextTopoEvent(t, ch)
+
+	for i := 0; i < 100; i++ {
+		target, err := store.Get(ctx, "device-1")
+		assert.NoError(t, err)
+		assert.NoError(t, store.Update(ctx, target))
+	}
+	var revision topoapi.Revision
+	for i := 0; i < 100; i++ {
+		event := nextTopoEvent(t, ch)
+		assert.Greater(t, event.Object.Revision, revision)
+		revision = event.Object.Revision
+	}
+
+	// The channel of the slow watcher is closed once the watch is canceled
+	cancel()
+	for range slowCh {
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"context"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+)
+
+// objectWatcher adapts a channel watching the events of objects matching the given filters to the store's watchers
+type objectWatcher struct {
+	filters *topoapi.Filters
+	ch      chan<- topoapi.Event
+}
+
+func (w *objectWatcher) Match(event interface{}) bool {
+	e := event.(topoapi.Event)
+	return matchFilters(&e.Object, w.filters)
+}
+
+func (w *objectWatcher) Send(ctx context.Context, event interface{}) bool {
+	select {
+	case w.ch <- event.(topoapi.Event):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *objectWatcher) Close() {
+	close(w.ch)
+}
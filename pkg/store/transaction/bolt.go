@@ -0,0 +1,346 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	boltBucket      = []byte("transactions")
+	boltIndexBucket = []byte("transaction-indexes")
+)
+
+// NewBoltStore returns a new Store that persists transactions in the given bbolt database.
+// Each change is committed to disk before it is acknowledged or published to watchers, so the
+// store survives process crashes. The database is owned by the caller and is not closed by the store.
+func NewBoltStore(db *bbolt.DB) (Store, error) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(boltBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(boltIndexBucket); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewInternal("failed to initialize transaction store: %v", err)
+	}
+	store := &boltStore{
+		db:       db,
+		watchers: watchers.New(),
+	}
+	return store, nil
+}
+
+type boltStore struct {
+	db       *bbolt.DB
+	mu       sync.RWMutex
+	watchers *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are committed.
+func (s *boltStore) publishEvent(eventType configapi.TransactionEvent_EventType, entry *memoryEntry) {
+	var transaction configapi.Transaction
+	if err := decodeMemoryEntry(entry, &transaction); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.TransactionEvent{
+		Type:        eventType,
+		Transaction: transaction,
+	})
+}
+
+// Get gets a transaction
+func (s *boltStore) Get(ctx context.Context, id configapi.TransactionID) (*configapi.Transaction, error) {
+	var entry *memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(boltBucket).Get([]byte(id))
+		if value == nil {
+			return errors.NewNotFound("transaction '%s' not found", id)
+		}
+		entry = decodeBoltEntry(id, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	transaction := &configapi.Transaction{}
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return nil, errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	return transaction, nil
+}
+
+// GetByIndex gets a transaction by index
+func (s *boltStore) GetByIndex(ctx context.Context, index configapi.Index) (*configapi.Transaction, error) {
+	var entry *memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		id := tx.Bucket(boltIndexBucket).Get(encodeBoltIndex(index))
+		if id == nil {
+			return errors.NewNotFound("transaction %d not found", index)
+		}
+		value := tx.Bucket(boltBucket).Get(id)
+		if value == nil {
+			return errors.NewNotFound("transaction %d not found", index)
+		}
+		entry = decodeBoltEntry(configapi.TransactionID(id), value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	transaction := &configapi.Transaction{}
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return nil, errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	return transaction, nil
+}
+
+// Create creates a new transaction, appending it to the transaction log
+func (s *boltStore) Create(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeCreate(transaction)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = createEntry(newBoltEntries(tx), transaction.ID, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the transaction from the stored entry.
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.TransactionEvent_CREATED, entry)
+	return nil
+}
+
+// Update updates an existing transaction
+func (s *boltStore) Update(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeUpdate(transaction, true)
+	if err != nil {
+		return err
+	}
+	return s.update(transaction, bytes)
+}
+
+// UpdateStatus updates an existing transaction status
+func (s *boltStore) UpdateStatus(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeUpdate(transaction, false)
+	if err != nil {
+		return err
+	}
+	return s.update(transaction, bytes)
+}
+
+// update replaces the stored transaction, using the transaction version as an optimistic lock
+func (s *boltStore) update(transaction *configapi.Transaction, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entry *memoryEntry
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		entry, err = updateEntry(newBoltEntries(tx), transaction.ID, transaction.Version, bytes)
+		return err
+	})
+	if err != nil {
+		return fromBolt(err)
+	}
+
+	// Decode the transaction from the stored entry.
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.TransactionEvent_UPDATED, entry)
+	return nil
+}
+
+// List lists transactions in index order
+func (s *boltStore) List(ctx context.Context) ([]*configapi.Transaction, error) {
+	entries, err := s.list("")
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*configapi.Transaction, 0, len(entries))
+	for _, entry := range entries {
+		transaction := &configapi.Transaction{}
+		if err := decodeMemoryEntry(entry, transaction); err != nil {
+			log.Error(err)
+		} else {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+// list reads the stored entries in index order, optionally filtered by transaction ID
+func (s *boltStore) list(id configapi.TransactionID) ([]*memoryEntry, error) {
+	var entries []*memoryEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		return tx.Bucket(boltIndexBucket).ForEach(func(_, key []byte) error {
+			if id != "" && configapi.TransactionID(key) != id {
+				return nil
+			}
+			if value := bucket.Get(key); value != nil {
+				entries = append(entries, decodeBoltEntry(configapi.TransactionID(key), value))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fromBolt(err)
+	}
+	return entries, nil
+}
+
+// Watch watches the transaction store for changes
+func (s *boltStore) Watch(ctx context.Context, ch chan<- configapi.TransactionEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current transactions and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		entries, err := s.list(options.transactionID)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			var transaction configapi.Transaction
+			if err := decodeMemoryEntry(entry, &transaction); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.TransactionEvent{
+					Type:        configapi.TransactionEvent_REPLAYED,
+					Transaction: transaction,
+				})
+			}
+		}
+	}
+
+	s.watchers.Add(ctx, &transactionWatcher{id: options.transactionID, ch: ch}, replay)
+	return nil
+}
+
+// Close closes the store
+func (s *boltStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// boltEntries stores entries and their indexes in buckets within a database transaction.
+// The index and revision sequences are only advanced if the transaction is committed.
+type boltEntries struct {
+	bucket  *bbolt.Bucket
+	indexes *bbolt.Bucket
+}
+
+func newBoltEntries(tx *bbolt.Tx) *boltEntries {
+	return &boltEntries{
+		bucket:  tx.Bucket(boltBucket),
+		indexes: tx.Bucket(boltIndexBucket),
+	}
+}
+
+func (e *boltEntries) getEntry(id configapi.TransactionID) *memoryEntry {
+	value := e.bucket.Get([]byte(id))
+	if value == nil {
+		return nil
+	}
+	return decodeBoltEntry(id, value)
+}
+
+func (e *boltEntries) putEntry(entry *memoryEntry) error {
+	if err := e.bucket.Put([]byte(entry.id), encodeBoltEntry(entry)); err != nil {
+		return err
+	}
+	return e.indexes.Put(encodeBoltIndex(entry.index), []byte(entry.id))
+}
+
+func (e *boltEntries) nextIndex() (configapi.Index, error) {
+	index, err := e.indexes.NextSequence()
+	if err != nil {
+		return 0, err
+	}
+	return configapi.Index(index), nil
+}
+
+func (e *boltEntries) nextRevision() (uint64, error) {
+	return e.bucket.NextSequence()
+}
+
+// encodeBoltEntry encodes an entry as its revision and index followed by the transaction bytes
+func encodeBoltEntry(entry *memoryEntry) []byte {
+	bytes := make([]byte, 16+len(entry.value))
+	binary.BigEndian.PutUint64(bytes[0:8], entry.revision)
+	binary.BigEndian.PutUint64(bytes[8:16], uint64(entry.index))
+	copy(bytes[16:], entry.value)
+	return bytes
+}
+
+// decodeBoltEntry decodes an entry read from the database. The value is copied
+// because bbolt values are only valid for the life of the database transaction.
+func decodeBoltEntry(id configapi.TransactionID, bytes []byte) *memoryEntry {
+	value := make([]byte, len(bytes)-16)
+	copy(value, bytes[16:])
+	return &memoryEntry{
+		id:       id,
+		revision: binary.BigEndian.Uint64(bytes[0:8]),
+		index:    configapi.Index(binary.BigEndian.Uint64(bytes[8:16])),
+		value:    value,
+	}
+}
+
+// encodeBoltIndex encodes an index as a big-endian key so that keys sort in index order
+func encodeBoltIndex(index configapi.Index) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(index))
+	return key
+}
+
+// fromBolt passes typed errors through and wraps database errors
+func fromBolt(err error) error {
+	if errors.IsNotFound(err) || errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		return err
+	}
+	return errors.NewInternal("transaction store failed: %v", err)
+}
+
+var _ Store = &boltStore{}
@@ -0,0 +1,84 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestBoltTransactionStore(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "onos-config.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	testTransactionStore(t, store, store)
+}
+
+func TestBoltTransactionStoreSemantics(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "onos-config.db"), 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	testStoreSemantics(t, store)
+}
+
+func TestBoltTransactionStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onos-config.db")
+	db, err := bbolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	store, err := NewBoltStore(db)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	transaction1 := &configapi.Transaction{ID: "transaction-1"}
+	assert.NoError(t, store.Create(ctx, transaction1))
+	transaction1.Status.State = configapi.TransactionStatus_APPLIED
+	assert.NoError(t, store.UpdateStatus(ctx, transaction1))
+	assert.True(t, errors.IsAlreadyExists(store.Create(ctx, &configapi.Transaction{ID: "transaction-1"})))
+	assert.NoError(t, db.Close())
+
+	// Reopen the database and verify the transaction log was persisted
+	db, err = bbolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+	store, err = NewBoltStore(db)
+	assert.NoError(t, err)
+
+	transaction, err := store.GetByIndex(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction1.ID, transaction.ID)
+	assert.Equal(t, transaction1.Version, transaction.Version)
+	assert.Equal(t, configapi.TransactionStatus_APPLIED, transaction.Status.State)
+
+	// New transactions continue the log where it left off
+	transaction2 := &configapi.Transaction{ID: "transaction-2"}
+	assert.NoError(t, store.Create(ctx, transaction2))
+	assert.Equal(t, configapi.Index(2), transaction2.Index)
+	assert.Greater(t, transaction2.Version, transaction1.Version)
+
+	ch := make(chan configapi.TransactionEvent)
+	assert.NoError(t, store.Watch(ctx, ch, WithReplay()))
+	assert.Equal(t, transaction1.ID, nextEvent(t, ch).Transaction.ID)
+	assert.Equal(t, transaction2.ID, nextEvent(t, ch).Transaction.ID)
+}
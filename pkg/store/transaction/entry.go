@@ -0,0 +1,128 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// entryStorage stores the encoded entries of a transaction store. The memory and bolt stores only
+// differ in how entries are stored; indexes, revisions and optimistic locking are implemented once by
+// createEntry and updateEntry so that both backends handle conflicts the same way.
+type entryStorage interface {
+	// getEntry returns the stored entry with the given ID, or nil if the entry does not exist
+	getEntry(id configapi.TransactionID) *memoryEntry
+	// putEntry stores the given entry and indexes it by its index
+	putEntry(entry *memoryEntry) error
+	// nextIndex returns the next index in the transaction log
+	nextIndex() (configapi.Index, error)
+	// nextRevision returns the next revision of the store
+	nextRevision() (uint64, error)
+}
+
+// encodeCreate validates a new transaction, initializes its ID, revision and timestamps and encodes it
+func encodeCreate(transaction *configapi.Transaction) ([]byte, error) {
+	if transaction.ID == "" {
+		transaction.ID = newTransactionID()
+	}
+	if transaction.Version != 0 {
+		return nil, errors.NewInvalid("not a new object")
+	}
+	if transaction.Revision != 0 {
+		return nil, errors.NewInvalid("not a new object")
+	}
+	transaction.Revision = 1
+	transaction.Created = time.Now()
+	transaction.Updated = time.Now()
+	return encodeTransaction(transaction)
+}
+
+// encodeUpdate validates an updated transaction, bumps its timestamp and its revision if requested and encodes it
+func encodeUpdate(transaction *configapi.Transaction, incrementRevision bool) ([]byte, error) {
+	if transaction.Revision == 0 {
+		return nil, errors.NewInvalid("transaction must contain a revision on update")
+	}
+	if transaction.Version == 0 {
+		return nil, errors.NewInvalid("transaction must contain a version on update")
+	}
+	if incrementRevision {
+		transaction.Revision++
+	}
+	transaction.Updated = time.Now()
+	return encodeTransaction(transaction)
+}
+
+func encodeTransaction(transaction *configapi.Transaction) ([]byte, error) {
+	bytes, err := proto.Marshal(transaction)
+	if err != nil {
+		return nil, errors.NewInvalid("transaction encoding failed: %v", err)
+	}
+	return bytes, nil
+}
+
+// createEntry appends a new entry for the given transaction bytes to the transaction log
+func createEntry(storage entryStorage, id configapi.TransactionID, value []byte) (*memoryEntry, error) {
+	if storage.getEntry(id) != nil {
+		return nil, errors.NewAlreadyExists("transaction '%s' already exists", id)
+	}
+	index, err := storage.nextIndex()
+	if err != nil {
+		return nil, err
+	}
+	return putEntry(storage, id, index, value)
+}
+
+// updateEntry replaces the stored entry for the given transaction bytes, using the version as an optimistic lock
+func updateEntry(storage entryStorage, id configapi.TransactionID, version uint64, value []byte) (*memoryEntry, error) {
+	stored := storage.getEntry(id)
+	if stored == nil {
+		return nil, errors.NewNotFound("transaction '%s' not found", id)
+	}
+	if stored.revision != version {
+		return nil, errors.NewConflict("transaction '%s' version %d does not match the stored version %d", id, version, stored.revision)
+	}
+	return putEntry(storage, id, stored.index, value)
+}
+
+func putEntry(storage entryStorage, id configapi.TransactionID, index configapi.Index, value []byte) (*memoryEntry, error) {
+	revision, err := storage.nextRevision()
+	if err != nil {
+		return nil, err
+	}
+	entry := &memoryEntry{
+		id:       id,
+		index:    index,
+		revision: revision,
+		value:    value,
+	}
+	if err := storage.putEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func decodeMemoryEntry(entry *memoryEntry, transaction *configapi.Transaction) error {
+	if err := proto.Unmarshal(entry.value, transaction); err != nil {
+		return err
+	}
+	transaction.ID = entry.id
+	transaction.Index = entry.index
+	transaction.Version = entry.revision
+	return nil
+}
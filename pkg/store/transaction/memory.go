@@ -0,0 +1,234 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+	"sync"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-config/pkg/store/watchers"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// NewMemoryStore returns a new Store that keeps transactions in memory.
+// The store has the same semantics as the Atomix store but is not shared between nodes or persisted.
+func NewMemoryStore() Store {
+	store := &memoryStore{
+		entries:  make(map[configapi.TransactionID]*memoryEntry),
+		indexes:  make(map[configapi.Index]*memoryEntry),
+		watchers: watchers.New(),
+	}
+	return store
+}
+
+// memoryEntry is an encoded transaction in the memory store
+type memoryEntry struct {
+	id       configapi.TransactionID
+	index    configapi.Index
+	revision uint64
+	value    []byte
+}
+
+type memoryStore struct {
+	entries   map[configapi.TransactionID]*memoryEntry
+	indexes   map[configapi.Index]*memoryEntry
+	lastIndex configapi.Index
+	revision  uint64
+	mu        sync.RWMutex
+	watchers  *watchers.Watchers
+}
+
+// publishEvent publishes an event for the given entry. The caller must hold the store's lock
+// to ensure events are published in the order in which changes are made. Events are queued
+// for each watcher, so publishing never blocks on watchers.
+func (s *memoryStore) publishEvent(eventType configapi.TransactionEvent_EventType, entry *memoryEntry) {
+	var transaction configapi.Transaction
+	if err := decodeMemoryEntry(entry, &transaction); err != nil {
+		log.Error(err)
+		return
+	}
+	s.watchers.Publish(configapi.TransactionEvent{
+		Type:        eventType,
+		Transaction: transaction,
+	})
+}
+
+// Get gets a transaction
+func (s *memoryStore) Get(ctx context.Context, id configapi.TransactionID) (*configapi.Transaction, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("transaction '%s' not found", id)
+	}
+	transaction := &configapi.Transaction{}
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return nil, errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	return transaction, nil
+}
+
+// GetByIndex gets a transaction by index
+func (s *memoryStore) GetByIndex(ctx context.Context, index configapi.Index) (*configapi.Transaction, error) {
+	s.mu.RLock()
+	entry, ok := s.indexes[index]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("transaction %d not found", index)
+	}
+	transaction := &configapi.Transaction{}
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return nil, errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	return transaction, nil
+}
+
+// Create creates a new transaction, appending it to the transaction log
+func (s *memoryStore) Create(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeCreate(transaction)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := createEntry(s, transaction.ID, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the transaction from the stored entry.
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.TransactionEvent_CREATED, entry)
+	return nil
+}
+
+// Update updates an existing transaction
+func (s *memoryStore) Update(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeUpdate(transaction, true)
+	if err != nil {
+		return err
+	}
+	return s.update(transaction, bytes)
+}
+
+// UpdateStatus updates an existing transaction status
+func (s *memoryStore) UpdateStatus(ctx context.Context, transaction *configapi.Transaction) error {
+	bytes, err := encodeUpdate(transaction, false)
+	if err != nil {
+		return err
+	}
+	return s.update(transaction, bytes)
+}
+
+// update replaces the stored transaction, using the transaction version as an optimistic lock
+func (s *memoryStore) update(transaction *configapi.Transaction, bytes []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, err := updateEntry(s, transaction.ID, transaction.Version, bytes)
+	if err != nil {
+		return err
+	}
+
+	// Decode the transaction from the stored entry.
+	if err := decodeMemoryEntry(entry, transaction); err != nil {
+		return errors.NewInvalid("transaction decoding failed: %v", err)
+	}
+	s.publishEvent(configapi.TransactionEvent_UPDATED, entry)
+	return nil
+}
+
+// List lists transactions in index order
+func (s *memoryStore) List(ctx context.Context) ([]*configapi.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	transactions := make([]*configapi.Transaction, 0, len(s.entries))
+	for index := configapi.Index(1); index <= s.lastIndex; index++ {
+		entry, ok := s.indexes[index]
+		if !ok {
+			continue
+		}
+		transaction := &configapi.Transaction{}
+		if err := decodeMemoryEntry(entry, transaction); err != nil {
+			log.Error(err)
+		} else {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+// Watch watches the transaction store for changes
+func (s *memoryStore) Watch(ctx context.Context, ch chan<- configapi.TransactionEvent, opts ...WatchOption) error {
+	var options watchOptions
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	// Replay the current transactions and register the watcher atomically with respect to changes
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []interface{}
+	if options.replay {
+		for index := configapi.Index(1); index <= s.lastIndex; index++ {
+			entry, ok := s.indexes[index]
+			if !ok || (options.transactionID != "" && entry.id != options.transactionID) {
+				continue
+			}
+			var transaction configapi.Transaction
+			if err := decodeMemoryEntry(entry, &transaction); err != nil {
+				log.Error(err)
+			} else {
+				replay = append(replay, configapi.TransactionEvent{
+					Type:        configapi.TransactionEvent_REPLAYED,
+					Transaction: transaction,
+				})
+			}
+		}
+	}
+
+	s.watchers.Add(ctx, &transactionWatcher{id: options.transactionID, ch: ch}, replay)
+	return nil
+}
+
+// Close closes the store
+func (s *memoryStore) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *memoryStore) getEntry(id configapi.TransactionID) *memoryEntry {
+	return s.entries[id]
+}
+
+func (s *memoryStore) putEntry(entry *memoryEntry) error {
+	s.entries[entry.id] = entry
+	s.indexes[entry.index] = entry
+	return nil
+}
+
+func (s *memoryStore) nextIndex() (configapi.Index, error) {
+	s.lastIndex++
+	return s.lastIndex, nil
+}
+
+func (s *memoryStore) nextRevision() (uint64, error) {
+	s.revision++
+	return s.revision, nil
+}
+
+var _ Store = &memoryStore{}
@@ -0,0 +1,70 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTransactionStore(t *testing.T) {
+	store := NewMemoryStore()
+	testTransactionStore(t, store, store)
+}
+
+func TestMemoryTransactionStoreSemantics(t *testing.T) {
+	testStoreSemantics(t, NewMemoryStore())
+}
+
+// testStoreSemantics verifies the revision and optimistic locking semantics shared by all backends
+func testStoreSemantics(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	transaction1 := &configapi.Transaction{ID: "transaction-1"}
+	assert.NoError(t, store.Create(ctx, transaction1))
+	assert.Equal(t, configapi.Index(1), transaction1.Index)
+	assert.True(t, errors.IsAlreadyExists(store.Create(ctx, &configapi.Transaction{ID: "transaction-1"})))
+
+	transaction2 := &configapi.Transaction{}
+	assert.NoError(t, store.Create(ctx, transaction2))
+	assert.NotEmpty(t, transaction2.ID)
+	assert.Equal(t, configapi.Index(2), transaction2.Index)
+
+	transaction, err := store.GetByIndex(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction2.ID, transaction.ID)
+	_, err = store.GetByIndex(ctx, 3)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Status updates do not change the revision but do change the version
+	stale, err := store.Get(ctx, "transaction-1")
+	assert.NoError(t, err)
+	transaction1.Status.State = configapi.TransactionStatus_APPLIED
+	version := transaction1.Version
+	assert.NoError(t, store.UpdateStatus(ctx, transaction1))
+	assert.Equal(t, configapi.Revision(1), transaction1.Revision)
+	assert.NotEqual(t, version, transaction1.Version)
+	assert.True(t, errors.IsConflict(store.Update(ctx, stale)))
+
+	ch := make(chan configapi.TransactionEvent)
+	assert.NoError(t, store.Watch(ctx, ch, WithReplay(), WithTransactionID(transaction2.ID)))
+	event := nextEvent(t, ch)
+	assert.Equal(t, configapi.TransactionEvent_REPLAYED, event.Type)
+	assert.Equal(t, transaction2.ID, event.Transaction.ID)
+}
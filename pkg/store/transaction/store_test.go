@@ -47,11 +47,15 @@ func TestTransactionStore(t *testing.T) {
 	store2, err := NewAtomixStore(client2)
 	assert.NoError(t, err)
 
+	testTransactionStore(t, store1, store2)
+}
+
+func testTransactionStore(t *testing.T, store1 Store, store2 Store) {
 	target1 := configapi.TargetID("target-1")
 	target2 := configapi.TargetID("target-2")
 
 	eventCh := make(chan configapi.TransactionEvent)
-	err = store2.Watch(context.Background(), eventCh)
+	err := store2.Watch(context.Background(), eventCh)
 	assert.NoError(t, err)
 
 	transaction1 := &configapi.Transaction{
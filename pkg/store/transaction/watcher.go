@@ -0,0 +1,44 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+
+	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+)
+
+// transactionWatcher adapts a channel watching the events of the transaction with the given ID, or all transactions if the ID is empty to the store's watchers
+type transactionWatcher struct {
+	id configapi.TransactionID
+	ch chan<- configapi.TransactionEvent
+}
+
+func (w *transactionWatcher) Match(event interface{}) bool {
+	return w.id == "" || w.id == event.(configapi.TransactionEvent).Transaction.ID
+}
+
+func (w *transactionWatcher) Send(ctx context.Context, event interface{}) bool {
+	select {
+	case w.ch <- event.(configapi.TransactionEvent):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *transactionWatcher) Close() {
+	close(w.ch)
+}
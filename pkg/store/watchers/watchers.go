@@ -0,0 +1,120 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchers implements the watchers of the local stores. Events are queued for each watcher without
+// blocking, so stores can publish events while holding their lock to preserve the order of changes, and
+// a slow watcher blocks neither changes to the store nor other watchers.
+package watchers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Adapter adapts the events of a store to the typed channel of a watcher
+type Adapter interface {
+	// Match returns whether the given event is to be sent to the watcher
+	Match(event interface{}) bool
+	// Send sends the given event to the watcher's channel, returning false if the context is canceled first
+	Send(ctx context.Context, event interface{}) bool
+	// Close closes the watcher's channel
+	Close()
+}
+
+// Watchers are the watchers of a store
+type Watchers struct {
+	watchers map[uuid.UUID]*watcher
+	mu       sync.RWMutex
+}
+
+// New returns a new set of watchers
+func New() *Watchers {
+	return &Watchers{
+		watchers: make(map[uuid.UUID]*watcher),
+	}
+}
+
+// Add adds a watcher receiving the given replayed events followed by the published events matched by the
+// given adapter. The watcher's channel is closed once the context is canceled.
+// To avoid missing or duplicating events, the caller must hold the lock under which events are published.
+func (w *Watchers) Add(ctx context.Context, adapter Adapter, replay []interface{}) {
+	watcher := &watcher{
+		adapter:  adapter,
+		events:   replay,
+		notifyCh: make(chan struct{}, 1),
+	}
+	watcherID := uuid.New()
+	w.mu.Lock()
+	w.watchers[watcherID] = watcher
+	w.mu.Unlock()
+
+	go func() {
+		watcher.run(ctx)
+		w.mu.Lock()
+		delete(w.watchers, watcherID)
+		w.mu.Unlock()
+		adapter.Close()
+	}()
+}
+
+// Publish queues the event for each watcher matching it
+func (w *Watchers) Publish(event interface{}) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, watcher := range w.watchers {
+		if watcher.adapter.Match(event) {
+			watcher.push(event)
+		}
+	}
+}
+
+// watcher is a watcher with an unbounded queue of events
+type watcher struct {
+	adapter  Adapter
+	events   []interface{}
+	mu       sync.Mutex
+	notifyCh chan struct{}
+}
+
+func (w *watcher) push(event interface{}) {
+	w.mu.Lock()
+	w.events = append(w.events, event)
+	w.mu.Unlock()
+	select {
+	case w.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// run sends the queued events to the watcher's channel until the context is canceled
+func (w *watcher) run(ctx context.Context) {
+	for {
+		w.mu.Lock()
+		events := w.events
+		w.events = nil
+		w.mu.Unlock()
+		for _, event := range events {
+			if !w.adapter.Send(ctx, event) {
+				return
+			}
+		}
+		select {
+		case <-w.notifyCh:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2022-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testWatcher watches the even or odd integers published to the watchers
+type testWatcher struct {
+	odd bool
+	ch  chan int
+}
+
+func (w *testWatcher) Match(event interface{}) bool {
+	return (event.(int)%2 == 1) == w.odd
+}
+
+func (w *testWatcher) Send(ctx context.Context, event interface{}) bool {
+	select {
+	case w.ch <- event.(int):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *testWatcher) Close() {
+	close(w.ch)
+}
+
+func nextEvent(t *testing.T, ch chan int) int {
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return 0
+	}
+}
+
+func TestWatchers(t *testing.T) {
+	w := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	evenCh := make(chan int)
+	oddCh := make(chan int)
+	w.Add(ctx, &testWatcher{ch: evenCh}, []interface{}{-2})
+	w.Add(ctx, &testWatcher{odd: true, ch: oddCh}, nil)
+
+	// Publishing does not block on watchers that are not receiving
+	for i := 0; i < 100; i++ {
+		w.Publish(i)
+	}
+
+	// Each watcher receives the replayed events followed by the matching events in order
+	assert.Equal(t, -2, nextEvent(t, evenCh))
+	for i := 0; i < 100; i += 2 {
+		assert.Equal(t, i, nextEvent(t, evenCh))
+	}
+	for i := 1; i < 100; i += 2 {
+		assert.Equal(t, i, nextEvent(t, oddCh))
+	}
+
+	// Watchers are removed and their channels closed once the context is canceled
+	cancel()
+	_, ok := <-evenCh
+	assert.False(t, ok)
+	_, ok = <-oddCh
+	assert.False(t, ok)
+	assert.Eventually(t, func() bool {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		return len(w.watchers) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
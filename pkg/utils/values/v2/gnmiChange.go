@@ -15,12 +15,17 @@
 package values
 
 import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
 
 	"github.com/onosproject/onos-config/pkg/utils"
 	pathutils "github.com/onosproject/onos-config/pkg/utils/path"
+	"github.com/onosproject/onos-config/pkg/utils/tree"
 	"github.com/openconfig/gnmi/proto/gnmi"
 )
 
@@ -71,3 +76,205 @@ func PathValuesToGnmiChange(values []*configapi.PathValue) (*gnmi.SetRequest, er
 
 	return &setRequest, nil
 }
+
+// PathValuesToGnmiChangeWithEncoding converts the given path values to a gNMI SetRequest using the given encoding.
+// With PROTO encoding, each leaf is updated with a scalar typed value. With JSON or JSON_IETF encoding, the updated
+// leaves are grouped into a single JSON subtree per top-level container.
+func PathValuesToGnmiChangeWithEncoding(values []*configapi.PathValue, encoding gnmi.Encoding) (*gnmi.SetRequest, error) {
+	switch encoding {
+	case gnmi.Encoding_PROTO:
+		return PathValuesToGnmiChange(values)
+	case gnmi.Encoding_JSON, gnmi.Encoding_JSON_IETF:
+	default:
+		return nil, errors.NewNotSupported("encoding %s is not supported", encoding)
+	}
+
+	var deletePaths []*gnmi.Path
+	var containers []string
+	containerValues := make(map[string][]*configapi.PathValue)
+	for _, pathValue := range values {
+		elems := utils.SplitPath(pathValue.Path)
+		pathElemsRefs, err := utils.ParseGNMIElements(elems)
+		if err != nil {
+			return nil, err
+		}
+
+		if pathValue.Deleted {
+			deletePaths = append(deletePaths, &gnmi.Path{Elem: pathElemsRefs.Elem})
+		} else if len(pathElemsRefs.Elem) > 0 {
+			container := pathElemsRefs.Elem[0].Name
+			if _, ok := containerValues[container]; !ok {
+				containers = append(containers, container)
+			}
+			containerValues[container] = append(containerValues[container], pathValue)
+		}
+	}
+
+	updatedPaths := make([]*gnmi.Update, 0, len(containers))
+	for _, container := range containers {
+		jsonTree, err := tree.BuildTree(containerValues[container], encoding == gnmi.Encoding_JSON_IETF)
+		if err != nil {
+			return nil, errors.NewInvalid("error building configuration tree for %s: %s", container, err)
+		}
+
+		// Extract the subtree for the container from the tree built from the root
+		var root map[string]json.RawMessage
+		if err := json.Unmarshal(jsonTree, &root); err != nil {
+			return nil, errors.NewInvalid("error building configuration tree for %s: %s", container, err)
+		}
+		subtree, ok := root[container]
+		if !ok {
+			continue
+		}
+
+		var value *gnmi.TypedValue
+		if encoding == gnmi.Encoding_JSON_IETF {
+			value = &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: subtree}}
+		} else {
+			value = &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonVal{JsonVal: subtree}}
+		}
+		updatedPaths = append(updatedPaths, &gnmi.Update{
+			Path: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: container}}},
+			Val:  value,
+		})
+	}
+
+	return &gnmi.SetRequest{
+		Delete: deletePaths,
+		Update: updatedPaths,
+	}, nil
+}
+
+// PathValuesToGnmiReplace creates a gNMI SetRequest replacing the entire configuration of a target with the
+// given path values. The values are encoded as a single JSON or JSON_IETF tree replacing the root path.
+func PathValuesToGnmiReplace(values []*configapi.PathValue, encoding gnmi.Encoding) (*gnmi.SetRequest, error) {
+	var value *gnmi.TypedValue
+	switch encoding {
+	case gnmi.Encoding_JSON:
+		jsonTree, err := tree.BuildTree(values, false)
+		if err != nil {
+			return nil, errors.NewInvalid("error building configuration tree: %s", err)
+		}
+		value = &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonVal{JsonVal: jsonTree}}
+	case gnmi.Encoding_JSON_IETF:
+		jsonTree, err := tree.BuildTree(values, true)
+		if err != nil {
+			return nil, errors.NewInvalid("error building configuration tree: %s", err)
+		}
+		value = &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: jsonTree}}
+	default:
+		return nil, errors.NewNotSupported("encoding %s is not supported to replace the configuration", encoding)
+	}
+	return &gnmi.SetRequest{
+		Replace: []*gnmi.Update{
+			{
+				Path: &gnmi.Path{},
+				Val:  value,
+			},
+		},
+	}, nil
+}
+
+// SplitSetRequest splits the given gNMI SetRequest into an ordered list of requests, each containing at most
+// maxUpdates operations and at most maxBytes bytes of encoded operations. A limit of 0 is treated as unlimited.
+// The prefix and extensions of the given request are copied to each request.
+// Deletes are ordered before replaces, and replaces before updates, preserving the order in which a target
+// processes the operations of a single request. An operation larger than maxBytes is sent in a request alone.
+func SplitSetRequest(request *gnmi.SetRequest, maxUpdates int, maxBytes int) []*gnmi.SetRequest {
+	if maxUpdates <= 0 && maxBytes <= 0 {
+		return []*gnmi.SetRequest{request}
+	}
+
+	var requests []*gnmi.SetRequest
+	chunk := &gnmi.SetRequest{Prefix: request.Prefix, Extension: request.Extension}
+	chunkUpdates := 0
+	chunkBytes := 0
+	add := func(size int, addTo func(*gnmi.SetRequest)) {
+		if chunkUpdates > 0 && ((maxUpdates > 0 && chunkUpdates+1 > maxUpdates) || (maxBytes > 0 && chunkBytes+size > maxBytes)) {
+			requests = append(requests, chunk)
+			chunk = &gnmi.SetRequest{Prefix: request.Prefix, Extension: request.Extension}
+			chunkUpdates = 0
+			chunkBytes = 0
+		}
+		addTo(chunk)
+		chunkUpdates++
+		chunkBytes += size
+	}
+
+	for _, path := range request.Delete {
+		path := path
+		add(proto.Size(path), func(chunk *gnmi.SetRequest) {
+			chunk.Delete = append(chunk.Delete, path)
+		})
+	}
+	for _, update := range request.Replace {
+		update := update
+		add(proto.Size(update), func(chunk *gnmi.SetRequest) {
+			chunk.Replace = append(chunk.Replace, update)
+		})
+	}
+	for _, update := range request.Update {
+		update := update
+		add(proto.Size(update), func(chunk *gnmi.SetRequest) {
+			chunk.Update = append(chunk.Update, update)
+		})
+	}
+	if chunkUpdates > 0 || len(requests) == 0 {
+		requests = append(requests, chunk)
+	}
+	return requests
+}
+
+// JSONDecoder extracts typed path values from a JSON tree rooted at the given path prefix
+type JSONDecoder func(pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error)
+
+// GnmiNotificationsToPathValues converts the updates in the given gNMI notifications to path values.
+// Scalar values are typed using the given read/write path map; JSON values are decoded with the given decoder.
+func GnmiNotificationsToPathValues(notifications []*gnmi.Notification, rwPaths pathutils.ReadWritePathMap, decodeJSON JSONDecoder) ([]*configapi.PathValue, error) {
+	pathValues := make([]*configapi.PathValue, 0)
+	for _, notification := range notifications {
+		prefixPath := ""
+		if notification.Prefix != nil {
+			prefixPath = strings.TrimSuffix(utils.StrPath(notification.Prefix), "/")
+		}
+		for _, update := range notification.Update {
+			path := prefixPath + strings.TrimSuffix(utils.StrPath(update.Path), "/")
+
+			var jsonVal []byte
+			switch value := update.GetVal().GetValue().(type) {
+			case *gnmi.TypedValue_JsonVal:
+				jsonVal = value.JsonVal
+			case *gnmi.TypedValue_JsonIetfVal:
+				jsonVal = value.JsonIetfVal
+			}
+
+			if jsonVal != nil {
+				if decodeJSON == nil {
+					return nil, errors.NewNotSupported("cannot decode JSON value for path %s", path)
+				}
+				jsonPathValues, err := decodeJSON(path, jsonVal)
+				if err != nil {
+					return nil, err
+				}
+				pathValues = append(pathValues, jsonPathValues...)
+				continue
+			}
+
+			var rwPathElem *pathutils.ReadWritePathElem
+			if rwPaths != nil {
+				if _, rwPath, err := pathutils.FindPathFromModel(path, rwPaths, true); err == nil {
+					rwPathElem = rwPath
+				}
+			}
+			value, err := GnmiTypedValueToNativeType(update.Val, rwPathElem)
+			if err != nil {
+				return nil, errors.NewInvalid("error converting %s: %s", path, err)
+			}
+			pathValues = append(pathValues, &configapi.PathValue{
+				Path:  path,
+				Value: *value,
+			})
+		}
+	}
+	return pathValues, nil
+}
@@ -24,6 +24,7 @@ import (
 	pathutils "github.com/onosproject/onos-config/pkg/utils/path"
 
 	configapi "github.com/onosproject/onos-api/go/onos/config/v2"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/stretchr/testify/assert"
@@ -338,3 +339,144 @@ func Test_NativeBoolToGnmi(t *testing.T) {
 
 	assert.Equal(t, gnmiBool.GetBoolVal(), true)
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// gNMI notifications to path values
+////////////////////////////////////////////////////////////////////////////////
+
+func Test_GnmiNotificationsToPathValues(t *testing.T) {
+	notifications := []*gnmi.Notification{
+		{
+			Prefix: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: "system"}}},
+			Update: []*gnmi.Update{
+				{
+					Path: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: "config"}, {Name: "hostname"}}},
+					Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: testString}},
+				},
+				{
+					Path: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: "clock"}}},
+					Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonVal{JsonVal: []byte(`{"timezone-name": "UTC"}`)}},
+				},
+			},
+		},
+	}
+
+	_, err := GnmiNotificationsToPathValues(notifications, nil, nil)
+	assert.Error(t, err)
+
+	decodeJSON := func(pathPrefix string, jsonData []byte) ([]*configapi.PathValue, error) {
+		assert.Equal(t, "/system/clock", pathPrefix)
+		return []*configapi.PathValue{
+			{
+				Path:  pathPrefix + "/timezone-name",
+				Value: *configapi.NewTypedValueString("UTC"),
+			},
+		}, nil
+	}
+	pathValues, err := GnmiNotificationsToPathValues(notifications, nil, decodeJSON)
+	assert.NoError(t, err)
+	assert.Len(t, pathValues, 2)
+	assert.Equal(t, "/system/config/hostname", pathValues[0].Path)
+	assert.Equal(t, testString, pathValues[0].Value.ValueToString())
+	assert.Equal(t, "/system/clock/timezone-name", pathValues[1].Path)
+	assert.Equal(t, "UTC", pathValues[1].Value.ValueToString())
+}
+
+func Test_PathValuesToGnmiReplace(t *testing.T) {
+	values := []*configapi.PathValue{
+		{
+			Path:  "/system/config/hostname",
+			Value: *configapi.NewTypedValueString(testString),
+		},
+		{
+			Path:    "/system/clock",
+			Deleted: true,
+		},
+	}
+	setRequest, err := PathValuesToGnmiReplace(values, gnmi.Encoding_JSON_IETF)
+	assert.NoError(t, err)
+	assert.Len(t, setRequest.Delete, 0)
+	assert.Len(t, setRequest.Update, 0)
+	assert.Len(t, setRequest.Replace, 1)
+	assert.Len(t, setRequest.Replace[0].Path.Elem, 0)
+	assert.JSONEq(t, `{"system": {"config": {"hostname": "This is a test"}}}`, string(setRequest.Replace[0].Val.GetJsonIetfVal()))
+
+	setRequest, err = PathValuesToGnmiReplace(values, gnmi.Encoding_JSON)
+	assert.NoError(t, err)
+	assert.Len(t, setRequest.Replace, 1)
+	assert.JSONEq(t, `{"system": {"config": {"hostname": "This is a test"}}}`, string(setRequest.Replace[0].Val.GetJsonVal()))
+
+	_, err = PathValuesToGnmiReplace(values, gnmi.Encoding_PROTO)
+	assert.True(t, errors.IsNotSupported(err))
+}
+
+func Test_PathValuesToGnmiChangeWithEncoding(t *testing.T) {
+	values := []*configapi.PathValue{
+		{
+			Path:  "/system/config/hostname",
+			Value: *configapi.NewTypedValueString(testString),
+		},
+		{
+			Path:  "/interfaces/interface[name=eth1]/config/mtu",
+			Value: *configapi.NewTypedValueUint(1500, 16),
+		},
+		{
+			Path:    "/system/clock",
+			Deleted: true,
+		},
+	}
+
+	setRequest, err := PathValuesToGnmiChangeWithEncoding(values, gnmi.Encoding_PROTO)
+	assert.NoError(t, err)
+	assert.Len(t, setRequest.Delete, 1)
+	assert.Len(t, setRequest.Update, 2)
+
+	setRequest, err = PathValuesToGnmiChangeWithEncoding(values, gnmi.Encoding_JSON_IETF)
+	assert.NoError(t, err)
+	assert.Len(t, setRequest.Delete, 1)
+	assert.Len(t, setRequest.Update, 2)
+	assert.Equal(t, "system", setRequest.Update[0].Path.Elem[0].Name)
+	assert.JSONEq(t, `{"config": {"hostname": "This is a test"}}`, string(setRequest.Update[0].Val.GetJsonIetfVal()))
+	assert.Equal(t, "interfaces", setRequest.Update[1].Path.Elem[0].Name)
+	assert.NotNil(t, setRequest.Update[1].Val.GetJsonIetfVal())
+
+	_, err = PathValuesToGnmiChangeWithEncoding(values, gnmi.Encoding_ASCII)
+	assert.Error(t, err)
+}
+
+func Test_SplitSetRequest(t *testing.T) {
+	newPath := func(name string) *gnmi.Path {
+		return &gnmi.Path{Elem: []*gnmi.PathElem{{Name: name}}}
+	}
+	newUpdate := func(name string) *gnmi.Update {
+		return &gnmi.Update{
+			Path: newPath(name),
+			Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: testString}},
+		}
+	}
+	request := &gnmi.SetRequest{
+		Delete:  []*gnmi.Path{newPath("a"), newPath("b")},
+		Replace: []*gnmi.Update{newUpdate("c")},
+		Update:  []*gnmi.Update{newUpdate("d"), newUpdate("e")},
+	}
+
+	requests := SplitSetRequest(request, 0, 0)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, request, requests[0])
+
+	requests = SplitSetRequest(request, 2, 0)
+	assert.Len(t, requests, 3)
+	assert.Len(t, requests[0].Delete, 2)
+	assert.Len(t, requests[1].Replace, 1)
+	assert.Len(t, requests[1].Update, 1)
+	assert.Equal(t, "d", requests[1].Update[0].Path.Elem[0].Name)
+	assert.Len(t, requests[2].Update, 1)
+	assert.Equal(t, "e", requests[2].Update[0].Path.Elem[0].Name)
+
+	// Each update is larger than the limit and must be sent alone
+	requests = SplitSetRequest(request, 0, 1)
+	assert.Len(t, requests, 5)
+	for _, r := range requests {
+		assert.Equal(t, 1, len(r.Delete)+len(r.Replace)+len(r.Update))
+	}
+}